@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/chat-socio/backend/configuration"
 	"github.com/chat-socio/backend/infrastructure/fcm"
@@ -27,12 +28,20 @@ import (
 )
 
 type Handler struct {
-	UserHandler         *handler.UserHandler
-	ConversationHandler *handler.ConversationHandler
-	Middleware          *middleware.Middleware
-	WebSocketHandler    *handler.WebSocketHandler
-	UploadHandler       *handler.UploadHandler
-	FCMHandler          *handler.FCMHandler
+	UserHandler             *handler.UserHandler
+	ConversationHandler     *handler.ConversationHandler
+	Middleware              *middleware.Middleware
+	WebSocketHandler        *handler.WebSocketHandler
+	UploadHandler           *handler.UploadHandler
+	FCMHandler              *handler.FCMHandler
+	BotHandler              *handler.BotHandler
+	WebhookHandler          *handler.WebhookHandler
+	PollHandler             *handler.PollHandler
+	LocationHandler         *handler.LocationHandler
+	StickerHandler          *handler.StickerHandler
+	PinHandler              *handler.PinHandler
+	ScheduledMessageHandler *handler.ScheduledMessageHandler
+	InviteHandler           *handler.InviteHandler
 }
 
 func CreateStream(js natsjs.JetStreamContext) error {
@@ -64,6 +73,25 @@ func CreateStream(js natsjs.JetStreamContext) error {
 		return err
 	}
 
+	// the user stream carries long running jobs, keep it across restarts
+	_, err = js.AddStream(&natsjs.StreamConfig{
+		Name:     domain.STREAM_NAME_USER,
+		Subjects: []string{domain.SUBJECT_WILDCARD_USER},
+	})
+	if err != nil {
+		return err
+	}
+
+	// bots keep durable consumers on the bot stream, keep it across restarts
+	_, err = js.AddStream(&natsjs.StreamConfig{
+		Name:     domain.STREAM_NAME_BOT,
+		Subjects: []string{domain.SUBJECT_WILDCARD_BOT},
+		MaxAge:   time.Hour,
+	})
+	if err != nil {
+		return err
+	}
+
 	return nil
 
 }
@@ -123,24 +151,54 @@ func RunApp() {
 	userOnlineRepository := postgresql.NewUserOnlineRepository(db)
 	seenMessageRepository := postgresql.NewSeenMessageRepository(db, observability)
 	fcmRepository := postgresql.NewFcmRepository(db, observability)
+	contactRepository := postgresql.NewContactRepository(db, observability)
+	accountDeletionRepository := postgresql.NewAccountDeletionRepository(db, observability)
+	dataExportRepository := postgresql.NewDataExportRepository(db, observability)
+	botRepository := postgresql.NewBotRepository(db, observability)
+	apiKeyRepository := postgresql.NewApiKeyRepository(db, observability)
+	webhookRepository := postgresql.NewWebhookRepository(db, observability)
+	incomingWebhookRepository := postgresql.NewIncomingWebhookRepository(db, observability)
+	botCommandRepository := postgresql.NewBotCommandRepository(db, observability)
+	pollVoteRepository := postgresql.NewPollVoteRepository(db, observability)
+	liveLocationRepository := postgresql.NewLiveLocationRepository(db, observability)
+	stickerRepository := postgresql.NewStickerRepository(db, observability)
+	pinnedMessageRepository := postgresql.NewPinnedMessageRepository(db, observability)
+	conversationInviteRepository := postgresql.NewConversationInviteRepository(db, observability)
+	unreadCountRepository := postgresql.NewUnreadCountRepository(db, observability)
+	messageDeliveryRepository := postgresql.NewMessageDeliveryRepository(db, observability)
+	scheduledMessageRepository := postgresql.NewScheduledMessageRepository(db, observability)
 
 	// Initialize publisher
 	messagePublisher := nats.NewPublisher(js)
 
 	// Initialize use cases
 	userUseCase := usecase.NewUserUseCase(accountRepository, userRepository, sessionRepository, sessionCacheRepository, userCacheRepository, observability)
-	conversationUseCase := usecase.NewConversationUseCase(conversationRepository, messageRepository, messagePublisher, userOnlineRepository, userRepository, seenMessageRepository, fcmRepository, observability, fcmClient)
+	conversationUseCase := usecase.NewConversationUseCase(conversationRepository, messageRepository, messagePublisher, userOnlineRepository, userRepository, seenMessageRepository, fcmRepository, botCommandRepository, pollVoteRepository, contactRepository, stickerRepository, pinnedMessageRepository, unreadCountRepository, messageDeliveryRepository, observability, fcmClient)
 	userOnlineUseCase := usecase.NewUserOnlineUsecase(userOnlineRepository)
 	fcmUseCase := usecase.NewFCMUseCase(fcmRepository, observability)
+	accountDeletionUseCase := usecase.NewAccountDeletionUseCase(accountRepository, userRepository, sessionRepository, sessionCacheRepository, botRepository, apiKeyRepository, fcmRepository, contactRepository, userOnlineRepository, seenMessageRepository, messageRepository, accountDeletionRepository, messagePublisher, observability)
+	dataExportUseCase := usecase.NewDataExportUseCase(userRepository, sessionRepository, contactRepository, conversationRepository, messageRepository, dataExportRepository, storage, messagePublisher, observability)
+	botUseCase := usecase.NewBotUseCase(userRepository, botRepository, apiKeyRepository, conversationRepository, botCommandRepository, messagePublisher, observability)
+	webhookUseCase := usecase.NewWebhookUseCase(userRepository, conversationRepository, webhookRepository, messagePublisher, observability)
+	incomingWebhookUseCase := usecase.NewIncomingWebhookUseCase(userRepository, conversationRepository, botRepository, incomingWebhookRepository, conversationUseCase, messagePublisher, observability)
+	pollUseCase := usecase.NewPollUseCase(conversationRepository, messageRepository, pollVoteRepository, conversationUseCase, messagePublisher, observability)
+	locationUseCase := usecase.NewLocationUseCase(conversationRepository, messageRepository, liveLocationRepository, conversationUseCase, messagePublisher, observability)
+	stickerUseCase := usecase.NewStickerUseCase(stickerRepository, storage, observability)
+	pinUseCase := usecase.NewPinUseCase(conversationRepository, messageRepository, pinnedMessageRepository, conversationUseCase, messagePublisher, observability)
+	inviteUseCase := usecase.NewInviteUseCase(conversationRepository, conversationInviteRepository, userRepository, conversationUseCase, messagePublisher, observability)
+	scheduledMessageUseCase := usecase.NewScheduledMessageUseCase(conversationRepository, scheduledMessageRepository, conversationUseCase, postgresql.NewAdvisoryLock(db, observability, "scheduled_message_sender"), messagePublisher, observability)
+	messageExpiryUseCase := usecase.NewMessageExpiryUseCase(messageRepository, unreadCountRepository, storage, messagePublisher, observability)
 
 	// Initialize the handler
 	handler := &Handler{
 		UserHandler: &handler.UserHandler{
-			UserUseCase: userUseCase,
-			Obs:         observability,
+			UserUseCase:            userUseCase,
+			AccountDeletionUseCase: accountDeletionUseCase,
+			DataExportUseCase:      dataExportUseCase,
+			Obs:                    observability,
 		},
 
-		Middleware: middleware.NewMiddleware(sessionCacheRepository, sessionRepository),
+		Middleware: middleware.NewMiddleware(sessionCacheRepository, sessionRepository, apiKeyRepository),
 		WebSocketHandler: handler.NewWebSocketHandler(&websocket.HertzUpgrader{
 			CheckOrigin: func(c *app.RequestContext) bool {
 				return true
@@ -160,6 +218,45 @@ func RunApp() {
 			UserUseCase: userUseCase,
 			Obs:         observability,
 		},
+		BotHandler: &handler.BotHandler{
+			BotUseCase: botUseCase,
+			Obs:        observability,
+		},
+		WebhookHandler: &handler.WebhookHandler{
+			WebhookUseCase:         webhookUseCase,
+			IncomingWebhookUseCase: incomingWebhookUseCase,
+			Obs:                    observability,
+		},
+		PollHandler: &handler.PollHandler{
+			PollUseCase: pollUseCase,
+			UserUseCase: userUseCase,
+			Obs:         observability,
+		},
+		LocationHandler: &handler.LocationHandler{
+			LocationUseCase: locationUseCase,
+			UserUseCase:     userUseCase,
+			Obs:             observability,
+		},
+		StickerHandler: &handler.StickerHandler{
+			StickerUseCase: stickerUseCase,
+			UserUseCase:    userUseCase,
+			Obs:            observability,
+		},
+		PinHandler: &handler.PinHandler{
+			PinUseCase:  pinUseCase,
+			UserUseCase: userUseCase,
+			Obs:         observability,
+		},
+		ScheduledMessageHandler: &handler.ScheduledMessageHandler{
+			ScheduledMessageUseCase: scheduledMessageUseCase,
+			UserUseCase:             userUseCase,
+			Obs:                     observability,
+		},
+		InviteHandler: &handler.InviteHandler{
+			InviteUseCase: inviteUseCase,
+			UserUseCase:   userUseCase,
+			Obs:           observability,
+		},
 	}
 
 	// Init subscriber
@@ -181,18 +278,62 @@ func RunApp() {
 		panic(err)
 	}
 
+	MessageDeliveredSubscriber := nats.NewQueueSubscriber(js, domain.QUEUE_NAME_MESSAGE_DELIVERED, domain.CONSUMER_NAME_MESSAGE_DELIVERED)
+	err = MessageDeliveredSubscriber.Subscribe(ctx, domain.SUBJECT_MESSAGE_DELIVERED, nats.WrapHandler(conversationUseCase.HandleMessageDelivered))
+	if err != nil {
+		panic(err)
+	}
+
 	FcmMessageSubscriber := nats.NewQueueSubscriber(js, domain.QUEUE_NAME_FCM_MESSAGE, domain.CONSUMER_NAME_FCM_MESSAGE)
 	err = FcmMessageSubscriber.Subscribe(ctx, domain.SUBJECT_FCM_MESSAGE, nats.WrapHandler(conversationUseCase.HandleSendMessageToFCM))
 	if err != nil {
 		panic(err)
 	}
 
+	DeleteAccountSubscriber := nats.NewQueueSubscriber(js, domain.QUEUE_NAME_DELETE_ACCOUNT, domain.CONSUMER_NAME_DELETE_ACCOUNT)
+	err = DeleteAccountSubscriber.Subscribe(ctx, domain.SUBJECT_DELETE_ACCOUNT, nats.WrapHandler(accountDeletionUseCase.HandleDeleteAccount))
+	if err != nil {
+		panic(err)
+	}
+	err = accountDeletionUseCase.ResumeAccountDeletionJobs(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	ExportDataSubscriber := nats.NewQueueSubscriber(js, domain.QUEUE_NAME_EXPORT_DATA, domain.CONSUMER_NAME_EXPORT_DATA)
+	err = ExportDataSubscriber.Subscribe(ctx, domain.SUBJECT_EXPORT_DATA, nats.WrapHandler(dataExportUseCase.HandleExportData))
+	if err != nil {
+		panic(err)
+	}
+
+	WebhookEventSubscriber := nats.NewQueueSubscriber(js, domain.QUEUE_NAME_WEBHOOK_EVENT, domain.CONSUMER_NAME_WEBHOOK_EVENT)
+	err = WebhookEventSubscriber.Subscribe(ctx, domain.SUBJECT_NEW_MESSAGE, nats.WrapHandler(webhookUseCase.HandleConversationEvent))
+	if err != nil {
+		panic(err)
+	}
+
+	WebhookDeliverySubscriber := nats.NewQueueSubscriber(js, domain.QUEUE_NAME_WEBHOOK_DELIVERY, domain.CONSUMER_NAME_WEBHOOK_DELIVERY)
+	err = WebhookDeliverySubscriber.Subscribe(ctx, domain.SUBJECT_WEBHOOK_DELIVERY, nats.WrapHandler(webhookUseCase.HandleWebhookDelivery))
+	if err != nil {
+		panic(err)
+	}
+	go webhookUseCase.RunWebhookRetry(ctx)
+	go locationUseCase.RunLiveLocationExpiry(ctx)
+	go scheduledMessageUseCase.RunScheduledMessageSender(ctx)
+	go messageExpiryUseCase.RunMessageExpiry(ctx)
+
+	BotCommandReplySubscriber := nats.NewQueueSubscriber(js, domain.QUEUE_NAME_BOT_COMMAND_REPLY, domain.CONSUMER_NAME_BOT_COMMAND_REPLY)
+	err = BotCommandReplySubscriber.Subscribe(ctx, domain.SUBJECT_BOT_COMMAND_REPLY, nats.WrapHandler(conversationUseCase.HandleBotCommandReply))
+	if err != nil {
+		panic(err)
+	}
+
 	// Initialize the server
 	s := http.NewServer(configuration.ConfigInstance.Server)
 	s.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"*"},
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
+		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", middleware.ApiKeyHeader},
 		ExposeHeaders:    []string{"Content-Length", "Authorization"},
 		AllowCredentials: true,
 	}))
@@ -221,30 +362,106 @@ func SetUpRoutes(s *server.Hertz, handler *Handler) {
 	// Route not use auth middleware
 	s.POST(("/user/register"), handler.UserHandler.Register)
 	s.POST(("/user/login"), handler.UserHandler.Login)
+	s.POST("/hooks/:token", handler.WebhookHandler.PostIncomingWebhook)
 	// Route use auth middleware
 	authGroup := s.Group("/auth")
 	authGroup.Use(handler.Middleware.AuthMiddleware())
 	authGroup.GET("/user/info", handler.UserHandler.GetMyInfo)
 	authGroup.GET("/user/search", handler.UserHandler.GetListUser)
 	authGroup.PATCH("/user", handler.UserHandler.UpdateUser)
+	authGroup.POST("/user/logout", handler.UserHandler.Logout)
+	authGroup.DELETE("/user", handler.UserHandler.DeleteAccount)
+	authGroup.POST("/user/export", handler.UserHandler.ExportData)
+	authGroup.GET("/user/export", handler.UserHandler.GetDataExport)
 	// Conversation
 	authGroup.GET("/conversation", handler.ConversationHandler.GetListConversation)
 	authGroup.POST("/conversation", handler.ConversationHandler.CreateConversation)
+	authGroup.PATCH("/conversation", handler.ConversationHandler.UpdateConversation)
+	authGroup.PUT("/conversation/message-ttl", handler.ConversationHandler.UpdateMessageTTL)
+	authGroup.GET("/conversation/unread", handler.ConversationHandler.GetTotalUnreadCount)
+	authGroup.POST("/conversation/mark-unread", handler.ConversationHandler.MarkUnread)
+	authGroup.POST("/conversation/mark-all-read", handler.ConversationHandler.MarkAllRead)
+	authGroup.PATCH("/conversation/settings", handler.ConversationHandler.UpdateConversationSettings)
+	authGroup.PUT("/conversation/pinned", handler.ConversationHandler.ReorderPinnedConversations)
+	authGroup.DELETE("/conversation/member", handler.ConversationHandler.RemoveConversationMember)
 	// authGroup.GET("/conversation/:conversation_id", handler.ConversationHandler.GetConversationByID)
 
 	// Message
 	authGroup.POST("/message", handler.ConversationHandler.SendMessage)
 	authGroup.GET("/message", handler.ConversationHandler.GetListMessage)
+	authGroup.PATCH("/message", handler.ConversationHandler.EditMessage)
+	authGroup.GET("/message/thread", handler.ConversationHandler.GetMessageThread)
+	authGroup.GET("/message/search", handler.ConversationHandler.SearchMessages)
+	authGroup.POST("/message/forward", handler.ConversationHandler.ForwardMessage)
+	authGroup.POST("/message/delivered", handler.ConversationHandler.AckDeliveredMessages)
+	authGroup.GET("/message/status", handler.ConversationHandler.GetMessageStatus)
+	authGroup.GET("/message/seen-by", handler.ConversationHandler.GetListSeenBy)
+	authGroup.POST("/message/scheduled", handler.ScheduledMessageHandler.CreateScheduledMessage)
+	authGroup.GET("/message/scheduled", handler.ScheduledMessageHandler.GetListScheduledMessage)
+	authGroup.DELETE("/message/scheduled", handler.ScheduledMessageHandler.CancelScheduledMessage)
 
 	// Upload
 	authGroup.POST("/upload", handler.UploadHandler.UploadFile)
 
 	// Seen message
 	authGroup.POST("/seen-message", handler.ConversationHandler.SeenMessage)
+	authGroup.GET("/seen-message", handler.ConversationHandler.GetListSeenMessage)
 
 	// FCM
 	authGroup.POST("/fcm/token", handler.FCMHandler.CreateFCMToken)
 	authGroup.DELETE("/fcm/token", handler.FCMHandler.DeleteFCMToken)
 
+	// Bot
+	authGroup.POST("/bot", handler.BotHandler.CreateBot)
+	authGroup.GET("/bot", handler.BotHandler.GetListBot)
+	authGroup.DELETE("/bot", handler.BotHandler.DeleteBot)
+	authGroup.POST("/bot/api-key", handler.BotHandler.CreateApiKey)
+	authGroup.GET("/bot/api-key", handler.BotHandler.GetListApiKey)
+	authGroup.DELETE("/bot/api-key", handler.BotHandler.RevokeApiKey)
+	authGroup.POST("/bot/conversation", handler.BotHandler.AddBotToConversation)
+	authGroup.POST("/bot/command", handler.BotHandler.CreateBotCommand)
+	authGroup.GET("/bot/command", handler.BotHandler.GetListBotCommand)
+	authGroup.DELETE("/bot/command", handler.BotHandler.DeleteBotCommand)
+
+	// Webhook
+	authGroup.POST("/conversation/webhook", handler.WebhookHandler.CreateWebhook)
+	authGroup.GET("/conversation/webhook", handler.WebhookHandler.GetListWebhook)
+	authGroup.PATCH("/conversation/webhook", handler.WebhookHandler.UpdateWebhook)
+	authGroup.DELETE("/conversation/webhook", handler.WebhookHandler.DeleteWebhook)
+	authGroup.GET("/conversation/webhook/delivery", handler.WebhookHandler.GetListWebhookDelivery)
+	authGroup.POST("/conversation/incoming-webhook", handler.WebhookHandler.CreateIncomingWebhook)
+	authGroup.GET("/conversation/incoming-webhook", handler.WebhookHandler.GetListIncomingWebhook)
+	authGroup.DELETE("/conversation/incoming-webhook", handler.WebhookHandler.RevokeIncomingWebhook)
+
+	// Poll
+	authGroup.POST("/poll", handler.PollHandler.CreatePoll)
+	authGroup.POST("/poll/vote", handler.PollHandler.VotePoll)
+	authGroup.DELETE("/poll/vote", handler.PollHandler.UnvotePoll)
+
+	// Location
+	authGroup.POST("/location/live", handler.LocationHandler.StartLiveLocation)
+	authGroup.PATCH("/location/live", handler.LocationHandler.UpdateLiveLocation)
+	authGroup.DELETE("/location/live", handler.LocationHandler.StopLiveLocation)
+
+	// Sticker
+	authGroup.GET("/stickers", handler.StickerHandler.GetListSticker)
+	authGroup.GET("/stickers/packs", handler.StickerHandler.GetListStickerPack)
+	authGroup.POST("/stickers/install", handler.StickerHandler.InstallStickerPack)
+	authGroup.DELETE("/stickers/install", handler.StickerHandler.UninstallStickerPack)
+	authGroup.PATCH("/stickers/order", handler.StickerHandler.ReorderStickerPack)
+
+	// Pin
+	authGroup.GET("/conversation/pins", handler.PinHandler.GetListPinnedMessage)
+	authGroup.POST("/conversation/pins", handler.PinHandler.PinMessage)
+	authGroup.DELETE("/conversation/pins", handler.PinHandler.UnpinMessage)
+	// Invite
+	authGroup.POST("/conversation/invite", handler.InviteHandler.CreateInvite)
+	authGroup.GET("/conversation/invite", handler.InviteHandler.GetListInvite)
+	authGroup.DELETE("/conversation/invite", handler.InviteHandler.RevokeInvite)
+	authGroup.GET("/conversation/join-request", handler.InviteHandler.GetListJoinRequest)
+	authGroup.POST("/conversation/join-request/review", handler.InviteHandler.ReviewJoinRequest)
+	authGroup.GET("/invite/preview", handler.InviteHandler.GetInvitePreview)
+	authGroup.POST("/invite/join", handler.InviteHandler.JoinInvite)
+
 	s.GET("/ws", handler.WebSocketHandler.HandleWebsocket)
 }
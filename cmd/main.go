@@ -6,6 +6,7 @@ import (
 
 	"github.com/chat-socio/backend/cmd/app"
 	"github.com/chat-socio/backend/cmd/migrate"
+	"github.com/chat-socio/backend/cmd/sticker"
 	"github.com/chat-socio/backend/configuration"
 	"github.com/spf13/cobra"
 )
@@ -13,6 +14,7 @@ import (
 var (
 	svc        string
 	configPath string
+	packDir    string
 )
 
 var rootCmd = &cobra.Command{
@@ -39,6 +41,9 @@ var rootCmd = &cobra.Command{
 		case "migrate":
 			// Run the migration service
 			migrate.Migrate()
+		case "sticker":
+			// Import a sticker pack
+			sticker.ImportPack(packDir)
 		default:
 			log.Printf("Unknown service: %s\n", svc)
 			os.Exit(1)
@@ -47,8 +52,9 @@ var rootCmd = &cobra.Command{
 }
 
 func main() {
-	rootCmd.Flags().StringVarP(&svc, "service", "s", "", "Service to run (app, migrate)")
+	rootCmd.Flags().StringVarP(&svc, "service", "s", "", "Service to run (app, migrate, sticker)")
 	rootCmd.Flags().StringVarP(&configPath, "config", "c", "./config.yaml", "Path to the config file")
+	rootCmd.Flags().StringVarP(&packDir, "pack", "p", "", "Directory of the sticker pack to import (sticker)")
 
 	if err := rootCmd.Execute(); err != nil {
 		log.Printf("Error executing command: %v\n", err)
@@ -0,0 +1,95 @@
+package sticker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/chat-socio/backend/configuration"
+	"github.com/chat-socio/backend/infrastructure/minio"
+	"github.com/chat-socio/backend/infrastructure/postgresql"
+	"github.com/chat-socio/backend/internal/presenter"
+	"github.com/chat-socio/backend/internal/usecase"
+	"github.com/chat-socio/backend/pkg/observability"
+)
+
+// ImportPack uploads the sticker pack described by the pack.json file of dir:
+//
+//	{
+//	  "name": "Cats",
+//	  "description": "...",
+//	  "is_default": false,
+//	  "stickers": [{"file": "happy.webp", "emoji": "😺"}]
+//	}
+//
+// Sticker files are read relative to dir.
+func ImportPack(dir string) {
+	ctx := context.Background()
+	if dir == "" {
+		log.Println("Please specify the sticker pack directory")
+		os.Exit(1)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "pack.json"))
+	if err != nil {
+		log.Println("Error reading pack.json:", err)
+		os.Exit(1)
+	}
+	var request presenter.ImportStickerPackRequest
+	err = json.Unmarshal(data, &request)
+	if err != nil {
+		log.Println("Error parsing pack.json:", err)
+		os.Exit(1)
+	}
+	for _, sticker := range request.Stickers {
+		file, err := os.Open(filepath.Join(dir, filepath.Clean(sticker.FileName)))
+		if err != nil {
+			log.Println("Error opening sticker file:", err)
+			os.Exit(1)
+		}
+		defer file.Close()
+		info, err := file.Stat()
+		if err != nil {
+			log.Println("Error reading sticker file:", err)
+			os.Exit(1)
+		}
+		sticker.Reader = file
+		sticker.Size = info.Size()
+	}
+	if err := request.Validate(); err != nil {
+		log.Println("Invalid sticker pack:", err)
+		os.Exit(1)
+	}
+
+	db, err := postgresql.Connect(ctx, configuration.ConfigInstance.Postgres)
+	if err != nil {
+		log.Println("Error connecting to database:", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	obs, err := observability.New(observability.Config{
+		ServiceName: configuration.ConfigInstance.Observability.JaegerService,
+	})
+	if err != nil {
+		log.Println("Error initializing observability:", err)
+		os.Exit(1)
+	}
+	storage, err := minio.NewMinioClient(configuration.ConfigInstance.Minio, obs)
+	if err != nil {
+		log.Println("Error connecting to storage:", err)
+		os.Exit(1)
+	}
+
+	stickerUseCase := usecase.NewStickerUseCase(postgresql.NewStickerRepository(db, obs), storage, obs)
+	pack, err := stickerUseCase.ImportStickerPack(ctx, &request)
+	if err != nil {
+		log.Println("Error importing sticker pack:", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Sticker pack %q imported with id %s and %d stickers\n", pack.Name, pack.PackID, len(pack.Stickers))
+}
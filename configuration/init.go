@@ -67,6 +67,8 @@ type MinioConfig struct {
 	Token          string `yaml:"token,omitempty"`
 	UseSSL         bool   `yaml:"use_ssl,omitempty"`
 	PublicEndpoint string `yaml:"public_endpoint,omitempty"`
+	ExportBucket   string `yaml:"export_bucket,omitempty"`
+	StickerBucket  string `yaml:"sticker_bucket,omitempty"`
 }
 
 type FCMConfig struct {
@@ -3,3 +3,4 @@
 mc alias set minio http://minio:9000 ROOTNAME CHANGEME123
 mc mb minio/avatar
 mc anonymous set download minio/avatar
+mc mb minio/export
@@ -0,0 +1,110 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/pkg/observability"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type accountDeletionRepository struct {
+	db  *pgxpool.Pool
+	obs *observability.Observability
+}
+
+// CreateAccountDeletionJob implements domain.AccountDeletionRepository.
+func (a *accountDeletionRepository) CreateAccountDeletionJob(ctx context.Context, job *domain.AccountDeletionJob) error {
+	ctx, span := a.obs.StartSpan(ctx, "AccountDeletionRepository.CreateAccountDeletionJob")
+	defer span()
+	logger := a.obs.Logger.WithContext(ctx)
+	query := `
+		INSERT INTO account_deletion_job (id, user_id, account_id, status, step, processed_messages, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	_, err := a.db.Exec(ctx, query, job.ID, job.UserID, job.AccountID, job.Status, job.Step, job.ProcessedMessages, job.CreatedAt, job.UpdatedAt)
+	if err != nil {
+		logger.Error("failed to create account deletion job", err)
+		return err
+	}
+	return nil
+}
+
+// GetAccountDeletionJobByID implements domain.AccountDeletionRepository.
+func (a *accountDeletionRepository) GetAccountDeletionJobByID(ctx context.Context, id string) (*domain.AccountDeletionJob, error) {
+	ctx, span := a.obs.StartSpan(ctx, "AccountDeletionRepository.GetAccountDeletionJobByID")
+	defer span()
+	var job domain.AccountDeletionJob
+	fields, values := job.MapFields()
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE id = $1`, strings.Join(fields, ","), job.TableName())
+	err := a.db.QueryRow(ctx, query, id).Scan(values...)
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// GetAccountDeletionJobByUserID implements domain.AccountDeletionRepository.
+func (a *accountDeletionRepository) GetAccountDeletionJobByUserID(ctx context.Context, userID string) (*domain.AccountDeletionJob, error) {
+	ctx, span := a.obs.StartSpan(ctx, "AccountDeletionRepository.GetAccountDeletionJobByUserID")
+	defer span()
+	var job domain.AccountDeletionJob
+	fields, values := job.MapFields()
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE user_id = $1`, strings.Join(fields, ","), job.TableName())
+	err := a.db.QueryRow(ctx, query, userID).Scan(values...)
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// GetListAccountDeletionJobByStatus implements domain.AccountDeletionRepository.
+func (a *accountDeletionRepository) GetListAccountDeletionJobByStatus(ctx context.Context, status string) ([]*domain.AccountDeletionJob, error) {
+	ctx, span := a.obs.StartSpan(ctx, "AccountDeletionRepository.GetListAccountDeletionJobByStatus")
+	defer span()
+	var temp domain.AccountDeletionJob
+	fields, _ := temp.MapFields()
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE status = $1 ORDER BY id`, strings.Join(fields, ","), temp.TableName())
+	rows, err := a.db.Query(ctx, query, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []*domain.AccountDeletionJob
+	for rows.Next() {
+		var job domain.AccountDeletionJob
+		_, values := job.MapFields()
+		if err := rows.Scan(values...); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, &job)
+	}
+	return jobs, nil
+}
+
+// UpdateAccountDeletionJob implements domain.AccountDeletionRepository.
+func (a *accountDeletionRepository) UpdateAccountDeletionJob(ctx context.Context, job *domain.AccountDeletionJob) error {
+	ctx, span := a.obs.StartSpan(ctx, "AccountDeletionRepository.UpdateAccountDeletionJob")
+	defer span()
+	logger := a.obs.Logger.WithContext(ctx)
+	query := `
+		UPDATE account_deletion_job
+		SET status = $1, step = $2, processed_messages = $3, updated_at = $4, completed_at = $5
+		WHERE id = $6
+	`
+	_, err := a.db.Exec(ctx, query, job.Status, job.Step, job.ProcessedMessages, job.UpdatedAt, job.CompletedAt, job.ID)
+	if err != nil {
+		logger.Error("failed to update account deletion job", err)
+		return err
+	}
+	return nil
+}
+
+var _ domain.AccountDeletionRepository = &accountDeletionRepository{}
+
+func NewAccountDeletionRepository(db *pgxpool.Pool, obs *observability.Observability) domain.AccountDeletionRepository {
+	return &accountDeletionRepository{db: db, obs: obs}
+}
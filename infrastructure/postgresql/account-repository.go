@@ -93,6 +93,17 @@ func (a *accountRepository) UpdatePassword(ctx context.Context, id string, passw
 	return nil
 }
 
+// AnonymizeAccount implements domain.AccountRepository.
+func (a *accountRepository) AnonymizeAccount(ctx context.Context, id string) error {
+	var temp domain.Account
+	query := fmt.Sprintf(`UPDATE %s SET username = $1, password = '', updated_at = NOW() WHERE id = $2`, temp.TableName())
+	_, err := a.db.Exec(ctx, query, fmt.Sprintf("deleted-%s", id), id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 var _ domain.AccountRepository = (*accountRepository)(nil)
 
 // NewAccountRepository creates a new instance of AccountRepository.
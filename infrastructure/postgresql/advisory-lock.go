@@ -0,0 +1,74 @@
+package postgresql
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/pkg/observability"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// advisoryLock is a session level postgres advisory lock. The connection that
+// took the lock is kept out of the pool for as long as the lock is held, so
+// the lock is released by postgres when the replica dies.
+type advisoryLock struct {
+	db   *pgxpool.Pool
+	obs  *observability.Observability
+	key  int64
+	mu   sync.Mutex
+	conn *pgxpool.Conn
+}
+
+// TryLock implements domain.LeaderLock.
+func (a *advisoryLock) TryLock(ctx context.Context) (bool, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.conn != nil {
+		// still the leader as long as the session is alive
+		if err := a.conn.Ping(ctx); err == nil {
+			return true, nil
+		}
+		a.conn.Release()
+		a.conn = nil
+	}
+
+	conn, err := a.db.Acquire(ctx)
+	if err != nil {
+		return false, err
+	}
+	var locked bool
+	err = conn.QueryRow(ctx, `SELECT pg_try_advisory_lock($1)`, a.key).Scan(&locked)
+	if err != nil || !locked {
+		conn.Release()
+		return false, err
+	}
+	a.conn = conn
+	return true, nil
+}
+
+// Unlock implements domain.LeaderLock.
+func (a *advisoryLock) Unlock(ctx context.Context) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.conn == nil {
+		return
+	}
+	_, err := a.conn.Exec(ctx, `SELECT pg_advisory_unlock($1)`, a.key)
+	if err != nil {
+		a.obs.Logger.WithContext(ctx).Error("failed to release advisory lock", err)
+	}
+	a.conn.Release()
+	a.conn = nil
+}
+
+var _ domain.LeaderLock = &advisoryLock{}
+
+// NewAdvisoryLock returns a leader lock keyed by name, every replica using
+// the same name competes for the same lock.
+func NewAdvisoryLock(db *pgxpool.Pool, obs *observability.Observability, name string) domain.LeaderLock {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	return &advisoryLock{db: db, obs: obs, key: int64(h.Sum64())}
+}
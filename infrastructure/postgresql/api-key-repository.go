@@ -0,0 +1,142 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/pkg/observability"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type apiKeyRepository struct {
+	db  *pgxpool.Pool
+	obs *observability.Observability
+}
+
+// CreateApiKey implements domain.ApiKeyRepository.
+func (a *apiKeyRepository) CreateApiKey(ctx context.Context, apiKey *domain.ApiKey) error {
+	ctx, span := a.obs.StartSpan(ctx, "ApiKeyRepository.CreateApiKey")
+	defer span()
+	logger := a.obs.Logger.WithContext(ctx)
+	query := `
+		INSERT INTO api_key (id, bot_id, name, prefix, key_hash, scopes, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err := a.db.Exec(ctx, query, apiKey.ID, apiKey.BotID, apiKey.Name, apiKey.Prefix, apiKey.KeyHash, apiKey.Scopes, apiKey.CreatedAt)
+	if err != nil {
+		logger.Error("failed to create api key", err)
+		return err
+	}
+	return nil
+}
+
+// GetApiKeyByID implements domain.ApiKeyRepository.
+func (a *apiKeyRepository) GetApiKeyByID(ctx context.Context, id string) (*domain.ApiKey, error) {
+	ctx, span := a.obs.StartSpan(ctx, "ApiKeyRepository.GetApiKeyByID")
+	defer span()
+	var apiKey domain.ApiKey
+	fields, values := apiKey.MapFields()
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE id = $1`, strings.Join(fields, ","), apiKey.TableName())
+	err := a.db.QueryRow(ctx, query, id).Scan(values...)
+	if err != nil {
+		return nil, err
+	}
+	return &apiKey, nil
+}
+
+// GetActiveApiKeyByHash implements domain.ApiKeyRepository.
+// Keys of deleted bots are never returned.
+func (a *apiKeyRepository) GetActiveApiKeyByHash(ctx context.Context, keyHash string) (*domain.ApiKey, error) {
+	ctx, span := a.obs.StartSpan(ctx, "ApiKeyRepository.GetActiveApiKeyByHash")
+	defer span()
+	var apiKey domain.ApiKey
+	fields, values := apiKey.MapFields()
+	for i := range fields {
+		fields[i] = "k." + fields[i]
+	}
+	query := fmt.Sprintf(`
+		SELECT %s, u.account_id
+		FROM api_key k
+		INNER JOIN bot b ON b.id = k.bot_id
+		INNER JOIN user_info u ON u.id = b.id
+		INNER JOIN user_info o ON o.id = b.owner_id
+		WHERE k.key_hash = $1 AND k.revoked_at IS NULL AND b.deleted_at IS NULL AND o.deleted_at IS NULL
+	`, strings.Join(fields, ","))
+	err := a.db.QueryRow(ctx, query, keyHash).Scan(append(values, &apiKey.AccountID)...)
+	if err != nil {
+		return nil, err
+	}
+	return &apiKey, nil
+}
+
+// GetListApiKeyByBotID implements domain.ApiKeyRepository.
+func (a *apiKeyRepository) GetListApiKeyByBotID(ctx context.Context, botID string) ([]*domain.ApiKey, error) {
+	ctx, span := a.obs.StartSpan(ctx, "ApiKeyRepository.GetListApiKeyByBotID")
+	defer span()
+	var temp domain.ApiKey
+	fields, _ := temp.MapFields()
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE bot_id = $1 ORDER BY id DESC`, strings.Join(fields, ","), temp.TableName())
+	rows, err := a.db.Query(ctx, query, botID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var apiKeys []*domain.ApiKey
+	for rows.Next() {
+		var apiKey domain.ApiKey
+		_, values := apiKey.MapFields()
+		if err := rows.Scan(values...); err != nil {
+			return nil, err
+		}
+		apiKeys = append(apiKeys, &apiKey)
+	}
+	return apiKeys, nil
+}
+
+// RevokeApiKey implements domain.ApiKeyRepository.
+func (a *apiKeyRepository) RevokeApiKey(ctx context.Context, id string, revokedAt time.Time) error {
+	ctx, span := a.obs.StartSpan(ctx, "ApiKeyRepository.RevokeApiKey")
+	defer span()
+	logger := a.obs.Logger.WithContext(ctx)
+	query := `UPDATE api_key SET revoked_at = $1 WHERE id = $2 AND revoked_at IS NULL`
+	_, err := a.db.Exec(ctx, query, revokedAt, id)
+	if err != nil {
+		logger.Error("failed to revoke api key", err)
+		return err
+	}
+	return nil
+}
+
+// RevokeApiKeysByBotID implements domain.ApiKeyRepository.
+func (a *apiKeyRepository) RevokeApiKeysByBotID(ctx context.Context, botID string, revokedAt time.Time) error {
+	ctx, span := a.obs.StartSpan(ctx, "ApiKeyRepository.RevokeApiKeysByBotID")
+	defer span()
+	logger := a.obs.Logger.WithContext(ctx)
+	query := `UPDATE api_key SET revoked_at = $1 WHERE bot_id = $2 AND revoked_at IS NULL`
+	_, err := a.db.Exec(ctx, query, revokedAt, botID)
+	if err != nil {
+		logger.Error("failed to revoke api keys of bot", err)
+		return err
+	}
+	return nil
+}
+
+// UpdateLastUsedAt implements domain.ApiKeyRepository.
+func (a *apiKeyRepository) UpdateLastUsedAt(ctx context.Context, id string, lastUsedAt time.Time) error {
+	query := `UPDATE api_key SET last_used_at = $1 WHERE id = $2`
+	_, err := a.db.Exec(ctx, query, lastUsedAt, id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+var _ domain.ApiKeyRepository = &apiKeyRepository{}
+
+func NewApiKeyRepository(db *pgxpool.Pool, obs *observability.Observability) domain.ApiKeyRepository {
+	return &apiKeyRepository{db: db, obs: obs}
+}
@@ -0,0 +1,151 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/pkg/observability"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type botCommandRepository struct {
+	db  *pgxpool.Pool
+	obs *observability.Observability
+}
+
+// CreateBotCommand implements domain.BotCommandRepository.
+func (b *botCommandRepository) CreateBotCommand(ctx context.Context, command *domain.BotCommand) error {
+	ctx, span := b.obs.StartSpan(ctx, "BotCommandRepository.CreateBotCommand")
+	defer span()
+	logger := b.obs.Logger.WithContext(ctx)
+	query := `
+		INSERT INTO bot_command (id, bot_id, command, description, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (bot_id, command) DO UPDATE SET description = EXCLUDED.description
+		RETURNING id, created_at
+	`
+	err := b.db.QueryRow(ctx, query, command.ID, command.BotID, command.Command, command.Description, command.CreatedAt).Scan(&command.ID, &command.CreatedAt)
+	if err != nil {
+		logger.Error("failed to create bot command", err)
+		return err
+	}
+	return nil
+}
+
+// GetBotCommandByID implements domain.BotCommandRepository.
+func (b *botCommandRepository) GetBotCommandByID(ctx context.Context, id string) (*domain.BotCommand, error) {
+	ctx, span := b.obs.StartSpan(ctx, "BotCommandRepository.GetBotCommandByID")
+	defer span()
+	var command domain.BotCommand
+	fields, values := command.MapFields()
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE id = $1`, strings.Join(fields, ","), command.TableName())
+	err := b.db.QueryRow(ctx, query, id).Scan(values...)
+	if err != nil {
+		return nil, err
+	}
+	return &command, nil
+}
+
+// GetListBotCommandByBotID implements domain.BotCommandRepository.
+func (b *botCommandRepository) GetListBotCommandByBotID(ctx context.Context, botID string) ([]*domain.BotCommand, error) {
+	ctx, span := b.obs.StartSpan(ctx, "BotCommandRepository.GetListBotCommandByBotID")
+	defer span()
+	var temp domain.BotCommand
+	fields, _ := temp.MapFields()
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE bot_id = $1 ORDER BY command`, strings.Join(fields, ","), temp.TableName())
+	rows, err := b.db.Query(ctx, query, botID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var commands []*domain.BotCommand
+	for rows.Next() {
+		var command domain.BotCommand
+		_, values := command.MapFields()
+		if err := rows.Scan(values...); err != nil {
+			return nil, err
+		}
+		commands = append(commands, &command)
+	}
+	return commands, nil
+}
+
+// GetBotCommandInConversation implements domain.BotCommandRepository.
+// When several bots of the conversation register the same command the oldest
+// registration wins.
+func (b *botCommandRepository) GetBotCommandInConversation(ctx context.Context, conversationID string, command string) (*domain.BotCommand, error) {
+	ctx, span := b.obs.StartSpan(ctx, "BotCommandRepository.GetBotCommandInConversation")
+	defer span()
+	var botCommand domain.BotCommand
+	fields, values := botCommand.MapFields()
+	for i := range fields {
+		fields[i] = "bc." + fields[i]
+	}
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM bot_command bc
+		INNER JOIN bot b ON b.id = bc.bot_id
+		INNER JOIN conversation_member cm ON cm.user_id = bc.bot_id
+		WHERE cm.conversation_id = $1 AND bc.command = $2 AND b.deleted_at IS NULL
+		ORDER BY bc.created_at
+		LIMIT 1
+	`, strings.Join(fields, ","))
+	err := b.db.QueryRow(ctx, query, conversationID, command).Scan(values...)
+	if err != nil {
+		return nil, err
+	}
+	return &botCommand, nil
+}
+
+// DeleteBotCommand implements domain.BotCommandRepository.
+func (b *botCommandRepository) DeleteBotCommand(ctx context.Context, id string) error {
+	ctx, span := b.obs.StartSpan(ctx, "BotCommandRepository.DeleteBotCommand")
+	defer span()
+	logger := b.obs.Logger.WithContext(ctx)
+	_, err := b.db.Exec(ctx, `DELETE FROM bot_command WHERE id = $1`, id)
+	if err != nil {
+		logger.Error("failed to delete bot command", err)
+		return err
+	}
+	return nil
+}
+
+// CreateBotCommandInvocation implements domain.BotCommandRepository.
+func (b *botCommandRepository) CreateBotCommandInvocation(ctx context.Context, invocation *domain.BotCommandInvocation) error {
+	ctx, span := b.obs.StartSpan(ctx, "BotCommandRepository.CreateBotCommandInvocation")
+	defer span()
+	logger := b.obs.Logger.WithContext(ctx)
+	query := `
+		INSERT INTO bot_command_invocation (id, bot_id, command, args, conversation_id, user_id, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	_, err := b.db.Exec(ctx, query, invocation.ID, invocation.BotID, invocation.Command, invocation.Args, invocation.ConversationID, invocation.UserID, invocation.CreatedAt, invocation.ExpiresAt)
+	if err != nil {
+		logger.Error("failed to create bot command invocation", err)
+		return err
+	}
+	return nil
+}
+
+// GetBotCommandInvocationByID implements domain.BotCommandRepository.
+func (b *botCommandRepository) GetBotCommandInvocationByID(ctx context.Context, id string) (*domain.BotCommandInvocation, error) {
+	ctx, span := b.obs.StartSpan(ctx, "BotCommandRepository.GetBotCommandInvocationByID")
+	defer span()
+	var invocation domain.BotCommandInvocation
+	fields, values := invocation.MapFields()
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE id = $1`, strings.Join(fields, ","), invocation.TableName())
+	err := b.db.QueryRow(ctx, query, id).Scan(values...)
+	if err != nil {
+		return nil, err
+	}
+	return &invocation, nil
+}
+
+var _ domain.BotCommandRepository = &botCommandRepository{}
+
+func NewBotCommandRepository(db *pgxpool.Pool, obs *observability.Observability) domain.BotCommandRepository {
+	return &botCommandRepository{db: db, obs: obs}
+}
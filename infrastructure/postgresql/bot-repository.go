@@ -0,0 +1,128 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/pkg/observability"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type botRepository struct {
+	db  *pgxpool.Pool
+	obs *observability.Observability
+}
+
+// CreateBot implements domain.BotRepository.
+// The account, the user info and the bot row are created in one transaction.
+func (b *botRepository) CreateBot(ctx context.Context, account *domain.Account, user *domain.UserInfo, bot *domain.Bot) error {
+	ctx, span := b.obs.StartSpan(ctx, "BotRepository.CreateBot")
+	defer span()
+	logger := b.obs.Logger.WithContext(ctx)
+	tx, err := b.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = insertBot(ctx, tx, account, user, bot)
+	if err != nil {
+		logger.Error("failed to create bot", err)
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// insertBot creates the account, the user info and the bot row of a bot in tx.
+func insertBot(ctx context.Context, tx pgx.Tx, account *domain.Account, user *domain.UserInfo, bot *domain.Bot) error {
+	query := fmt.Sprintf(`INSERT INTO %s (id, username, password, created_at, updated_at) VALUES ($1, $2, $3, $4, $5)`, account.TableName())
+	_, err := tx.Exec(ctx, query, account.ID, account.Username, account.Password, account.CreatedAt, account.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	query = fmt.Sprintf(`INSERT INTO %s (id, account_id, type, email, full_name, avatar, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`, user.TableName())
+	_, err = tx.Exec(ctx, query, user.ID, account.ID, user.Type, user.Email, user.FullName, user.Avatar, user.CreatedAt, user.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	query = fmt.Sprintf(`INSERT INTO %s (id, owner_id, created_at, updated_at) VALUES ($1, $2, $3, $4)`, bot.TableName())
+	_, err = tx.Exec(ctx, query, bot.ID, bot.OwnerID, bot.CreatedAt, bot.UpdatedAt)
+	return err
+}
+
+// GetBotByID implements domain.BotRepository.
+func (b *botRepository) GetBotByID(ctx context.Context, id string) (*domain.Bot, error) {
+	ctx, span := b.obs.StartSpan(ctx, "BotRepository.GetBotByID")
+	defer span()
+	query := `
+		SELECT b.id, b.owner_id, b.created_at, b.updated_at, b.deleted_at, u.account_id, u.full_name, u.avatar
+		FROM bot b
+		INNER JOIN user_info u ON u.id = b.id
+		WHERE b.id = $1 AND b.deleted_at IS NULL
+	`
+	bot := &domain.Bot{User: &domain.UserInfo{}}
+	err := b.db.QueryRow(ctx, query, id).Scan(&bot.ID, &bot.OwnerID, &bot.CreatedAt, &bot.UpdatedAt, &bot.DeletedAt, &bot.User.AccountID, &bot.User.FullName, &bot.User.Avatar)
+	if err != nil {
+		return nil, err
+	}
+	bot.User.ID = bot.ID
+	bot.User.Type = domain.InternalUserType
+	return bot, nil
+}
+
+// GetListBotByOwnerID implements domain.BotRepository.
+func (b *botRepository) GetListBotByOwnerID(ctx context.Context, ownerID string) ([]*domain.Bot, error) {
+	ctx, span := b.obs.StartSpan(ctx, "BotRepository.GetListBotByOwnerID")
+	defer span()
+	query := `
+		SELECT b.id, b.owner_id, b.created_at, b.updated_at, b.deleted_at, u.account_id, u.full_name, u.avatar
+		FROM bot b
+		INNER JOIN user_info u ON u.id = b.id
+		WHERE b.owner_id = $1 AND b.deleted_at IS NULL
+		ORDER BY b.id DESC
+	`
+	rows, err := b.db.Query(ctx, query, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var bots []*domain.Bot
+	for rows.Next() {
+		bot := &domain.Bot{User: &domain.UserInfo{}}
+		err := rows.Scan(&bot.ID, &bot.OwnerID, &bot.CreatedAt, &bot.UpdatedAt, &bot.DeletedAt, &bot.User.AccountID, &bot.User.FullName, &bot.User.Avatar)
+		if err != nil {
+			return nil, err
+		}
+		bot.User.ID = bot.ID
+		bot.User.Type = domain.InternalUserType
+		bots = append(bots, bot)
+	}
+	return bots, nil
+}
+
+// DeleteBot implements domain.BotRepository.
+func (b *botRepository) DeleteBot(ctx context.Context, id string, deletedAt time.Time) error {
+	ctx, span := b.obs.StartSpan(ctx, "BotRepository.DeleteBot")
+	defer span()
+	logger := b.obs.Logger.WithContext(ctx)
+	query := `UPDATE bot SET deleted_at = $1, updated_at = $1 WHERE id = $2`
+	_, err := b.db.Exec(ctx, query, deletedAt, id)
+	if err != nil {
+		logger.Error("failed to delete bot", err)
+		return err
+	}
+	return nil
+}
+
+var _ domain.BotRepository = &botRepository{}
+
+func NewBotRepository(db *pgxpool.Pool, obs *observability.Observability) domain.BotRepository {
+	return &botRepository{db: db, obs: obs}
+}
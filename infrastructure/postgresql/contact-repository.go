@@ -77,7 +77,33 @@ func (c *contactRepository) CreateRequestFriend(ctx context.Context, requestFrie
 
 // GetListContactByUserID implements domain.ContactRepository.
 func (c *contactRepository) GetListContactByUserID(ctx context.Context, userID string, limit int, lastID string) ([]*domain.Contact, error) {
-	panic("unimplemented")
+	ctx, span := c.obs.StartSpan(ctx, "ContactRepository.GetListContactByUserID")
+	defer span()
+	logger := c.obs.Logger.WithContext(ctx)
+	query := `select c.id, c.user_id, c.friend_id, c.created_at, c.updated_at, u.id, u.full_name, u.avatar, u.type, u.created_at, u.updated_at
+		from contact c join user_info u on c.friend_id = u.id
+		where c.user_id = $1 and ($2 = '' or c.id < $2)
+		order by c.id desc limit $3`
+	rows, err := c.db.Query(ctx, query, userID, lastID, limit)
+	if err != nil {
+		logger.Error("failed to get list contact", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var contacts []*domain.Contact
+	for rows.Next() {
+		var contact domain.Contact
+		var friend domain.UserInfo
+		err := rows.Scan(&contact.ID, &contact.UserID, &contact.FriendID, &contact.CreatedAt, &contact.UpdatedAt, &friend.ID, &friend.FullName, &friend.Avatar, &friend.Type, &friend.CreatedAt, &friend.UpdatedAt)
+		if err != nil {
+			logger.Error("failed to scan contact", err)
+			return nil, err
+		}
+		contact.Friend = &friend
+		contacts = append(contacts, &contact)
+	}
+	return contacts, nil
 }
 
 // GetListRequestFriendReceivedByUserID implements domain.ContactRepository.
@@ -95,6 +121,75 @@ func (c *contactRepository) UpdateRequestFriendStatus(ctx context.Context, id st
 	panic("unimplemented")
 }
 
+// DeleteContactsByUserID implements domain.ContactRepository.
+func (c *contactRepository) DeleteContactsByUserID(ctx context.Context, userID string) error {
+	ctx, span := c.obs.StartSpan(ctx, "ContactRepository.DeleteContactsByUserID")
+	defer span()
+	logger := c.obs.Logger.WithContext(ctx)
+	query := `delete from contact where user_id = $1 or friend_id = $1`
+	_, err := c.db.Exec(ctx, query, userID)
+	if err != nil {
+		logger.Error("failed to delete contacts", err)
+		return err
+	}
+	return nil
+}
+
+// DeletePendingRequestFriendByUserID implements domain.ContactRepository.
+func (c *contactRepository) DeletePendingRequestFriendByUserID(ctx context.Context, userID string) error {
+	ctx, span := c.obs.StartSpan(ctx, "ContactRepository.DeletePendingRequestFriendByUserID")
+	defer span()
+	logger := c.obs.Logger.WithContext(ctx)
+	query := `delete from friend_request where (from_user_id = $1 or to_user_id = $1) and status = $2`
+	_, err := c.db.Exec(ctx, query, userID, domain.RequestFriendStatusPending.String())
+	if err != nil {
+		logger.Error("failed to delete pending friend requests", err)
+		return err
+	}
+	return nil
+}
+
+// GetListContactRelation implements domain.ContactRepository.
+func (c *contactRepository) GetListContactRelation(ctx context.Context, userID string, otherUserIDs []string) ([]*domain.ContactRelation, error) {
+	ctx, span := c.obs.StartSpan(ctx, "ContactRepository.GetListContactRelation")
+	defer span()
+	logger := c.obs.Logger.WithContext(ctx)
+	query := `
+		select o.id,
+			exists (select 1 from contact c where c.user_id = $1 and c.friend_id = o.id),
+			exists (
+				select 1 from friend_request fr
+				where fr.status = $3
+				and ((fr.from_user_id = $1 and fr.to_user_id = o.id) or (fr.from_user_id = o.id and fr.to_user_id = $1))
+			),
+			(
+				select cv.id from conversation cv
+				inner join conversation_member cm1 on cv.id = cm1.conversation_id
+				inner join conversation_member cm2 on cv.id = cm2.conversation_id
+				where cm1.user_id = $1 and cm2.user_id = o.id and cv.type = 'DM'
+				limit 1
+			)
+		from unnest($2::text[]) as o(id)`
+	rows, err := c.db.Query(ctx, query, userID, otherUserIDs, domain.RequestFriendStatusPending.String())
+	if err != nil {
+		logger.Error("failed to get list contact relation", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var relations []*domain.ContactRelation
+	for rows.Next() {
+		var relation domain.ContactRelation
+		err := rows.Scan(&relation.UserID, &relation.IsContact, &relation.HasPendingRequest, &relation.DMConversationID)
+		if err != nil {
+			logger.Error("failed to scan contact relation", err)
+			return nil, err
+		}
+		relations = append(relations, &relation)
+	}
+	return relations, nil
+}
+
 var _ domain.ContactRepository = &contactRepository{}
 
 func NewContactRepository(db *pgxpool.Pool, obs *observability.Observability) *contactRepository {
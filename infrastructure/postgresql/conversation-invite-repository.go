@@ -0,0 +1,261 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/pkg/observability"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type conversationInviteRepository struct {
+	db  *pgxpool.Pool
+	obs *observability.Observability
+}
+
+// CreateConversationInvite implements domain.ConversationInviteRepository.
+func (c *conversationInviteRepository) CreateConversationInvite(ctx context.Context, invite *domain.ConversationInvite) error {
+	ctx, span := c.obs.StartSpan(ctx, "ConversationInviteRepository.CreateConversationInvite")
+	defer span()
+	logger := c.obs.Logger.WithContext(ctx)
+	query := `
+		INSERT INTO conversation_invite (id, conversation_id, code, created_by, expires_at, max_uses, use_count, requires_approval, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+	_, err := c.db.Exec(ctx, query, invite.ID, invite.ConversationID, invite.Code, invite.CreatedBy, invite.ExpiresAt, invite.MaxUses, invite.UseCount, invite.RequiresApproval, invite.CreatedAt, invite.UpdatedAt)
+	if err != nil {
+		logger.Error("failed to create conversation invite", err)
+		return err
+	}
+	return nil
+}
+
+// GetConversationInviteByID implements domain.ConversationInviteRepository.
+func (c *conversationInviteRepository) GetConversationInviteByID(ctx context.Context, id string) (*domain.ConversationInvite, error) {
+	ctx, span := c.obs.StartSpan(ctx, "ConversationInviteRepository.GetConversationInviteByID")
+	defer span()
+	var invite domain.ConversationInvite
+	fields, values := invite.MapFields()
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE id = $1`, strings.Join(fields, ","), invite.TableName())
+	err := c.db.QueryRow(ctx, query, id).Scan(values...)
+	if err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
+
+// GetConversationInviteByCode implements domain.ConversationInviteRepository.
+func (c *conversationInviteRepository) GetConversationInviteByCode(ctx context.Context, code string) (*domain.ConversationInvite, error) {
+	ctx, span := c.obs.StartSpan(ctx, "ConversationInviteRepository.GetConversationInviteByCode")
+	defer span()
+	var invite domain.ConversationInvite
+	fields, values := invite.MapFields()
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE code = $1`, strings.Join(fields, ","), invite.TableName())
+	err := c.db.QueryRow(ctx, query, code).Scan(values...)
+	if err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
+
+// GetListConversationInvite implements domain.ConversationInviteRepository.
+// Revoked invites are left out, the newest come first.
+func (c *conversationInviteRepository) GetListConversationInvite(ctx context.Context, conversationID string) ([]*domain.ConversationInvite, error) {
+	ctx, span := c.obs.StartSpan(ctx, "ConversationInviteRepository.GetListConversationInvite")
+	defer span()
+	var temp domain.ConversationInvite
+	fields, _ := temp.MapFields()
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE conversation_id = $1 AND revoked_at IS NULL ORDER BY created_at DESC`, strings.Join(fields, ","), temp.TableName())
+	rows, err := c.db.Query(ctx, query, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var invites []*domain.ConversationInvite
+	for rows.Next() {
+		var invite domain.ConversationInvite
+		_, values := invite.MapFields()
+		if err := rows.Scan(values...); err != nil {
+			return nil, err
+		}
+		invites = append(invites, &invite)
+	}
+	return invites, nil
+}
+
+// RevokeConversationInvite implements domain.ConversationInviteRepository.
+func (c *conversationInviteRepository) RevokeConversationInvite(ctx context.Context, id string, revokedAt time.Time) error {
+	ctx, span := c.obs.StartSpan(ctx, "ConversationInviteRepository.RevokeConversationInvite")
+	defer span()
+	logger := c.obs.Logger.WithContext(ctx)
+	query := `UPDATE conversation_invite SET revoked_at = $1, updated_at = $1 WHERE id = $2 AND revoked_at IS NULL`
+	_, err := c.db.Exec(ctx, query, revokedAt, id)
+	if err != nil {
+		logger.Error("failed to revoke conversation invite", err)
+		return err
+	}
+	return nil
+}
+
+// JoinConversationInvite implements domain.ConversationInviteRepository.
+// The use is counted and the member added in one transaction, so concurrent
+// joins cannot go over max_uses nor count a user twice. used is false when
+// the invite can no longer be used, added is false when the user is already a
+// member, nothing is stored unless both are true.
+func (c *conversationInviteRepository) JoinConversationInvite(ctx context.Context, inviteID string, member *domain.ConversationMember, now time.Time) (bool, bool, error) {
+	ctx, span := c.obs.StartSpan(ctx, "ConversationInviteRepository.JoinConversationInvite")
+	defer span()
+	tx, err := c.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return false, false, err
+	}
+	defer tx.Rollback(ctx)
+
+	used, err := useConversationInvite(ctx, tx, inviteID, now)
+	if err != nil || !used {
+		return false, false, err
+	}
+	query := `
+		INSERT INTO conversation_member (id, conversation_id, user_id, role, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (conversation_id, user_id) DO NOTHING
+	`
+	tag, err := tx.Exec(ctx, query, member.ID, member.ConversationID, member.UserID, member.Role, member.CreatedAt, member.UpdatedAt)
+	if err != nil {
+		return false, false, err
+	}
+	if tag.RowsAffected() == 0 {
+		return true, false, nil
+	}
+	return true, true, tx.Commit(ctx)
+}
+
+// CreateConversationJoinRequest implements domain.ConversationInviteRepository.
+// Like JoinConversationInvite, used is false when the invite can no longer be
+// used and created is false when the user already waits for approval, nothing
+// is stored unless both are true.
+func (c *conversationInviteRepository) CreateConversationJoinRequest(ctx context.Context, joinRequest *domain.ConversationJoinRequest, now time.Time) (bool, bool, error) {
+	ctx, span := c.obs.StartSpan(ctx, "ConversationInviteRepository.CreateConversationJoinRequest")
+	defer span()
+	logger := c.obs.Logger.WithContext(ctx)
+	tx, err := c.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return false, false, err
+	}
+	defer tx.Rollback(ctx)
+
+	used, err := useConversationInvite(ctx, tx, joinRequest.InviteID, now)
+	if err != nil || !used {
+		return false, false, err
+	}
+	query := `
+		INSERT INTO conversation_join_request (id, invite_id, conversation_id, user_id, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (conversation_id, user_id) WHERE status = 'pending' DO NOTHING
+	`
+	tag, err := tx.Exec(ctx, query, joinRequest.ID, joinRequest.InviteID, joinRequest.ConversationID, joinRequest.UserID, joinRequest.Status, joinRequest.CreatedAt, joinRequest.UpdatedAt)
+	if err != nil {
+		logger.Error("failed to create conversation join request", err)
+		return false, false, err
+	}
+	if tag.RowsAffected() == 0 {
+		return true, false, nil
+	}
+	return true, true, tx.Commit(ctx)
+}
+
+// useConversationInvite counts a use of the invite, it reports false when the
+// invite is revoked, expired or used up.
+func useConversationInvite(ctx context.Context, tx pgx.Tx, inviteID string, now time.Time) (bool, error) {
+	query := `
+		UPDATE conversation_invite SET use_count = use_count + 1, updated_at = $1
+		WHERE id = $2 AND revoked_at IS NULL
+		AND (expires_at IS NULL OR expires_at > $1)
+		AND (max_uses = 0 OR use_count < max_uses)
+	`
+	tag, err := tx.Exec(ctx, query, now, inviteID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// GetConversationJoinRequestByID implements domain.ConversationInviteRepository.
+func (c *conversationInviteRepository) GetConversationJoinRequestByID(ctx context.Context, id string) (*domain.ConversationJoinRequest, error) {
+	ctx, span := c.obs.StartSpan(ctx, "ConversationInviteRepository.GetConversationJoinRequestByID")
+	defer span()
+	var joinRequest domain.ConversationJoinRequest
+	fields, values := joinRequest.MapFields()
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE id = $1`, strings.Join(fields, ","), joinRequest.TableName())
+	err := c.db.QueryRow(ctx, query, id).Scan(values...)
+	if err != nil {
+		return nil, err
+	}
+	return &joinRequest, nil
+}
+
+// GetPendingConversationJoinRequest implements domain.ConversationInviteRepository.
+func (c *conversationInviteRepository) GetPendingConversationJoinRequest(ctx context.Context, conversationID string, userID string) (*domain.ConversationJoinRequest, error) {
+	ctx, span := c.obs.StartSpan(ctx, "ConversationInviteRepository.GetPendingConversationJoinRequest")
+	defer span()
+	var joinRequest domain.ConversationJoinRequest
+	fields, values := joinRequest.MapFields()
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE conversation_id = $1 AND user_id = $2 AND status = $3`, strings.Join(fields, ","), joinRequest.TableName())
+	err := c.db.QueryRow(ctx, query, conversationID, userID, domain.JoinRequestStatusPending).Scan(values...)
+	if err != nil {
+		return nil, err
+	}
+	return &joinRequest, nil
+}
+
+// GetListPendingConversationJoinRequest implements domain.ConversationInviteRepository.
+func (c *conversationInviteRepository) GetListPendingConversationJoinRequest(ctx context.Context, conversationID string) ([]*domain.ConversationJoinRequest, error) {
+	ctx, span := c.obs.StartSpan(ctx, "ConversationInviteRepository.GetListPendingConversationJoinRequest")
+	defer span()
+	var temp domain.ConversationJoinRequest
+	fields, _ := temp.MapFields()
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE conversation_id = $1 AND status = $2 ORDER BY created_at`, strings.Join(fields, ","), temp.TableName())
+	rows, err := c.db.Query(ctx, query, conversationID, domain.JoinRequestStatusPending)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var joinRequests []*domain.ConversationJoinRequest
+	for rows.Next() {
+		var joinRequest domain.ConversationJoinRequest
+		_, values := joinRequest.MapFields()
+		if err := rows.Scan(values...); err != nil {
+			return nil, err
+		}
+		joinRequests = append(joinRequests, &joinRequest)
+	}
+	return joinRequests, nil
+}
+
+// ReviewConversationJoinRequest implements domain.ConversationInviteRepository.
+// It reports false when the request was already reviewed.
+func (c *conversationInviteRepository) ReviewConversationJoinRequest(ctx context.Context, id string, status string, reviewedBy string, reviewedAt time.Time) (bool, error) {
+	ctx, span := c.obs.StartSpan(ctx, "ConversationInviteRepository.ReviewConversationJoinRequest")
+	defer span()
+	query := `
+		UPDATE conversation_join_request SET status = $1, reviewed_by = $2, reviewed_at = $3, updated_at = $3
+		WHERE id = $4 AND status = $5
+	`
+	tag, err := c.db.Exec(ctx, query, status, reviewedBy, reviewedAt, id, domain.JoinRequestStatusPending)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+var _ domain.ConversationInviteRepository = &conversationInviteRepository{}
+
+func NewConversationInviteRepository(db *pgxpool.Pool, obs *observability.Observability) domain.ConversationInviteRepository {
+	return &conversationInviteRepository{db: db, obs: obs}
+}
@@ -50,12 +50,13 @@ func (c *conversationRepository) CreateConversation(ctx context.Context, convers
 	}
 
 	query = `
-		INSERT INTO conversation_member (id, conversation_id, user_id, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO conversation_member (id, conversation_id, user_id, role, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (conversation_id, user_id) DO NOTHING
 	`
 
 	for _, conversationMember := range conversationMembers {
-		_, err = tx.Exec(ctx, query, conversationMember.ID, conversationMember.ConversationID, conversationMember.UserID, conversationMember.CreatedAt, conversationMember.UpdatedAt)
+		_, err = tx.Exec(ctx, query, conversationMember.ID, conversationMember.ConversationID, conversationMember.UserID, conversationMember.Role, conversationMember.CreatedAt, conversationMember.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -83,7 +84,7 @@ func (c *conversationRepository) GetConversationByID(ctx context.Context, id str
 		return nil, nil, err
 	}
 
-	query = `SELECT cm.conversation_id, cm.user_id, ui.full_name, ui.avatar, ui.type FROM conversation_member cm
+	query = `SELECT cm.conversation_id, cm.user_id, ui.full_name, ui.avatar, ui.type, cm.role, cm.muted_until FROM conversation_member cm
 		INNER JOIN user_info ui ON cm.user_id = ui.id
 		WHERE cm.conversation_id = $1`
 	rows, err := c.db.Query(ctx, query, id)
@@ -94,7 +95,7 @@ func (c *conversationRepository) GetConversationByID(ctx context.Context, id str
 
 	for rows.Next() {
 		var conversationMember domain.ConversationMemberWithUser
-		if err := rows.Scan(&conversationMember.ConversationID, &conversationMember.UserID, &conversationMember.FullName, &conversationMember.Avatar, &conversationMember.UserType); err != nil {
+		if err := rows.Scan(&conversationMember.ConversationID, &conversationMember.UserID, &conversationMember.FullName, &conversationMember.Avatar, &conversationMember.UserType, &conversationMember.Role, &conversationMember.MutedUntil); err != nil {
 			return nil, nil, err
 		}
 		conversationMembers = append(conversationMembers, &conversationMember)
@@ -104,7 +105,7 @@ func (c *conversationRepository) GetConversationByID(ctx context.Context, id str
 }
 
 // GetListConversationByUserID implements domain.ConversationRepository.
-func (c *conversationRepository) GetListConversationByUserID(ctx context.Context, userID string, lastMessageID string, limit int) ([]*domain.Conversation, error) {
+func (c *conversationRepository) GetListConversationByUserID(ctx context.Context, userID string, lastMessageID string, limit int, filter domain.ConversationListFilter) ([]*domain.Conversation, error) {
 	var conversations []*domain.Conversation
 	var conditionLastMessageID string
 	var params []any
@@ -113,6 +114,17 @@ func (c *conversationRepository) GetListConversationByUserID(ctx context.Context
 		conditionLastMessageID = `AND last_message_id < $2`
 		params = append(params, lastMessageID)
 	}
+	conditionSettings := `AND me.archived_at IS NULL`
+	if filter.Archived {
+		conditionSettings = `AND me.archived_at IS NOT NULL`
+	}
+	orderBy, orderByData := "c.last_message_id DESC", "cd.last_message_id DESC"
+	if filter.Pinned {
+		conditionSettings += ` AND me.pin_order IS NOT NULL`
+		orderBy, orderByData = "me.pin_order, c.last_message_id DESC", "cd.pin_order, cd.last_message_id DESC"
+	} else {
+		conditionSettings += ` AND me.pin_order IS NULL`
+	}
 	// Add NULL handling for last_message_id
 	// fieldsWithCoalesce := []string{
 	// 	"c.id",
@@ -154,6 +166,7 @@ func (c *conversationRepository) GetListConversationByUserID(ctx context.Context
 				COALESCE(m.created_at, NULL) as message_created_at,
 				COALESCE(m.updated_at, NULL) as message_updated_at,
 				COALESCE(m.reply_to::text, '') as message_reply_to,
+				COALESCE(m.display_name::text, '') as message_display_name,
 				COALESCE(ui.id::text, '') as user_id,
 				COALESCE(ui.full_name::text, '') as user_full_name,
 				COALESCE(ui.avatar::text, '') as user_avatar,
@@ -166,16 +179,25 @@ func (c *conversationRepository) GetListConversationByUserID(ctx context.Context
 						AND sm.message_id = c.last_message_id
 					) THEN true
 					ELSE false
-				END as is_read
+				END as is_read,
+				COALESCE((
+					SELECT cu.unread_count FROM conversation_unread cu
+					WHERE cu.conversation_id = c.id AND cu.user_id = $1
+				), 0) as unread_count,
+				me.muted_until,
+				me.archived_at,
+				me.pin_order
 			FROM conversation c
-			LEFT JOIN message m ON c.last_message_id = m.id
+			INNER JOIN LATERAL (
+				SELECT muted_until, archived_at, pin_order
+				FROM conversation_member
+				WHERE conversation_id = c.id AND user_id = $1
+				LIMIT 1
+			) me ON true
+			LEFT JOIN message m ON c.last_message_id = m.id AND (m.expires_at IS NULL OR m.expires_at > now())
 			LEFT JOIN user_info ui ON m.user_id = ui.id
-			WHERE c.id IN (
-				SELECT DISTINCT conversation_id 
-				FROM conversation_member 
-				WHERE user_id = $1
-			) %s
-			ORDER BY c.last_message_id DESC
+			WHERE true %s %s
+			ORDER BY %s
 			LIMIT %d
 		),
 		conversation_members AS (
@@ -199,7 +221,7 @@ func (c *conversationRepository) GetListConversationByUserID(ctx context.Context
 			COALESCE(cm.members::text, '[]') as members
 		FROM conversation_data cd
 		LEFT JOIN conversation_members cm ON cd.id = cm.conversation_id
-		ORDER BY cd.last_message_id DESC`, conditionLastMessageID, limit)
+		ORDER BY %s`, conditionSettings, conditionLastMessageID, orderBy, limit, orderByData)
 
 	rows, err := c.db.Query(ctx, query, params...)
 	if err != nil && err != pgx.ErrNoRows {
@@ -234,11 +256,16 @@ func (c *conversationRepository) GetListConversationByUserID(ctx context.Context
 			&message.CreatedAt,
 			&message.UpdatedAt,
 			&message.ReplyTo,
+			&message.DisplayName,
 			&userInfo.ID,
 			&userInfo.FullName,
 			&userInfo.Avatar,
 			&userInfo.Type,
 			&message.IsRead,
+			&conversation.UnreadCount,
+			&conversation.MutedUntil,
+			&conversation.ArchivedAt,
+			&conversation.PinOrder,
 			&membersString,
 		}
 		if err := rows.Scan(values...); err != nil {
@@ -309,6 +336,132 @@ func NewConversationRepository(db *pgxpool.Pool) domain.ConversationRepository {
 	return &conversationRepository{db: db}
 }
 
+// GetListConversationIDByUserID implements domain.ConversationRepository.
+func (c *conversationRepository) GetListConversationIDByUserID(ctx context.Context, userID string) ([]string, error) {
+	query := `SELECT DISTINCT conversation_id FROM conversation_member WHERE user_id = $1 ORDER BY conversation_id`
+	rows, err := c.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var conversationIDs []string
+	for rows.Next() {
+		var conversationID string
+		if err := rows.Scan(&conversationID); err != nil {
+			return nil, err
+		}
+		conversationIDs = append(conversationIDs, conversationID)
+	}
+	return conversationIDs, nil
+}
+
+// AddConversationMember implements domain.ConversationRepository.
+// It reports false when the user is already a member.
+func (c *conversationRepository) AddConversationMember(ctx context.Context, member *domain.ConversationMember) (bool, error) {
+	query := `INSERT INTO conversation_member (id, conversation_id, user_id, role, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (conversation_id, user_id) DO NOTHING`
+	tag, err := c.db.Exec(ctx, query, member.ID, member.ConversationID, member.UserID, member.Role, member.CreatedAt, member.UpdatedAt)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// DeleteConversationMember implements domain.ConversationRepository.
+// It reports false when the user was not a member.
+func (c *conversationRepository) DeleteConversationMember(ctx context.Context, conversationID string, userID string) (bool, error) {
+	query := `DELETE FROM conversation_member WHERE conversation_id = $1 AND user_id = $2`
+	tag, err := c.db.Exec(ctx, query, conversationID, userID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// GetConversationMember implements domain.ConversationRepository.
+func (c *conversationRepository) GetConversationMember(ctx context.Context, conversationID string, userID string) (*domain.ConversationMember, error) {
+	var member domain.ConversationMember
+	fields, values := member.MapFields()
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE conversation_id = $1 AND user_id = $2`, strings.Join(fields, ","), member.TableName())
+	err := c.db.QueryRow(ctx, query, conversationID, userID).Scan(values...)
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// UpdateConversationMemberMutedUntil implements domain.ConversationRepository.
+// A nil mutedUntil unmutes the conversation.
+func (c *conversationRepository) UpdateConversationMemberMutedUntil(ctx context.Context, conversationID string, userID string, mutedUntil *time.Time) error {
+	query := `UPDATE conversation_member SET muted_until = $1, updated_at = $2 WHERE conversation_id = $3 AND user_id = $4`
+	_, err := c.db.Exec(ctx, query, mutedUntil, time.Now(), conversationID, userID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateConversationMemberArchivedAt implements domain.ConversationRepository.
+// A nil archivedAt moves the conversation back to the main list.
+func (c *conversationRepository) UpdateConversationMemberArchivedAt(ctx context.Context, conversationID string, userID string, archivedAt *time.Time) error {
+	query := `UPDATE conversation_member SET archived_at = $1, updated_at = $2 WHERE conversation_id = $3 AND user_id = $4`
+	_, err := c.db.Exec(ctx, query, archivedAt, time.Now(), conversationID, userID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetListPinnedConversationID implements domain.ConversationRepository.
+func (c *conversationRepository) GetListPinnedConversationID(ctx context.Context, userID string) ([]string, error) {
+	query := `SELECT conversation_id FROM conversation_member WHERE user_id = $1 AND pin_order IS NOT NULL ORDER BY pin_order`
+	rows, err := c.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	conversationIDs := make([]string, 0)
+	for rows.Next() {
+		var conversationID string
+		if err := rows.Scan(&conversationID); err != nil {
+			return nil, err
+		}
+		conversationIDs = append(conversationIDs, conversationID)
+	}
+	return conversationIDs, nil
+}
+
+// UpdatePinnedConversationOrder implements domain.ConversationRepository.
+// The conversations are pinned in the given order, every other conversation
+// of the user is unpinned.
+func (c *conversationRepository) UpdatePinnedConversationOrder(ctx context.Context, userID string, conversationIDs []string) error {
+	tx, err := c.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	now := time.Now()
+	query := `UPDATE conversation_member SET pin_order = NULL, updated_at = $1
+		WHERE user_id = $2 AND pin_order IS NOT NULL AND NOT (conversation_id = ANY($3))`
+	_, err = tx.Exec(ctx, query, now, userID, conversationIDs)
+	if err != nil {
+		return err
+	}
+
+	query = `UPDATE conversation_member cm SET pin_order = p.pin_order, updated_at = $1
+		FROM unnest($3::text[]) WITH ORDINALITY AS p(conversation_id, pin_order)
+		WHERE cm.user_id = $2 AND cm.conversation_id = p.conversation_id`
+	_, err = tx.Exec(ctx, query, now, userID, conversationIDs)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
 func (c *conversationRepository) CheckIsMemberOfConversation(ctx context.Context, userID string, conversationID string) (bool, error) {
 	var isMember int
 	query := `SELECT 1 FROM conversation_member WHERE user_id = $1 AND conversation_id = $2`
@@ -318,3 +471,33 @@ func (c *conversationRepository) CheckIsMemberOfConversation(ctx context.Context
 	}
 	return isMember == 1, nil
 }
+
+// GetConversationMessageTTL implements domain.ConversationRepository.
+func (c *conversationRepository) GetConversationMessageTTL(ctx context.Context, conversationID string) (string, error) {
+	var messageTTL string
+	err := c.db.QueryRow(ctx, `SELECT message_ttl FROM conversation WHERE id = $1`, conversationID).Scan(&messageTTL)
+	if err != nil {
+		return "", err
+	}
+	return messageTTL, nil
+}
+
+// UpdateConversationMessageTTL implements domain.ConversationRepository.
+func (c *conversationRepository) UpdateConversationMessageTTL(ctx context.Context, conversationID string, messageTTL string) error {
+	query := `UPDATE conversation SET message_ttl = $1, updated_at = $2 WHERE id = $3`
+	_, err := c.db.Exec(ctx, query, messageTTL, time.Now(), conversationID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateConversationInfo implements domain.ConversationRepository.
+func (c *conversationRepository) UpdateConversationInfo(ctx context.Context, conversationID string, title string, avatar string) error {
+	query := `UPDATE conversation SET title = $1, avatar = $2, updated_at = $3 WHERE id = $4`
+	_, err := c.db.Exec(ctx, query, title, avatar, time.Now(), conversationID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
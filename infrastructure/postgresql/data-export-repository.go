@@ -0,0 +1,71 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/pkg/observability"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type dataExportRepository struct {
+	db  *pgxpool.Pool
+	obs *observability.Observability
+}
+
+// CreateDataExportJob implements domain.DataExportRepository.
+func (d *dataExportRepository) CreateDataExportJob(ctx context.Context, job *domain.DataExportJob) error {
+	ctx, span := d.obs.StartSpan(ctx, "DataExportRepository.CreateDataExportJob")
+	defer span()
+	logger := d.obs.Logger.WithContext(ctx)
+	query := `
+		INSERT INTO data_export_job (id, user_id, account_id, status, object_name, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err := d.db.Exec(ctx, query, job.ID, job.UserID, job.AccountID, job.Status, job.ObjectName, job.CreatedAt, job.UpdatedAt)
+	if err != nil {
+		logger.Error("failed to create data export job", err)
+		return err
+	}
+	return nil
+}
+
+// GetDataExportJobByID implements domain.DataExportRepository.
+func (d *dataExportRepository) GetDataExportJobByID(ctx context.Context, id string) (*domain.DataExportJob, error) {
+	ctx, span := d.obs.StartSpan(ctx, "DataExportRepository.GetDataExportJobByID")
+	defer span()
+	var job domain.DataExportJob
+	fields, values := job.MapFields()
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE id = $1`, strings.Join(fields, ","), job.TableName())
+	err := d.db.QueryRow(ctx, query, id).Scan(values...)
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// UpdateDataExportJob implements domain.DataExportRepository.
+func (d *dataExportRepository) UpdateDataExportJob(ctx context.Context, job *domain.DataExportJob) error {
+	ctx, span := d.obs.StartSpan(ctx, "DataExportRepository.UpdateDataExportJob")
+	defer span()
+	logger := d.obs.Logger.WithContext(ctx)
+	query := `
+		UPDATE data_export_job
+		SET status = $1, object_name = $2, updated_at = $3, completed_at = $4
+		WHERE id = $5
+	`
+	_, err := d.db.Exec(ctx, query, job.Status, job.ObjectName, job.UpdatedAt, job.CompletedAt, job.ID)
+	if err != nil {
+		logger.Error("failed to update data export job", err)
+		return err
+	}
+	return nil
+}
+
+var _ domain.DataExportRepository = &dataExportRepository{}
+
+func NewDataExportRepository(db *pgxpool.Pool, obs *observability.Observability) domain.DataExportRepository {
+	return &dataExportRepository{db: db, obs: obs}
+}
@@ -97,4 +97,21 @@ func (f *fcmRepository) GetFcmTokenByUserID(ctx context.Context, userID string)
 	return fcmTokens, nil
 }
 
+// DeleteFcmTokenByUserID implements domain.FcmTokenRepository.
+func (f *fcmRepository) DeleteFcmTokenByUserID(ctx context.Context, userID string) error {
+	ctx, span := f.obs.StartSpan(ctx, "FcmRepository.DeleteFcmTokenByUserID")
+	defer span()
+	logger := f.obs.Logger.WithContext(ctx)
+	query := `
+		DELETE FROM fcm_token
+		WHERE user_id = $1
+	`
+	_, err := f.db.Exec(ctx, query, userID)
+	if err != nil {
+		logger.Error("failed to delete fcm token by user id", err)
+		return err
+	}
+	return nil
+}
+
 var _ domain.FcmTokenRepository = &fcmRepository{}
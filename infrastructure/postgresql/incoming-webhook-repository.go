@@ -0,0 +1,139 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/pkg/observability"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type incomingWebhookRepository struct {
+	db  *pgxpool.Pool
+	obs *observability.Observability
+}
+
+// CreateIncomingWebhook implements domain.IncomingWebhookRepository.
+// The bot, its membership of the conversation and the webhook are created in
+// one transaction so a failure never leaves a bot without its webhook.
+func (i *incomingWebhookRepository) CreateIncomingWebhook(ctx context.Context, account *domain.Account, user *domain.UserInfo, bot *domain.Bot, member *domain.ConversationMember, incomingWebhook *domain.IncomingWebhook) error {
+	ctx, span := i.obs.StartSpan(ctx, "IncomingWebhookRepository.CreateIncomingWebhook")
+	defer span()
+	logger := i.obs.Logger.WithContext(ctx)
+	tx, err := i.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = insertBot(ctx, tx, account, user, bot)
+	if err != nil {
+		logger.Error("failed to create incoming webhook bot", err)
+		return err
+	}
+
+	query := `INSERT INTO conversation_member (id, conversation_id, user_id, role, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err = tx.Exec(ctx, query, member.ID, member.ConversationID, member.UserID, member.Role, member.CreatedAt, member.UpdatedAt)
+	if err != nil {
+		logger.Error("failed to add incoming webhook bot to conversation", err)
+		return err
+	}
+
+	query = `
+		INSERT INTO incoming_webhook (id, conversation_id, bot_id, created_by, name, prefix, token_hash, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	_, err = tx.Exec(ctx, query, incomingWebhook.ID, incomingWebhook.ConversationID, incomingWebhook.BotID, incomingWebhook.CreatedBy, incomingWebhook.Name, incomingWebhook.Prefix, incomingWebhook.TokenHash, incomingWebhook.CreatedAt)
+	if err != nil {
+		logger.Error("failed to create incoming webhook", err)
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// GetIncomingWebhookByID implements domain.IncomingWebhookRepository.
+func (i *incomingWebhookRepository) GetIncomingWebhookByID(ctx context.Context, id string) (*domain.IncomingWebhook, error) {
+	ctx, span := i.obs.StartSpan(ctx, "IncomingWebhookRepository.GetIncomingWebhookByID")
+	defer span()
+	var incomingWebhook domain.IncomingWebhook
+	fields, values := incomingWebhook.MapFields()
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE id = $1`, strings.Join(fields, ","), incomingWebhook.TableName())
+	err := i.db.QueryRow(ctx, query, id).Scan(values...)
+	if err != nil {
+		return nil, err
+	}
+	return &incomingWebhook, nil
+}
+
+// GetActiveIncomingWebhookByHash implements domain.IncomingWebhookRepository.
+func (i *incomingWebhookRepository) GetActiveIncomingWebhookByHash(ctx context.Context, tokenHash string) (*domain.IncomingWebhook, error) {
+	ctx, span := i.obs.StartSpan(ctx, "IncomingWebhookRepository.GetActiveIncomingWebhookByHash")
+	defer span()
+	var incomingWebhook domain.IncomingWebhook
+	fields, values := incomingWebhook.MapFields()
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE token_hash = $1 AND revoked_at IS NULL`, strings.Join(fields, ","), incomingWebhook.TableName())
+	err := i.db.QueryRow(ctx, query, tokenHash).Scan(values...)
+	if err != nil {
+		return nil, err
+	}
+	return &incomingWebhook, nil
+}
+
+// GetListIncomingWebhookByConversationID implements domain.IncomingWebhookRepository.
+func (i *incomingWebhookRepository) GetListIncomingWebhookByConversationID(ctx context.Context, conversationID string) ([]*domain.IncomingWebhook, error) {
+	ctx, span := i.obs.StartSpan(ctx, "IncomingWebhookRepository.GetListIncomingWebhookByConversationID")
+	defer span()
+	var temp domain.IncomingWebhook
+	fields, _ := temp.MapFields()
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE conversation_id = $1 ORDER BY id DESC`, strings.Join(fields, ","), temp.TableName())
+	rows, err := i.db.Query(ctx, query, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var incomingWebhooks []*domain.IncomingWebhook
+	for rows.Next() {
+		var incomingWebhook domain.IncomingWebhook
+		_, values := incomingWebhook.MapFields()
+		if err := rows.Scan(values...); err != nil {
+			return nil, err
+		}
+		incomingWebhooks = append(incomingWebhooks, &incomingWebhook)
+	}
+	return incomingWebhooks, nil
+}
+
+// RevokeIncomingWebhook implements domain.IncomingWebhookRepository.
+func (i *incomingWebhookRepository) RevokeIncomingWebhook(ctx context.Context, id string, revokedAt time.Time) error {
+	ctx, span := i.obs.StartSpan(ctx, "IncomingWebhookRepository.RevokeIncomingWebhook")
+	defer span()
+	logger := i.obs.Logger.WithContext(ctx)
+	query := `UPDATE incoming_webhook SET revoked_at = $1 WHERE id = $2 AND revoked_at IS NULL`
+	_, err := i.db.Exec(ctx, query, revokedAt, id)
+	if err != nil {
+		logger.Error("failed to revoke incoming webhook", err)
+		return err
+	}
+	return nil
+}
+
+// UpdateIncomingWebhookLastUsedAt implements domain.IncomingWebhookRepository.
+func (i *incomingWebhookRepository) UpdateIncomingWebhookLastUsedAt(ctx context.Context, id string, lastUsedAt time.Time) error {
+	query := `UPDATE incoming_webhook SET last_used_at = $1 WHERE id = $2`
+	_, err := i.db.Exec(ctx, query, lastUsedAt, id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+var _ domain.IncomingWebhookRepository = &incomingWebhookRepository{}
+
+func NewIncomingWebhookRepository(db *pgxpool.Pool, obs *observability.Observability) domain.IncomingWebhookRepository {
+	return &incomingWebhookRepository{db: db, obs: obs}
+}
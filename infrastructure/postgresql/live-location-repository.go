@@ -0,0 +1,105 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/pkg/observability"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type liveLocationRepository struct {
+	db  *pgxpool.Pool
+	obs *observability.Observability
+}
+
+// CreateLiveLocation implements domain.LiveLocationRepository.
+func (l *liveLocationRepository) CreateLiveLocation(ctx context.Context, liveLocation *domain.LiveLocation) error {
+	ctx, span := l.obs.StartSpan(ctx, "LiveLocationRepository.CreateLiveLocation")
+	defer span()
+	logger := l.obs.Logger.WithContext(ctx)
+	query := `
+		INSERT INTO live_location (message_id, conversation_id, user_id, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	_, err := l.db.Exec(ctx, query, liveLocation.MessageID, liveLocation.ConversationID, liveLocation.UserID, liveLocation.ExpiresAt, liveLocation.CreatedAt)
+	if err != nil {
+		logger.Error("failed to create live location", err)
+		return err
+	}
+	return nil
+}
+
+// GetLiveLocationByMessageID implements domain.LiveLocationRepository.
+func (l *liveLocationRepository) GetLiveLocationByMessageID(ctx context.Context, messageID string) (*domain.LiveLocation, error) {
+	ctx, span := l.obs.StartSpan(ctx, "LiveLocationRepository.GetLiveLocationByMessageID")
+	defer span()
+	var liveLocation domain.LiveLocation
+	fields, values := liveLocation.MapFields()
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE message_id = $1`, strings.Join(fields, ","), liveLocation.TableName())
+	err := l.db.QueryRow(ctx, query, messageID).Scan(values...)
+	if err != nil {
+		return nil, err
+	}
+	return &liveLocation, nil
+}
+
+// EndLiveLocation implements domain.LiveLocationRepository.
+// It reports false when the live location had already ended.
+func (l *liveLocationRepository) EndLiveLocation(ctx context.Context, messageID string, endedAt time.Time) (bool, error) {
+	ctx, span := l.obs.StartSpan(ctx, "LiveLocationRepository.EndLiveLocation")
+	defer span()
+	logger := l.obs.Logger.WithContext(ctx)
+	tag, err := l.db.Exec(ctx, `UPDATE live_location SET ended_at = $1 WHERE message_id = $2 AND ended_at IS NULL`, endedAt, messageID)
+	if err != nil {
+		logger.Error("failed to end live location", err)
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// EndExpiredLiveLocations implements domain.LiveLocationRepository.
+// Rows are ended at their expiry in the same statement that selects them, so
+// concurrent workers never return the same live location twice.
+func (l *liveLocationRepository) EndExpiredLiveLocations(ctx context.Context, now time.Time, limit int) ([]*domain.LiveLocation, error) {
+	ctx, span := l.obs.StartSpan(ctx, "LiveLocationRepository.EndExpiredLiveLocations")
+	defer span()
+	var temp domain.LiveLocation
+	fields, _ := temp.MapFields()
+	query := fmt.Sprintf(`
+		UPDATE %s SET ended_at = expires_at
+		WHERE message_id IN (
+			SELECT message_id FROM live_location
+			WHERE ended_at IS NULL AND expires_at <= $1
+			ORDER BY expires_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING %s
+	`, temp.TableName(), strings.Join(fields, ","))
+	rows, err := l.db.Query(ctx, query, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var liveLocations []*domain.LiveLocation
+	for rows.Next() {
+		var liveLocation domain.LiveLocation
+		_, values := liveLocation.MapFields()
+		if err := rows.Scan(values...); err != nil {
+			return nil, err
+		}
+		liveLocations = append(liveLocations, &liveLocation)
+	}
+	return liveLocations, nil
+}
+
+var _ domain.LiveLocationRepository = &liveLocationRepository{}
+
+func NewLiveLocationRepository(db *pgxpool.Pool, obs *observability.Observability) domain.LiveLocationRepository {
+	return &liveLocationRepository{db: db, obs: obs}
+}
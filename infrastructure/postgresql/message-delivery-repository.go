@@ -0,0 +1,77 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/pkg/observability"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type messageDeliveryRepository struct {
+	db  *pgxpool.Pool
+	obs *observability.Observability
+}
+
+// CreateMessageDeliveries implements domain.MessageDeliveryRepository.
+// Users the message was already delivered to keep their first delivery, the
+// users it is newly delivered to are returned.
+func (m *messageDeliveryRepository) CreateMessageDeliveries(ctx context.Context, messageID string, userIDs []string, deliveredAt time.Time) ([]string, error) {
+	ctx, span := m.obs.StartSpan(ctx, "MessageDeliveryRepository.CreateMessageDeliveries")
+	defer span()
+	logger := m.obs.Logger.WithContext(ctx)
+	query := `
+		INSERT INTO message_delivery (message_id, user_id, delivered_at)
+		SELECT $1, user_id, $3 FROM unnest($2::text[]) AS user_id
+		ON CONFLICT (message_id, user_id) DO NOTHING
+		RETURNING user_id
+	`
+	rows, err := m.db.Query(ctx, query, messageID, userIDs, deliveredAt)
+	if err != nil {
+		logger.Error("failed to create message deliveries", err, messageID)
+		return nil, err
+	}
+	defer rows.Close()
+	var delivered []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		delivered = append(delivered, userID)
+	}
+	return delivered, rows.Err()
+}
+
+// GetListMessageDelivery implements domain.MessageDeliveryRepository.
+func (m *messageDeliveryRepository) GetListMessageDelivery(ctx context.Context, messageID string) ([]*domain.MessageDelivery, error) {
+	ctx, span := m.obs.StartSpan(ctx, "MessageDeliveryRepository.GetListMessageDelivery")
+	defer span()
+	var temp domain.MessageDelivery
+	fields, _ := temp.MapFields()
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE message_id = $1`, strings.Join(fields, ","), temp.TableName())
+	rows, err := m.db.Query(ctx, query, messageID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var deliveries []*domain.MessageDelivery
+	for rows.Next() {
+		var delivery domain.MessageDelivery
+		_, values := delivery.MapFields()
+		if err := rows.Scan(values...); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, &delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+var _ domain.MessageDeliveryRepository = &messageDeliveryRepository{}
+
+func NewMessageDeliveryRepository(db *pgxpool.Pool, obs *observability.Observability) domain.MessageDeliveryRepository {
+	return &messageDeliveryRepository{db: db, obs: obs}
+}
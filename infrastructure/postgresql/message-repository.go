@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/chat-socio/backend/internal/domain"
 	"github.com/jackc/pgx/v5"
@@ -16,16 +17,130 @@ type messageRepository struct {
 
 // CreateMessage implements domain.MessageRepository.
 func (m *messageRepository) CreateMessage(ctx context.Context, message *domain.Message) (*domain.Message, error) {
-	query := `INSERT INTO message (id, conversation_id, user_id, type, body, created_at, updated_at, deleted_at, reply_to) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
-	_, err := m.db.Exec(ctx, query, message.ID, message.ConversationID, message.UserID, message.Type, message.Body, message.CreatedAt, message.UpdatedAt, message.DeletedAt, message.ReplyTo)
+	query := `INSERT INTO message (id, conversation_id, user_id, type, body, created_at, updated_at, deleted_at, reply_to, display_name, thread_id, forwarded_from, expires_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+	_, err := m.db.Exec(ctx, query, message.ID, message.ConversationID, message.UserID, message.Type, message.Body, message.CreatedAt, message.UpdatedAt, message.DeletedAt, message.ReplyTo, message.DisplayName, message.ThreadID, message.ForwardedFrom, message.ExpiresAt)
 	if err != nil {
 		return nil, err
 	}
 	return message, nil
 }
 
+// CreateMessages implements domain.MessageRepository.
+// The messages are stored in one transaction, either all of them or none.
+func (m *messageRepository) CreateMessages(ctx context.Context, messages []*domain.Message) error {
+	tx, err := m.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `INSERT INTO message (id, conversation_id, user_id, type, body, created_at, updated_at, deleted_at, reply_to, display_name, thread_id, forwarded_from, expires_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+	for _, message := range messages {
+		_, err = tx.Exec(ctx, query, message.ID, message.ConversationID, message.UserID, message.Type, message.Body, message.CreatedAt, message.UpdatedAt, message.DeletedAt, message.ReplyTo, message.DisplayName, message.ThreadID, message.ForwardedFrom, message.ExpiresAt)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
 // GetListMessageByConversationID implements domain.MessageRepository.
 func (m *messageRepository) GetListMessageByConversationID(ctx context.Context, conversationID string, lastID string, limit int) ([]*domain.Message, error) {
+	condition := "m.conversation_id = $1 AND (m.expires_at IS NULL OR m.expires_at > now())"
+	params := []any{conversationID}
+	if lastID != "" {
+		condition = fmt.Sprintf("%s AND m.id < $2", condition)
+		params = append(params, lastID)
+	}
+	return m.queryMessagesWithUser(ctx, fmt.Sprintf("%s ORDER BY m.id DESC LIMIT %d", condition, limit), params...)
+}
+
+// GetListMessageAfterID implements domain.MessageRepository.
+// Messages newer than afterID are returned oldest first.
+func (m *messageRepository) GetListMessageAfterID(ctx context.Context, conversationID string, afterID string, limit int) ([]*domain.Message, error) {
+	condition := "m.conversation_id = $1 AND m.id > $2 AND (m.expires_at IS NULL OR m.expires_at > now())"
+	return m.queryMessagesWithUser(ctx, fmt.Sprintf("%s ORDER BY m.id ASC LIMIT %d", condition, limit), conversationID, afterID)
+}
+
+// GetMessageByID implements domain.MessageRepository.
+func (m *messageRepository) GetMessageByID(ctx context.Context, id string) (*domain.Message, error) {
+	messages, err := m.queryMessagesWithUser(ctx, "m.id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	if len(messages) == 0 {
+		return nil, pgx.ErrNoRows
+	}
+	return messages[0], nil
+}
+
+// GetListMessageByUserID implements domain.MessageRepository.
+// Messages are returned oldest first, afterID is the id of the last message of
+// the previous page.
+func (m *messageRepository) GetListMessageByUserID(ctx context.Context, userID string, afterID string, limit int) ([]*domain.Message, error) {
+	var temp domain.Message
+	fields, _ := temp.MapFields()
+	condition := "user_id = $1"
+	params := []any{userID}
+	if afterID != "" {
+		condition = fmt.Sprintf("%s AND id > $2", condition)
+		params = append(params, afterID)
+	}
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE %s ORDER BY id ASC LIMIT %d`, strings.Join(fields, ","), temp.TableName(), condition, limit)
+	rows, err := m.db.Query(ctx, query, params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []*domain.Message
+	for rows.Next() {
+		var message domain.Message
+		_, values := message.MapFields()
+		if err := rows.Scan(values...); err != nil {
+			return nil, err
+		}
+		messages = append(messages, &message)
+	}
+	return messages, nil
+}
+
+// ReassignMessagesByUserID implements domain.MessageRepository.
+func (m *messageRepository) ReassignMessagesByUserID(ctx context.Context, fromUserID string, toUserID string, limit int) (int64, error) {
+	query := `UPDATE message SET user_id = $1 WHERE id IN (SELECT id FROM message WHERE user_id = $2 ORDER BY id LIMIT $3)`
+	tag, err := m.db.Exec(ctx, query, toUserID, fromUserID, limit)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// UpdateMessageBody implements domain.MessageRepository.
+func (m *messageRepository) UpdateMessageBody(ctx context.Context, id string, body string, updatedAt time.Time) error {
+	query := `UPDATE message SET body = $1, updated_at = $2 WHERE id = $3`
+	_, err := m.db.Exec(ctx, query, body, updatedAt, id)
+	return err
+}
+
+// GetListMessageByThreadID implements domain.MessageRepository.
+// Replies are returned oldest first, lastID is the id of the last reply of the
+// previous page.
+func (m *messageRepository) GetListMessageByThreadID(ctx context.Context, threadID string, lastID string, limit int) ([]*domain.Message, error) {
+	condition := "m.thread_id = $1 AND (m.expires_at IS NULL OR m.expires_at > now())"
+	params := []any{threadID}
+	if lastID != "" {
+		condition = fmt.Sprintf("%s AND m.id > $2", condition)
+		params = append(params, lastID)
+	}
+	return m.queryMessagesWithUser(ctx, fmt.Sprintf("%s ORDER BY m.id ASC LIMIT %d", condition, limit), params...)
+}
+
+// GetListMessageByIDs implements domain.MessageRepository.
+func (m *messageRepository) GetListMessageByIDs(ctx context.Context, ids []string) ([]*domain.Message, error) {
+	return m.queryMessagesWithUser(ctx, "m.id = ANY($1)", ids)
+}
+
+func (m *messageRepository) queryMessagesWithUser(ctx context.Context, condition string, params ...any) ([]*domain.Message, error) {
 	fields := []string{
 		"m.id",
 		"m.conversation_id",
@@ -35,36 +150,28 @@ func (m *messageRepository) GetListMessageByConversationID(ctx context.Context,
 		"m.created_at",
 		"m.updated_at",
 		"m.deleted_at",
-		"m.reply_to",
+		"COALESCE(m.reply_to, '')",
+		"m.display_name",
+		"m.thread_id",
+		"m.forwarded_from",
+		"m.expires_at",
 		"u.id",
 		"u.full_name",
 		"u.avatar",
 		"u.type",
 	}
-	condition := "conversation_id = $1"
-	if lastID != "" {
-		condition = fmt.Sprintf("%s AND m.id < $2", condition)
-	}
-	query := fmt.Sprintf(`SELECT %s FROM message AS m JOIN user_info AS u ON m.user_id = u.id WHERE %s ORDER BY m.id DESC LIMIT %d`, strings.Join(fields, ","), condition, limit)
-	var params []any
-	params = append(params, conversationID)
-	if lastID != "" {
-		params = append(params, lastID)
-	}
+	query := fmt.Sprintf(`SELECT %s FROM message AS m JOIN user_info AS u ON m.user_id = u.id WHERE %s`, strings.Join(fields, ","), condition)
 	rows, err := m.db.Query(ctx, query, params...)
-	if err != nil && err != pgx.ErrNoRows {
+	if err != nil {
 		return nil, err
 	}
-	if err == pgx.ErrNoRows {
-		return []*domain.Message{}, nil
-	}
 	defer rows.Close()
 
 	var messages []*domain.Message
 	for rows.Next() {
 		var message domain.Message
 		var user domain.UserInfo
-		values := []any{
+		err := rows.Scan(
 			&message.ID,
 			&message.ConversationID,
 			&message.UserID,
@@ -74,12 +181,15 @@ func (m *messageRepository) GetListMessageByConversationID(ctx context.Context,
 			&message.UpdatedAt,
 			&message.DeletedAt,
 			&message.ReplyTo,
+			&message.DisplayName,
+			&message.ThreadID,
+			&message.ForwardedFrom,
+			&message.ExpiresAt,
 			&user.ID,
 			&user.FullName,
 			&user.Avatar,
 			&user.Type,
-		}
-		err := rows.Scan(values...)
+		)
 		if err != nil {
 			return nil, err
 		}
@@ -89,48 +199,222 @@ func (m *messageRepository) GetListMessageByConversationID(ctx context.Context,
 	return messages, nil
 }
 
-// GetMessageByID implements domain.MessageRepository.
-func (m *messageRepository) GetMessageByID(ctx context.Context, id string) (*domain.Message, error) {
-	fields := []string{
-		"m.id",
-		"m.conversation_id",
-		"m.user_id",
-		"m.type",
-		"m.body",
-		"m.created_at",
-		"m.updated_at",
-		"m.deleted_at",
-		"m.reply_to",
-		"u.id",
-		"u.full_name",
-		"u.avatar",
-		"u.type",
+// GetListThreadSummary implements domain.MessageRepository.
+// Deleted and expired replies are not counted, roots without replies are left
+// out.
+func (m *messageRepository) GetListThreadSummary(ctx context.Context, rootIDs []string) ([]*domain.ThreadSummary, error) {
+	query := `
+		SELECT thread_id, COUNT(*), (ARRAY_AGG(id ORDER BY id DESC))[1], MAX(created_at),
+			ARRAY(
+				SELECT user_id FROM message p
+				WHERE p.thread_id = m.thread_id AND p.deleted_at IS NULL AND (p.expires_at IS NULL OR p.expires_at > now())
+				GROUP BY user_id ORDER BY MAX(p.id) DESC
+			)
+		FROM message m
+		WHERE thread_id = ANY($1) AND deleted_at IS NULL AND (expires_at IS NULL OR expires_at > now())
+		GROUP BY thread_id
+	`
+	rows, err := m.db.Query(ctx, query, rootIDs)
+	if err != nil {
+		return nil, err
 	}
-	query := fmt.Sprintf(`SELECT %s FROM message AS m JOIN user_info AS u ON m.user_id = u.id WHERE m.id = $1`, strings.Join(fields, ","))
-	row := m.db.QueryRow(ctx, query, id)
-	var message domain.Message
-	var user domain.UserInfo
-	values := []any{
-		&message.ID,
-		&message.ConversationID,
-		&message.UserID,
-		&message.Type,
-		&message.Body,
-		&message.CreatedAt,
-		&message.UpdatedAt,
-		&message.DeletedAt,
-		&message.ReplyTo,
-		&user.ID,
-		&user.FullName,
-		&user.Avatar,
-		&user.Type,
-	}
-	err := row.Scan(values...)
+	defer rows.Close()
+
+	var summaries []*domain.ThreadSummary
+	for rows.Next() {
+		var summary domain.ThreadSummary
+		err := rows.Scan(&summary.RootID, &summary.ReplyCount, &summary.LastReplyID, &summary.LastReplyAt, &summary.ParticipantIDs)
+		if err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, &summary)
+	}
+	return summaries, nil
+}
+
+// DeleteExpiredMessages implements domain.MessageRepository.
+// The expired messages are removed with the rows that point at them, the
+// deleted messages are returned. Read markers are kept since they are only
+// compared by id, seen_message has no foreign key to message.
+func (m *messageRepository) DeleteExpiredMessages(ctx context.Context, now time.Time, limit int) ([]*domain.Message, error) {
+	tx, err := m.db.BeginTx(ctx, pgx.TxOptions{})
 	if err != nil {
 		return nil, err
 	}
-	message.User = &user
-	return &message, nil
+	defer tx.Rollback(ctx)
+
+	var temp domain.Message
+	fields, _ := temp.MapFields()
+	query := fmt.Sprintf(`
+		SELECT %s FROM %s
+		WHERE expires_at <= $1
+		ORDER BY expires_at
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	`, strings.Join(fields, ","), temp.TableName())
+	rows, err := tx.Query(ctx, query, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	var messages []*domain.Message
+	var ids []string
+	for rows.Next() {
+		var message domain.Message
+		_, values := message.MapFields()
+		if err := rows.Scan(values...); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		messages = append(messages, &message)
+		ids = append(ids, message.ID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(messages) == 0 {
+		return messages, nil
+	}
+
+	for _, query := range []string{
+		`DELETE FROM message_delivery WHERE message_id = ANY($1)`,
+		`DELETE FROM poll_vote WHERE message_id = ANY($1)`,
+		`DELETE FROM pinned_message WHERE message_id = ANY($1)`,
+		`DELETE FROM live_location WHERE message_id = ANY($1)`,
+		`DELETE FROM message WHERE id = ANY($1)`,
+	} {
+		_, err = tx.Exec(ctx, query, ids)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return messages, tx.Commit(ctx)
+}
+
+// CheckMessageBodyInUse implements domain.MessageRepository.
+// Any file message, pending scheduled message, conversation avatar or user
+// avatar still pointing at the url keeps the file, whoever it belongs to.
+func (m *messageRepository) CheckMessageBodyInUse(ctx context.Context, body string) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM message WHERE body = $1 AND type IN ('image', 'video', 'audio', 'file'))
+		OR EXISTS (SELECT 1 FROM scheduled_message WHERE body = $1 AND status IN ('pending', 'sending'))
+		OR EXISTS (SELECT 1 FROM conversation WHERE avatar = $1)
+		OR EXISTS (SELECT 1 FROM user_info WHERE avatar = $1)`
+	var inUse bool
+	err := m.db.QueryRow(ctx, query, body).Scan(&inUse)
+	if err != nil {
+		return false, err
+	}
+	return inUse, nil
+}
+
+// SearchMessages implements domain.MessageRepository.
+// Bodies matching the full-text query come first, partial words and typos are
+// caught by trigram similarity. Results are ordered by rank then id. The body
+// is HTML escaped before highlighting so the <mark> tags are the only markup in
+// the snippet.
+func (m *messageRepository) SearchMessages(ctx context.Context, filter *domain.MessageSearchFilter) ([]*domain.MessageSearchResult, error) {
+	conditions := []string{
+		"m.type IN ('text', 'action')",
+		"m.deleted_at IS NULL",
+		"(m.expires_at IS NULL OR m.expires_at > now())",
+		"m.conversation_id IN (SELECT conversation_id FROM conversation_member WHERE user_id = $1)",
+		`(to_tsvector('simple', immutable_unaccent(m.body)) @@ q.tsq
+			OR immutable_unaccent(lower(m.body)) LIKE '%' || q.pattern || '%'
+			OR q.term <% immutable_unaccent(lower(m.body)))`,
+	}
+	params := []any{filter.UserID, filter.Query, escapeLike(filter.Query)}
+	addCondition := func(condition string, param any) {
+		params = append(params, param)
+		conditions = append(conditions, fmt.Sprintf(condition, len(params)))
+	}
+	if filter.ConversationID != "" {
+		addCondition("m.conversation_id = $%d", filter.ConversationID)
+	}
+	if filter.FromUserID != "" {
+		addCondition("m.user_id = $%d", filter.FromUserID)
+	}
+	if filter.Before != nil {
+		addCondition("m.created_at < $%d", filter.Before)
+	}
+	if filter.After != nil {
+		addCondition("m.created_at > $%d", filter.After)
+	}
+	if filter.Type != "" {
+		addCondition("m.type = $%d", filter.Type)
+	}
+	cursor := ""
+	if filter.CursorID != "" {
+		params = append(params, filter.CursorRank, filter.CursorID)
+		cursor = fmt.Sprintf("WHERE (s.rank, s.id) < ($%d::real, $%d)", len(params)-1, len(params))
+	}
+	params = append(params, filter.Limit)
+
+	query := fmt.Sprintf(`
+		WITH q AS (
+			SELECT websearch_to_tsquery('simple', immutable_unaccent($2)) AS tsq,
+				immutable_unaccent(lower($2)) AS term,
+				immutable_unaccent(lower($3)) AS pattern
+		)
+		SELECT p.id, p.rank, ts_headline('simple', replace(replace(replace(replace(replace(p.body, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&#34;'), '''', '&#39;'), q.tsq, 'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5')
+		FROM (
+			SELECT s.id, s.rank, s.body FROM (
+				SELECT m.id, m.body,
+					(ts_rank_cd(to_tsvector('simple', immutable_unaccent(m.body)), q.tsq) + word_similarity(q.term, immutable_unaccent(lower(m.body))))::real AS rank
+				FROM message m, q
+				WHERE %s
+			) s
+			%s
+			ORDER BY s.rank DESC, s.id DESC
+			LIMIT $%d
+		) p, q
+		ORDER BY p.rank DESC, p.id DESC
+	`, strings.Join(conditions, " AND "), cursor, len(params))
+	rows, err := m.db.Query(ctx, query, params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.MessageSearchResult
+	var ids []string
+	for rows.Next() {
+		var result domain.MessageSearchResult
+		var id string
+		err := rows.Scan(&id, &result.Rank, &result.Snippet)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, &result)
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return results, nil
+	}
+
+	messages, err := m.GetListMessageByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	messageByID := make(map[string]*domain.Message, len(messages))
+	for _, message := range messages {
+		messageByID[message.ID] = message
+	}
+	found := results[:0]
+	for i, result := range results {
+		result.Message = messageByID[ids[i]]
+		if result.Message != nil {
+			found = append(found, result)
+		}
+	}
+	return found, nil
+}
+
+// escapeLike escapes the wildcards of a LIKE pattern.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
 }
 
 var _ domain.MessageRepository = &messageRepository{}
@@ -0,0 +1,126 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/pkg/observability"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type pinnedMessageRepository struct {
+	db  *pgxpool.Pool
+	obs *observability.Observability
+}
+
+// CreatePinnedMessage implements domain.PinnedMessageRepository.
+// Pinning a pinned message again is a no-op. The conversation row is locked
+// while counting so concurrent pins can not go over the limit, it reports
+// false when the limit is reached and returns the number of pins.
+func (p *pinnedMessageRepository) CreatePinnedMessage(ctx context.Context, pin *domain.PinnedMessage, limit int) (bool, int, error) {
+	ctx, span := p.obs.StartSpan(ctx, "PinnedMessageRepository.CreatePinnedMessage")
+	defer span()
+	logger := p.obs.Logger.WithContext(ctx)
+	tx, err := p.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return false, 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `SELECT 1 FROM conversation WHERE id = $1 FOR UPDATE`, pin.ConversationID)
+	if err != nil {
+		logger.Error("failed to lock conversation", err)
+		return false, 0, err
+	}
+	var count int
+	var pinned bool
+	query := `SELECT count(*), coalesce(bool_or(message_id = $2), false) FROM pinned_message WHERE conversation_id = $1`
+	err = tx.QueryRow(ctx, query, pin.ConversationID, pin.MessageID).Scan(&count, &pinned)
+	if err != nil {
+		logger.Error("failed to count pinned messages", err)
+		return false, 0, err
+	}
+	if pinned {
+		return true, count, nil
+	}
+	if count >= limit {
+		return false, count, nil
+	}
+
+	query = `
+		INSERT INTO pinned_message (conversation_id, message_id, pinned_by, pinned_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (conversation_id, message_id) DO NOTHING
+	`
+	tag, err := tx.Exec(ctx, query, pin.ConversationID, pin.MessageID, pin.PinnedBy, pin.PinnedAt)
+	if err != nil {
+		logger.Error("failed to create pinned message", err)
+		return false, 0, err
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return false, 0, err
+	}
+	return true, count + int(tag.RowsAffected()), nil
+}
+
+// DeletePinnedMessage implements domain.PinnedMessageRepository.
+// It reports whether the message was pinned.
+func (p *pinnedMessageRepository) DeletePinnedMessage(ctx context.Context, conversationID string, messageID string) (bool, error) {
+	ctx, span := p.obs.StartSpan(ctx, "PinnedMessageRepository.DeletePinnedMessage")
+	defer span()
+	logger := p.obs.Logger.WithContext(ctx)
+	tag, err := p.db.Exec(ctx, `DELETE FROM pinned_message WHERE conversation_id = $1 AND message_id = $2`, conversationID, messageID)
+	if err != nil {
+		logger.Error("failed to delete pinned message", err)
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// GetListPinnedMessage implements domain.PinnedMessageRepository.
+// The most recent pins come first.
+func (p *pinnedMessageRepository) GetListPinnedMessage(ctx context.Context, conversationID string) ([]*domain.PinnedMessage, error) {
+	ctx, span := p.obs.StartSpan(ctx, "PinnedMessageRepository.GetListPinnedMessage")
+	defer span()
+	var temp domain.PinnedMessage
+	fields, _ := temp.MapFields()
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE conversation_id = $1 ORDER BY pinned_at DESC, message_id DESC`, strings.Join(fields, ","), temp.TableName())
+	rows, err := p.db.Query(ctx, query, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pins []*domain.PinnedMessage
+	for rows.Next() {
+		var pin domain.PinnedMessage
+		_, values := pin.MapFields()
+		if err := rows.Scan(values...); err != nil {
+			return nil, err
+		}
+		pins = append(pins, &pin)
+	}
+	return pins, nil
+}
+
+// CountPinnedMessage implements domain.PinnedMessageRepository.
+func (p *pinnedMessageRepository) CountPinnedMessage(ctx context.Context, conversationID string) (int, error) {
+	ctx, span := p.obs.StartSpan(ctx, "PinnedMessageRepository.CountPinnedMessage")
+	defer span()
+	var count int
+	err := p.db.QueryRow(ctx, `SELECT COUNT(*) FROM pinned_message WHERE conversation_id = $1`, conversationID).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+var _ domain.PinnedMessageRepository = &pinnedMessageRepository{}
+
+func NewPinnedMessageRepository(db *pgxpool.Pool, obs *observability.Observability) domain.PinnedMessageRepository {
+	return &pinnedMessageRepository{db: db, obs: obs}
+}
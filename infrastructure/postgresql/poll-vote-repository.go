@@ -0,0 +1,110 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/pkg/observability"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type pollVoteRepository struct {
+	db  *pgxpool.Pool
+	obs *observability.Observability
+}
+
+// CreatePollVotes implements domain.PollVoteRepository.
+// With replace the previous votes of the user on the poll are removed first,
+// which is how single choice polls change their vote.
+func (p *pollVoteRepository) CreatePollVotes(ctx context.Context, messageID string, userID string, votes []*domain.PollVote, replace bool) error {
+	ctx, span := p.obs.StartSpan(ctx, "PollVoteRepository.CreatePollVotes")
+	defer span()
+	logger := p.obs.Logger.WithContext(ctx)
+	tx, err := p.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if replace {
+		_, err = tx.Exec(ctx, `DELETE FROM poll_vote WHERE message_id = $1 AND user_id = $2`, messageID, userID)
+		if err != nil {
+			logger.Error("failed to delete poll votes", err)
+			return err
+		}
+	}
+	query := `
+		INSERT INTO poll_vote (id, message_id, option_id, user_id, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (message_id, option_id, user_id) DO NOTHING
+	`
+	for _, vote := range votes {
+		_, err = tx.Exec(ctx, query, vote.ID, messageID, vote.OptionID, userID, vote.CreatedAt)
+		if err != nil {
+			logger.Error("failed to create poll vote", err)
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// DeletePollVotes implements domain.PollVoteRepository.
+// An empty optionID removes every vote of the user on the poll.
+func (p *pollVoteRepository) DeletePollVotes(ctx context.Context, messageID string, userID string, optionID string) error {
+	ctx, span := p.obs.StartSpan(ctx, "PollVoteRepository.DeletePollVotes")
+	defer span()
+	logger := p.obs.Logger.WithContext(ctx)
+	query := `DELETE FROM poll_vote WHERE message_id = $1 AND user_id = $2 AND ($3 = '' OR option_id = $3)`
+	_, err := p.db.Exec(ctx, query, messageID, userID, optionID)
+	if err != nil {
+		logger.Error("failed to delete poll votes", err)
+		return err
+	}
+	return nil
+}
+
+// GetListPollVoteByMessageIDs implements domain.PollVoteRepository.
+func (p *pollVoteRepository) GetListPollVoteByMessageIDs(ctx context.Context, messageIDs []string) ([]*domain.PollVote, error) {
+	ctx, span := p.obs.StartSpan(ctx, "PollVoteRepository.GetListPollVoteByMessageIDs")
+	defer span()
+	var temp domain.PollVote
+	fields, _ := temp.MapFields()
+	for i := range fields {
+		fields[i] = "pv." + fields[i]
+	}
+	query := fmt.Sprintf(`
+		SELECT %s, u.full_name, u.avatar, u.type
+		FROM poll_vote pv
+		INNER JOIN user_info u ON u.id = pv.user_id
+		WHERE pv.message_id = ANY($1)
+		ORDER BY pv.created_at
+	`, strings.Join(fields, ","))
+	rows, err := p.db.Query(ctx, query, messageIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var votes []*domain.PollVote
+	for rows.Next() {
+		var vote domain.PollVote
+		user := domain.UserInfo{}
+		_, values := vote.MapFields()
+		if err := rows.Scan(append(values, &user.FullName, &user.Avatar, &user.Type)...); err != nil {
+			return nil, err
+		}
+		user.ID = vote.UserID
+		vote.User = &user
+		votes = append(votes, &vote)
+	}
+	return votes, nil
+}
+
+var _ domain.PollVoteRepository = &pollVoteRepository{}
+
+func NewPollVoteRepository(db *pgxpool.Pool, obs *observability.Observability) domain.PollVoteRepository {
+	return &pollVoteRepository{db: db, obs: obs}
+}
@@ -0,0 +1,138 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/pkg/observability"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type scheduledMessageRepository struct {
+	db  *pgxpool.Pool
+	obs *observability.Observability
+}
+
+// CreateScheduledMessage implements domain.ScheduledMessageRepository.
+func (s *scheduledMessageRepository) CreateScheduledMessage(ctx context.Context, scheduledMessage *domain.ScheduledMessage) error {
+	ctx, span := s.obs.StartSpan(ctx, "ScheduledMessageRepository.CreateScheduledMessage")
+	defer span()
+	logger := s.obs.Logger.WithContext(ctx)
+	query := `
+		INSERT INTO scheduled_message (id, conversation_id, user_id, type, body, reply_to, send_at, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+	_, err := s.db.Exec(ctx, query, scheduledMessage.ID, scheduledMessage.ConversationID, scheduledMessage.UserID, scheduledMessage.Type, scheduledMessage.Body, scheduledMessage.ReplyTo, scheduledMessage.SendAt, scheduledMessage.Status, scheduledMessage.CreatedAt, scheduledMessage.UpdatedAt)
+	if err != nil {
+		logger.Error("failed to create scheduled message", err)
+		return err
+	}
+	return nil
+}
+
+// GetListScheduledMessageByUserID implements domain.ScheduledMessageRepository.
+// Only messages still waiting to be sent are listed, soonest first. An empty
+// conversationID lists the messages of every conversation.
+func (s *scheduledMessageRepository) GetListScheduledMessageByUserID(ctx context.Context, userID string, conversationID string) ([]*domain.ScheduledMessage, error) {
+	ctx, span := s.obs.StartSpan(ctx, "ScheduledMessageRepository.GetListScheduledMessageByUserID")
+	defer span()
+	var temp domain.ScheduledMessage
+	fields, _ := temp.MapFields()
+	query := fmt.Sprintf(`
+		SELECT %s FROM %s
+		WHERE user_id = $1 AND ($2 = '' OR conversation_id = $2) AND status = $3
+		ORDER BY send_at, id
+	`, strings.Join(fields, ","), temp.TableName())
+	rows, err := s.db.Query(ctx, query, userID, conversationID, domain.ScheduledMessageStatusPending)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var scheduledMessages []*domain.ScheduledMessage
+	for rows.Next() {
+		var scheduledMessage domain.ScheduledMessage
+		_, values := scheduledMessage.MapFields()
+		if err := rows.Scan(values...); err != nil {
+			return nil, err
+		}
+		scheduledMessages = append(scheduledMessages, &scheduledMessage)
+	}
+	return scheduledMessages, nil
+}
+
+// CancelScheduledMessage implements domain.ScheduledMessageRepository.
+// It reports whether the message was still pending, a message the scheduler
+// already picked up can not be cancelled.
+func (s *scheduledMessageRepository) CancelScheduledMessage(ctx context.Context, id string, userID string, cancelledAt time.Time) (bool, error) {
+	ctx, span := s.obs.StartSpan(ctx, "ScheduledMessageRepository.CancelScheduledMessage")
+	defer span()
+	logger := s.obs.Logger.WithContext(ctx)
+	query := `UPDATE scheduled_message SET status = $1, updated_at = $2 WHERE id = $3 AND user_id = $4 AND status = $5`
+	tag, err := s.db.Exec(ctx, query, domain.ScheduledMessageStatusCancelled, cancelledAt, id, userID, domain.ScheduledMessageStatusPending)
+	if err != nil {
+		logger.Error("failed to cancel scheduled message", err)
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// ClaimDueScheduledMessages implements domain.ScheduledMessageRepository.
+// Claimed messages move to sending, messages stuck in sending for longer
+// than lease are claimed again.
+func (s *scheduledMessageRepository) ClaimDueScheduledMessages(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.ScheduledMessage, error) {
+	ctx, span := s.obs.StartSpan(ctx, "ScheduledMessageRepository.ClaimDueScheduledMessages")
+	defer span()
+	var temp domain.ScheduledMessage
+	fields, _ := temp.MapFields()
+	query := fmt.Sprintf(`
+		UPDATE %s SET status = $2, updated_at = $1
+		WHERE id IN (
+			SELECT id FROM scheduled_message
+			WHERE send_at <= $1 AND (status = $3 OR (status = $2 AND updated_at <= $4))
+			ORDER BY send_at
+			LIMIT $5
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING %s
+	`, temp.TableName(), strings.Join(fields, ","))
+	rows, err := s.db.Query(ctx, query, now, domain.ScheduledMessageStatusSending, domain.ScheduledMessageStatusPending, now.Add(-lease), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var scheduledMessages []*domain.ScheduledMessage
+	for rows.Next() {
+		var scheduledMessage domain.ScheduledMessage
+		_, values := scheduledMessage.MapFields()
+		if err := rows.Scan(values...); err != nil {
+			return nil, err
+		}
+		scheduledMessages = append(scheduledMessages, &scheduledMessage)
+	}
+	return scheduledMessages, nil
+}
+
+// UpdateScheduledMessageStatus implements domain.ScheduledMessageRepository.
+func (s *scheduledMessageRepository) UpdateScheduledMessageStatus(ctx context.Context, scheduledMessage *domain.ScheduledMessage) error {
+	ctx, span := s.obs.StartSpan(ctx, "ScheduledMessageRepository.UpdateScheduledMessageStatus")
+	defer span()
+	logger := s.obs.Logger.WithContext(ctx)
+	query := `UPDATE scheduled_message SET status = $1, message_id = $2, error = $3, updated_at = $4 WHERE id = $5`
+	_, err := s.db.Exec(ctx, query, scheduledMessage.Status, scheduledMessage.MessageID, scheduledMessage.Error, scheduledMessage.UpdatedAt, scheduledMessage.ID)
+	if err != nil {
+		logger.Error("failed to update scheduled message status", err)
+		return err
+	}
+	return nil
+}
+
+var _ domain.ScheduledMessageRepository = &scheduledMessageRepository{}
+
+func NewScheduledMessageRepository(db *pgxpool.Pool, obs *observability.Observability) domain.ScheduledMessageRepository {
+	return &scheduledMessageRepository{db: db, obs: obs}
+}
@@ -64,3 +64,29 @@ func (r *seenMessageRepository) GetListSeenMessageByConversationID(ctx context.C
 	}
 	return seenMessages, nil
 }
+
+func (r *seenMessageRepository) DeleteSeenMessageByUserID(ctx context.Context, userID string) error {
+	ctx, span := r.obs.StartSpan(ctx, "seenMessageRepository.DeleteSeenMessageByUserID")
+	defer span()
+	logger := r.obs.Logger.WithContext(ctx)
+	query := `DELETE FROM seen_message WHERE user_id = $1`
+	_, err := r.db.Exec(ctx, query, userID)
+	if err != nil {
+		logger.Error("failed to delete seen message by user id", err, userID)
+		return err
+	}
+	return nil
+}
+
+func (r *seenMessageRepository) DeleteSeenMessage(ctx context.Context, userID string, conversationID string) error {
+	ctx, span := r.obs.StartSpan(ctx, "seenMessageRepository.DeleteSeenMessage")
+	defer span()
+	logger := r.obs.Logger.WithContext(ctx)
+	query := `DELETE FROM seen_message WHERE user_id = $1 AND conversation_id = $2`
+	_, err := r.db.Exec(ctx, query, userID, conversationID)
+	if err != nil {
+		logger.Error("failed to delete seen message", err, userID, conversationID)
+		return err
+	}
+	return nil
+}
@@ -69,6 +69,29 @@ func (s *sessionRepository) GetListSessionByAccountID(ctx context.Context, accou
 	return sessions, nil
 }
 
+// GetListSessionHistoryByAccountID implements domain.SessionRepository.
+func (s *sessionRepository) GetListSessionHistoryByAccountID(ctx context.Context, accountID string) ([]*domain.Session, error) {
+	var sessions []*domain.Session
+	query := `SELECT session_token, account_id, created_at, updated_at, expired_at, is_active, user_agent, ip_address FROM session WHERE account_id = $1 ORDER BY created_at DESC`
+	rows, err := s.db.Query(ctx, query, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var session domain.Session
+		err = rows.Scan(&session.SessionToken, &session.AccountID, &session.CreatedAt, &session.UpdatedAt, &session.ExpiredAt, &session.IsActive, &session.UserAgent, &session.IPAddress)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, &session)
+	}
+
+	return sessions, nil
+}
+
 // GetSessionByToken implements domain.SessionRepository.
 func (s *sessionRepository) GetSessionByToken(ctx context.Context, token string) (*domain.Session, error) {
 	query := `SELECT session_token, account_id, created_at, updated_at, expired_at, is_active, user_agent, ip_address FROM session WHERE session_token = $1`
@@ -0,0 +1,229 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/pkg/observability"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type stickerRepository struct {
+	db  *pgxpool.Pool
+	obs *observability.Observability
+}
+
+// CreateStickerPack implements domain.StickerRepository.
+func (s *stickerRepository) CreateStickerPack(ctx context.Context, pack *domain.StickerPack, stickers []*domain.Sticker) error {
+	ctx, span := s.obs.StartSpan(ctx, "StickerRepository.CreateStickerPack")
+	defer span()
+	logger := s.obs.Logger.WithContext(ctx)
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO sticker_pack (id, name, description, thumbnail_url, is_default, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err = tx.Exec(ctx, query, pack.ID, pack.Name, pack.Description, pack.ThumbnailURL, pack.IsDefault, pack.CreatedAt)
+	if err != nil {
+		logger.Error("failed to create sticker pack", err)
+		return err
+	}
+	query = `
+		INSERT INTO sticker (id, pack_id, emoji, url, position, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	for _, sticker := range stickers {
+		_, err = tx.Exec(ctx, query, sticker.ID, pack.ID, sticker.Emoji, sticker.URL, sticker.Position, sticker.CreatedAt)
+		if err != nil {
+			logger.Error("failed to create sticker", err)
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// GetStickerPackByID implements domain.StickerRepository.
+func (s *stickerRepository) GetStickerPackByID(ctx context.Context, id string) (*domain.StickerPack, error) {
+	ctx, span := s.obs.StartSpan(ctx, "StickerRepository.GetStickerPackByID")
+	defer span()
+	var pack domain.StickerPack
+	fields, values := pack.MapFields()
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE id = $1 AND deleted_at IS NULL`, strings.Join(fields, ","), pack.TableName())
+	err := s.db.QueryRow(ctx, query, id).Scan(values...)
+	if err != nil {
+		return nil, err
+	}
+	return &pack, nil
+}
+
+// GetListStickerPack implements domain.StickerRepository.
+// Every pack is returned, flagged when the user installed it.
+func (s *stickerRepository) GetListStickerPack(ctx context.Context, userID string) ([]*domain.StickerPack, error) {
+	ctx, span := s.obs.StartSpan(ctx, "StickerRepository.GetListStickerPack")
+	defer span()
+	query := `
+		SELECT %s, usp.user_id IS NOT NULL
+		FROM sticker_pack sp
+		LEFT JOIN user_sticker_pack usp ON usp.pack_id = sp.id AND usp.user_id = $1
+		WHERE sp.deleted_at IS NULL
+		ORDER BY sp.created_at, sp.id
+	`
+	return s.queryStickerPacks(ctx, query, userID)
+}
+
+// GetListInstalledStickerPack implements domain.StickerRepository.
+// Installed packs come first in the order of the user, followed by the
+// default packs the user did not install.
+func (s *stickerRepository) GetListInstalledStickerPack(ctx context.Context, userID string) ([]*domain.StickerPack, error) {
+	ctx, span := s.obs.StartSpan(ctx, "StickerRepository.GetListInstalledStickerPack")
+	defer span()
+	query := `
+		SELECT %s, usp.user_id IS NOT NULL
+		FROM sticker_pack sp
+		LEFT JOIN user_sticker_pack usp ON usp.pack_id = sp.id AND usp.user_id = $1
+		WHERE sp.deleted_at IS NULL AND (usp.user_id IS NOT NULL OR sp.is_default)
+		ORDER BY usp.position NULLS LAST, usp.installed_at, sp.created_at, sp.id
+	`
+	return s.queryStickerPacks(ctx, query, userID)
+}
+
+func (s *stickerRepository) queryStickerPacks(ctx context.Context, query string, args ...any) ([]*domain.StickerPack, error) {
+	var temp domain.StickerPack
+	fields, _ := temp.MapFields()
+	for i := range fields {
+		fields[i] = "sp." + fields[i]
+	}
+	rows, err := s.db.Query(ctx, fmt.Sprintf(query, strings.Join(fields, ",")), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var packs []*domain.StickerPack
+	for rows.Next() {
+		var pack domain.StickerPack
+		_, values := pack.MapFields()
+		if err := rows.Scan(append(values, &pack.Installed)...); err != nil {
+			return nil, err
+		}
+		packs = append(packs, &pack)
+	}
+	return packs, nil
+}
+
+// GetListStickerByPackIDs implements domain.StickerRepository.
+func (s *stickerRepository) GetListStickerByPackIDs(ctx context.Context, packIDs []string) ([]*domain.Sticker, error) {
+	ctx, span := s.obs.StartSpan(ctx, "StickerRepository.GetListStickerByPackIDs")
+	defer span()
+	var temp domain.Sticker
+	fields, _ := temp.MapFields()
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE pack_id = ANY($1) ORDER BY pack_id, position`, strings.Join(fields, ","), temp.TableName())
+	rows, err := s.db.Query(ctx, query, packIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stickers []*domain.Sticker
+	for rows.Next() {
+		var sticker domain.Sticker
+		_, values := sticker.MapFields()
+		if err := rows.Scan(values...); err != nil {
+			return nil, err
+		}
+		stickers = append(stickers, &sticker)
+	}
+	return stickers, nil
+}
+
+// GetAvailableSticker implements domain.StickerRepository.
+// It returns pgx.ErrNoRows when the sticker does not exist or its pack is
+// neither a default pack nor installed by the user.
+func (s *stickerRepository) GetAvailableSticker(ctx context.Context, userID string, stickerID string) (*domain.Sticker, error) {
+	ctx, span := s.obs.StartSpan(ctx, "StickerRepository.GetAvailableSticker")
+	defer span()
+	var sticker domain.Sticker
+	fields, values := sticker.MapFields()
+	for i := range fields {
+		fields[i] = "s." + fields[i]
+	}
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM sticker s
+		INNER JOIN sticker_pack sp ON sp.id = s.pack_id
+		LEFT JOIN user_sticker_pack usp ON usp.pack_id = sp.id AND usp.user_id = $1
+		WHERE s.id = $2 AND sp.deleted_at IS NULL AND (sp.is_default OR usp.user_id IS NOT NULL)
+	`, strings.Join(fields, ","))
+	err := s.db.QueryRow(ctx, query, userID, stickerID).Scan(values...)
+	if err != nil {
+		return nil, err
+	}
+	return &sticker, nil
+}
+
+// InstallStickerPack implements domain.StickerRepository.
+// New packs go to the end of the user's order, installing twice is a no-op.
+func (s *stickerRepository) InstallStickerPack(ctx context.Context, userID string, packID string, installedAt time.Time) error {
+	ctx, span := s.obs.StartSpan(ctx, "StickerRepository.InstallStickerPack")
+	defer span()
+	logger := s.obs.Logger.WithContext(ctx)
+	query := `
+		INSERT INTO user_sticker_pack (user_id, pack_id, position, installed_at)
+		SELECT $1, $2, COALESCE(MAX(position), 0) + 1, $3 FROM user_sticker_pack WHERE user_id = $1
+		ON CONFLICT (user_id, pack_id) DO NOTHING
+	`
+	_, err := s.db.Exec(ctx, query, userID, packID, installedAt)
+	if err != nil {
+		logger.Error("failed to install sticker pack", err)
+		return err
+	}
+	return nil
+}
+
+// UninstallStickerPack implements domain.StickerRepository.
+func (s *stickerRepository) UninstallStickerPack(ctx context.Context, userID string, packID string) error {
+	ctx, span := s.obs.StartSpan(ctx, "StickerRepository.UninstallStickerPack")
+	defer span()
+	logger := s.obs.Logger.WithContext(ctx)
+	_, err := s.db.Exec(ctx, `DELETE FROM user_sticker_pack WHERE user_id = $1 AND pack_id = $2`, userID, packID)
+	if err != nil {
+		logger.Error("failed to uninstall sticker pack", err)
+		return err
+	}
+	return nil
+}
+
+// UpdateStickerPackPositions implements domain.StickerRepository.
+// Packs are ordered as in packIDs, ids of packs the user did not install are
+// ignored.
+func (s *stickerRepository) UpdateStickerPackPositions(ctx context.Context, userID string, packIDs []string) error {
+	ctx, span := s.obs.StartSpan(ctx, "StickerRepository.UpdateStickerPackPositions")
+	defer span()
+	logger := s.obs.Logger.WithContext(ctx)
+	query := `
+		UPDATE user_sticker_pack usp SET position = o.position::int
+		FROM unnest($2::text[]) WITH ORDINALITY AS o(pack_id, position)
+		WHERE usp.user_id = $1 AND usp.pack_id = o.pack_id
+	`
+	_, err := s.db.Exec(ctx, query, userID, packIDs)
+	if err != nil {
+		logger.Error("failed to update sticker pack positions", err)
+		return err
+	}
+	return nil
+}
+
+var _ domain.StickerRepository = &stickerRepository{}
+
+func NewStickerRepository(db *pgxpool.Pool, obs *observability.Observability) domain.StickerRepository {
+	return &stickerRepository{db: db, obs: obs}
+}
@@ -0,0 +1,186 @@
+package postgresql
+
+import (
+	"context"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/pkg/observability"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type unreadCountRepository struct {
+	db  *pgxpool.Pool
+	obs *observability.Observability
+}
+
+// unreadMessagesCondition selects the messages of cu.conversation_id that
+// cu.user_id did not see yet.
+const unreadMessagesCondition = `
+	m.conversation_id = cu.conversation_id
+	AND m.user_id <> cu.user_id
+	AND m.type <> 'system'
+	AND m.deleted_at IS NULL
+	AND (m.expires_at IS NULL OR m.expires_at > now())
+	AND m.id > COALESCE((SELECT sm.message_id FROM seen_message sm WHERE sm.conversation_id = cu.conversation_id AND sm.user_id = cu.user_id), '')
+`
+
+// IncrementUnreadCount implements domain.UnreadCountRepository.
+// Every member but the sender gets one more unread message, unless they
+// already saw it. A message is only counted once, so a redelivered message
+// leaves the counts as they are. The changed counts are returned.
+func (u *unreadCountRepository) IncrementUnreadCount(ctx context.Context, message *domain.Message) ([]*domain.UnreadCount, error) {
+	ctx, span := u.obs.StartSpan(ctx, "UnreadCountRepository.IncrementUnreadCount")
+	defer span()
+	logger := u.obs.Logger.WithContext(ctx)
+	query := `
+		INSERT INTO conversation_unread (user_id, conversation_id, unread_count, last_message_id, updated_at)
+		SELECT cm.user_id, cm.conversation_id, 1, $2, now()
+		FROM conversation_member cm
+		WHERE cm.conversation_id = $1 AND cm.user_id <> $3
+		AND NOT EXISTS (SELECT 1 FROM seen_message sm WHERE sm.conversation_id = $1 AND sm.user_id = cm.user_id AND sm.message_id >= $2)
+		ON CONFLICT (user_id, conversation_id) DO UPDATE
+		SET unread_count = conversation_unread.unread_count + 1, last_message_id = EXCLUDED.last_message_id, updated_at = EXCLUDED.updated_at
+		WHERE conversation_unread.last_message_id < EXCLUDED.last_message_id
+		RETURNING user_id, conversation_id, unread_count
+	`
+	rows, err := u.db.Query(ctx, query, message.ConversationID, message.ID, message.UserID)
+	if err != nil {
+		logger.Error("failed to increment unread count", err, message.ID)
+		return nil, err
+	}
+	return scanUnreadCounts(rows)
+}
+
+// RecountUnreadCount implements domain.UnreadCountRepository.
+// The count is rebuilt from the seen message of the user, messages up to the
+// newest one are marked counted.
+func (u *unreadCountRepository) RecountUnreadCount(ctx context.Context, conversationID string, userID string) (*domain.UnreadCount, error) {
+	ctx, span := u.obs.StartSpan(ctx, "UnreadCountRepository.RecountUnreadCount")
+	defer span()
+	logger := u.obs.Logger.WithContext(ctx)
+	query := `
+		INSERT INTO conversation_unread (user_id, conversation_id, unread_count, last_message_id, updated_at)
+		SELECT cu.user_id, cu.conversation_id,
+			(SELECT COUNT(*) FROM message m WHERE ` + unreadMessagesCondition + `),
+			(SELECT COALESCE(MAX(m.id), '') FROM message m WHERE m.conversation_id = cu.conversation_id),
+			now()
+		FROM (SELECT $1::text AS user_id, $2::text AS conversation_id) cu
+		ON CONFLICT (user_id, conversation_id) DO UPDATE
+		SET unread_count = EXCLUDED.unread_count,
+			last_message_id = GREATEST(conversation_unread.last_message_id, EXCLUDED.last_message_id),
+			updated_at = EXCLUDED.updated_at
+		RETURNING user_id, conversation_id, unread_count
+	`
+	var unreadCount domain.UnreadCount
+	err := u.db.QueryRow(ctx, query, userID, conversationID).Scan(&unreadCount.UserID, &unreadCount.ConversationID, &unreadCount.UnreadCount)
+	if err != nil {
+		logger.Error("failed to recount unread count", err, conversationID, userID)
+		return nil, err
+	}
+	return &unreadCount, nil
+}
+
+// RecountConversationUnreadCount implements domain.UnreadCountRepository.
+// It is used when messages disappear, only members with unread messages can
+// be affected.
+func (u *unreadCountRepository) RecountConversationUnreadCount(ctx context.Context, conversationID string) ([]*domain.UnreadCount, error) {
+	ctx, span := u.obs.StartSpan(ctx, "UnreadCountRepository.RecountConversationUnreadCount")
+	defer span()
+	logger := u.obs.Logger.WithContext(ctx)
+	query := `
+		UPDATE conversation_unread cu
+		SET unread_count = (SELECT COUNT(*) FROM message m WHERE ` + unreadMessagesCondition + `), updated_at = now()
+		WHERE cu.conversation_id = $1 AND cu.unread_count > 0
+		RETURNING cu.user_id, cu.conversation_id, cu.unread_count
+	`
+	rows, err := u.db.Query(ctx, query, conversationID)
+	if err != nil {
+		logger.Error("failed to recount conversation unread count", err, conversationID)
+		return nil, err
+	}
+	return scanUnreadCounts(rows)
+}
+
+// GetTotalUnreadCounts implements domain.UnreadCountRepository.
+// Conversations the user left are not counted, users without unread messages
+// are left out.
+func (u *unreadCountRepository) GetTotalUnreadCounts(ctx context.Context, userIDs []string) (map[string]int, error) {
+	ctx, span := u.obs.StartSpan(ctx, "UnreadCountRepository.GetTotalUnreadCounts")
+	defer span()
+	query := `
+		SELECT cu.user_id, SUM(cu.unread_count)
+		FROM conversation_unread cu
+		INNER JOIN conversation_member cm ON cm.conversation_id = cu.conversation_id AND cm.user_id = cu.user_id
+		WHERE cu.user_id = ANY($1) AND cu.unread_count > 0
+		GROUP BY cu.user_id
+	`
+	rows, err := u.db.Query(ctx, query, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	totals := make(map[string]int, len(userIDs))
+	for rows.Next() {
+		var userID string
+		var total int
+		if err := rows.Scan(&userID, &total); err != nil {
+			return nil, err
+		}
+		totals[userID] = total
+	}
+	return totals, rows.Err()
+}
+
+// GetListLatestUnreadMessage implements domain.UnreadCountRepository.
+// It returns for every conversation of the user with unread messages the seen
+// message that marks all of them read.
+func (u *unreadCountRepository) GetListLatestUnreadMessage(ctx context.Context, userID string) ([]*domain.SeenMessage, error) {
+	ctx, span := u.obs.StartSpan(ctx, "UnreadCountRepository.GetListLatestUnreadMessage")
+	defer span()
+	query := `
+		SELECT cu.conversation_id, latest.id
+		FROM conversation_unread cu
+		INNER JOIN conversation_member cm ON cm.conversation_id = cu.conversation_id AND cm.user_id = cu.user_id
+		CROSS JOIN LATERAL (
+			SELECT m.id FROM message m
+			WHERE m.conversation_id = cu.conversation_id AND (m.expires_at IS NULL OR m.expires_at > now())
+			ORDER BY m.id DESC LIMIT 1
+		) latest
+		WHERE cu.user_id = $1 AND cu.unread_count > 0
+	`
+	rows, err := u.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var seenMessages []*domain.SeenMessage
+	for rows.Next() {
+		seenMessage := domain.SeenMessage{UserID: userID}
+		if err := rows.Scan(&seenMessage.ConversationID, &seenMessage.MessageID); err != nil {
+			return nil, err
+		}
+		seenMessages = append(seenMessages, &seenMessage)
+	}
+	return seenMessages, rows.Err()
+}
+
+func scanUnreadCounts(rows pgx.Rows) ([]*domain.UnreadCount, error) {
+	defer rows.Close()
+	var unreadCounts []*domain.UnreadCount
+	for rows.Next() {
+		var unreadCount domain.UnreadCount
+		err := rows.Scan(&unreadCount.UserID, &unreadCount.ConversationID, &unreadCount.UnreadCount)
+		if err != nil {
+			return nil, err
+		}
+		unreadCounts = append(unreadCounts, &unreadCount)
+	}
+	return unreadCounts, rows.Err()
+}
+
+var _ domain.UnreadCountRepository = &unreadCountRepository{}
+
+func NewUnreadCountRepository(db *pgxpool.Pool, obs *observability.Observability) domain.UnreadCountRepository {
+	return &unreadCountRepository{db: db, obs: obs}
+}
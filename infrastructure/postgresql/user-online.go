@@ -32,6 +32,27 @@ func (u *userOnlineRepository) GetUserOnlineByConversationID(ctx context.Context
 	return userOnlines, nil
 }
 
+// GetUserOnlineByUserID implements domain.UserOnlineRepository.
+func (u *userOnlineRepository) GetUserOnlineByUserID(ctx context.Context, userID string) ([]*domain.UserOnline, error) {
+	query := `SELECT id, user_id, connection_id, created_at FROM user_online WHERE user_id = $1`
+	rows, err := u.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var userOnlines []*domain.UserOnline
+	for rows.Next() {
+		var userOnline domain.UserOnline
+		err := rows.Scan(&userOnline.ID, &userOnline.UserID, &userOnline.ConnectionID, &userOnline.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		userOnlines = append(userOnlines, &userOnline)
+	}
+	return userOnlines, nil
+}
+
 // CreateUserOnline implements domain.UserOnlineRepository.
 func (u *userOnlineRepository) CreateUserOnline(ctx context.Context, userOnline *domain.UserOnline) error {
 	query := `INSERT INTO user_online (id, user_id, connection_id, created_at) VALUES ($1, $2, $3, $4)`
@@ -52,6 +73,16 @@ func (u *userOnlineRepository) DeleteUserOnline(ctx context.Context, id string)
 	return nil
 }
 
+// DeleteUserOnlineByUserID implements domain.UserOnlineRepository.
+func (u *userOnlineRepository) DeleteUserOnlineByUserID(ctx context.Context, userID string) error {
+	query := `DELETE FROM user_online WHERE user_id = $1`
+	_, err := u.db.Exec(ctx, query, userID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 var _ domain.UserOnlineRepository = &userOnlineRepository{}
 
 func NewUserOnlineRepository(db *pgxpool.Pool) *userOnlineRepository {
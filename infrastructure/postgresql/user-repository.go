@@ -36,7 +36,7 @@ func (u *userRepository) GetListUser(ctx context.Context, keyword string, limit
 		args = append(args, lastID)
 	}
 
-	conditions = append(conditions, "type = 'EXTERNAL'")
+	conditions = append(conditions, "type = 'EXTERNAL'", "deleted_at IS NULL")
 
 	if len(conditions) > 0 {
 		query = fmt.Sprintf("%s WHERE %s", query, strings.Join(conditions, " AND "))
@@ -96,7 +96,7 @@ func (u *userRepository) GetListUserWithConversation(ctx context.Context, userID
 		args = append(args, lastID)
 	}
 
-	conditions = append(conditions, "u.type = 'EXTERNAL'")
+	conditions = append(conditions, "u.type = 'EXTERNAL'", "u.deleted_at IS NULL")
 
 	if len(conditions) > 0 {
 		query = fmt.Sprintf("%s AND %s", query, strings.Join(conditions, " AND "))
@@ -212,6 +212,42 @@ func (u *userRepository) UpdateUser(ctx context.Context, user *domain.UserInfo)
 	return nil
 }
 
+// AnonymizeUser implements domain.UserRepository.
+func (u *userRepository) AnonymizeUser(ctx context.Context, userID string, deletedAt time.Time) error {
+	query := `UPDATE user_info SET full_name = $1, avatar = '', email = $2, deleted_at = $3, updated_at = $3 WHERE id = $4`
+	_, err := u.db.Exec(ctx, query, domain.DeletedUserFullName, fmt.Sprintf("deleted-%s", userID), deletedAt, userID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetListUserByIDs implements domain.UserRepository.
+// Deleted users are returned too, callers decide how to show them.
+func (u *userRepository) GetListUserByIDs(ctx context.Context, ids []string) ([]*domain.UserInfo, error) {
+	ctx, span := u.obs.StartSpan(ctx, "UserRepository.GetListUserByIDs")
+	defer span()
+	var temp domain.UserInfo
+	fields, _ := temp.MapFields()
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE id = ANY($1)`, strings.Join(fields, ","), temp.TableName())
+	rows, err := u.db.Query(ctx, query, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*domain.UserInfo
+	for rows.Next() {
+		var user domain.UserInfo
+		_, values := user.MapFields()
+		if err := rows.Scan(values...); err != nil {
+			return nil, err
+		}
+		users = append(users, &user)
+	}
+	return users, nil
+}
+
 func NewUserRepository(db *pgxpool.Pool, obs *observability.Observability) domain.UserRepository {
 	return &userRepository{
 		db:  db,
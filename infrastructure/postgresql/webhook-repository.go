@@ -0,0 +1,268 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/pkg/observability"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type webhookRepository struct {
+	db  *pgxpool.Pool
+	obs *observability.Observability
+}
+
+// CreateWebhook implements domain.WebhookRepository.
+func (w *webhookRepository) CreateWebhook(ctx context.Context, webhook *domain.Webhook) error {
+	ctx, span := w.obs.StartSpan(ctx, "WebhookRepository.CreateWebhook")
+	defer span()
+	logger := w.obs.Logger.WithContext(ctx)
+	query := `
+		INSERT INTO webhook (id, conversation_id, created_by, url, secret, events, is_active, failure_count, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+	_, err := w.db.Exec(ctx, query, webhook.ID, webhook.ConversationID, webhook.CreatedBy, webhook.URL, webhook.Secret, webhook.Events, webhook.IsActive, webhook.FailureCount, webhook.CreatedAt, webhook.UpdatedAt)
+	if err != nil {
+		logger.Error("failed to create webhook", err)
+		return err
+	}
+	return nil
+}
+
+// GetWebhookByID implements domain.WebhookRepository.
+func (w *webhookRepository) GetWebhookByID(ctx context.Context, id string) (*domain.Webhook, error) {
+	ctx, span := w.obs.StartSpan(ctx, "WebhookRepository.GetWebhookByID")
+	defer span()
+	var webhook domain.Webhook
+	fields, values := webhook.MapFields()
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE id = $1`, strings.Join(fields, ","), webhook.TableName())
+	err := w.db.QueryRow(ctx, query, id).Scan(values...)
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// GetListWebhookByConversationID implements domain.WebhookRepository.
+func (w *webhookRepository) GetListWebhookByConversationID(ctx context.Context, conversationID string) ([]*domain.Webhook, error) {
+	ctx, span := w.obs.StartSpan(ctx, "WebhookRepository.GetListWebhookByConversationID")
+	defer span()
+	var temp domain.Webhook
+	fields, _ := temp.MapFields()
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE conversation_id = $1 ORDER BY id`, strings.Join(fields, ","), temp.TableName())
+	rows, err := w.db.Query(ctx, query, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var webhooks []*domain.Webhook
+	for rows.Next() {
+		var webhook domain.Webhook
+		_, values := webhook.MapFields()
+		if err := rows.Scan(values...); err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, &webhook)
+	}
+	return webhooks, nil
+}
+
+// UpdateWebhook implements domain.WebhookRepository.
+func (w *webhookRepository) UpdateWebhook(ctx context.Context, webhook *domain.Webhook) error {
+	ctx, span := w.obs.StartSpan(ctx, "WebhookRepository.UpdateWebhook")
+	defer span()
+	logger := w.obs.Logger.WithContext(ctx)
+	query := `
+		UPDATE webhook
+		SET url = $1, events = $2, is_active = $3, failure_count = $4, disabled_at = $5, updated_at = $6
+		WHERE id = $7
+	`
+	_, err := w.db.Exec(ctx, query, webhook.URL, webhook.Events, webhook.IsActive, webhook.FailureCount, webhook.DisabledAt, webhook.UpdatedAt, webhook.ID)
+	if err != nil {
+		logger.Error("failed to update webhook", err)
+		return err
+	}
+	return nil
+}
+
+// DeleteWebhook implements domain.WebhookRepository.
+// The delivery log of the webhook is removed with it.
+func (w *webhookRepository) DeleteWebhook(ctx context.Context, id string) error {
+	ctx, span := w.obs.StartSpan(ctx, "WebhookRepository.DeleteWebhook")
+	defer span()
+	logger := w.obs.Logger.WithContext(ctx)
+	tx, err := w.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `DELETE FROM webhook_delivery WHERE webhook_id = $1`, id)
+	if err != nil {
+		logger.Error("failed to delete webhook deliveries", err)
+		return err
+	}
+	_, err = tx.Exec(ctx, `DELETE FROM webhook WHERE id = $1`, id)
+	if err != nil {
+		logger.Error("failed to delete webhook", err)
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// ResetWebhookFailure implements domain.WebhookRepository.
+func (w *webhookRepository) ResetWebhookFailure(ctx context.Context, id string) error {
+	query := `UPDATE webhook SET failure_count = 0 WHERE id = $1 AND failure_count <> 0`
+	_, err := w.db.Exec(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// IncreaseWebhookFailure implements domain.WebhookRepository.
+// It reports whether this failure disabled the webhook.
+func (w *webhookRepository) IncreaseWebhookFailure(ctx context.Context, id string, disableThreshold int, at time.Time) (bool, error) {
+	query := `
+		UPDATE webhook
+		SET failure_count = failure_count + 1,
+			is_active = CASE WHEN failure_count + 1 >= $2 THEN false ELSE is_active END,
+			disabled_at = CASE WHEN failure_count + 1 >= $2 AND is_active THEN $3 ELSE disabled_at END,
+			updated_at = $3
+		WHERE id = $1
+		RETURNING is_active, coalesce(disabled_at = $3, false)
+	`
+	var isActive, disabledNow bool
+	err := w.db.QueryRow(ctx, query, id, disableThreshold, at).Scan(&isActive, &disabledNow)
+	if err != nil {
+		return false, err
+	}
+	return !isActive && disabledNow, nil
+}
+
+// CreateWebhookDeliveries implements domain.WebhookRepository.
+func (w *webhookRepository) CreateWebhookDeliveries(ctx context.Context, deliveries []*domain.WebhookDelivery) error {
+	ctx, span := w.obs.StartSpan(ctx, "WebhookRepository.CreateWebhookDeliveries")
+	defer span()
+	logger := w.obs.Logger.WithContext(ctx)
+	query := `
+		INSERT INTO webhook_delivery (id, webhook_id, event, payload, status, attempt, next_retry_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	batch := &pgx.Batch{}
+	for _, delivery := range deliveries {
+		batch.Queue(query, delivery.ID, delivery.WebhookID, delivery.Event, delivery.Payload, delivery.Status, delivery.Attempt, delivery.NextRetryAt, delivery.CreatedAt, delivery.UpdatedAt)
+	}
+	err := w.db.SendBatch(ctx, batch).Close()
+	if err != nil {
+		logger.Error("failed to create webhook deliveries", err)
+		return err
+	}
+	return nil
+}
+
+// GetWebhookDeliveryByID implements domain.WebhookRepository.
+func (w *webhookRepository) GetWebhookDeliveryByID(ctx context.Context, id string) (*domain.WebhookDelivery, error) {
+	ctx, span := w.obs.StartSpan(ctx, "WebhookRepository.GetWebhookDeliveryByID")
+	defer span()
+	var delivery domain.WebhookDelivery
+	fields, values := delivery.MapFields()
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE id = $1`, strings.Join(fields, ","), delivery.TableName())
+	err := w.db.QueryRow(ctx, query, id).Scan(values...)
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// GetListWebhookDeliveryByWebhookID implements domain.WebhookRepository.
+func (w *webhookRepository) GetListWebhookDeliveryByWebhookID(ctx context.Context, webhookID string, lastID string, limit int) ([]*domain.WebhookDelivery, error) {
+	ctx, span := w.obs.StartSpan(ctx, "WebhookRepository.GetListWebhookDeliveryByWebhookID")
+	defer span()
+	var temp domain.WebhookDelivery
+	fields, _ := temp.MapFields()
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE webhook_id = $1 AND ($2 = '' OR id < $2) ORDER BY id DESC LIMIT $3`, strings.Join(fields, ","), temp.TableName())
+	rows, err := w.db.Query(ctx, query, webhookID, lastID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*domain.WebhookDelivery
+	for rows.Next() {
+		var delivery domain.WebhookDelivery
+		_, values := delivery.MapFields()
+		if err := rows.Scan(values...); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, &delivery)
+	}
+	return deliveries, nil
+}
+
+// UpdateWebhookDelivery implements domain.WebhookRepository.
+func (w *webhookRepository) UpdateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	ctx, span := w.obs.StartSpan(ctx, "WebhookRepository.UpdateWebhookDelivery")
+	defer span()
+	logger := w.obs.Logger.WithContext(ctx)
+	query := `
+		UPDATE webhook_delivery
+		SET status = $1, attempt = $2, response_status = $3, error = $4, next_retry_at = $5, updated_at = $6
+		WHERE id = $7
+	`
+	_, err := w.db.Exec(ctx, query, delivery.Status, delivery.Attempt, delivery.ResponseStatus, delivery.Error, delivery.NextRetryAt, delivery.UpdatedAt, delivery.ID)
+	if err != nil {
+		logger.Error("failed to update webhook delivery", err)
+		return err
+	}
+	return nil
+}
+
+// ClaimDueWebhookDeliveries implements domain.WebhookRepository.
+// Claimed deliveries are pushed back by the lease so that concurrent workers
+// do not pick the same rows, and a lost publish is retried after the lease.
+func (w *webhookRepository) ClaimDueWebhookDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.WebhookDelivery, error) {
+	ctx, span := w.obs.StartSpan(ctx, "WebhookRepository.ClaimDueWebhookDeliveries")
+	defer span()
+	var temp domain.WebhookDelivery
+	fields, _ := temp.MapFields()
+	query := fmt.Sprintf(`
+		UPDATE %s SET next_retry_at = $2
+		WHERE id IN (
+			SELECT id FROM webhook_delivery
+			WHERE status = $3 AND next_retry_at <= $1
+			ORDER BY next_retry_at
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING %s
+	`, temp.TableName(), strings.Join(fields, ","))
+	rows, err := w.db.Query(ctx, query, now, now.Add(lease), domain.WebhookDeliveryStatusPending, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*domain.WebhookDelivery
+	for rows.Next() {
+		var delivery domain.WebhookDelivery
+		_, values := delivery.MapFields()
+		if err := rows.Scan(values...); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, &delivery)
+	}
+	return deliveries, nil
+}
+
+var _ domain.WebhookRepository = &webhookRepository{}
+
+func NewWebhookRepository(db *pgxpool.Pool, obs *observability.Observability) domain.WebhookRepository {
+	return &webhookRepository{db: db, obs: obs}
+}
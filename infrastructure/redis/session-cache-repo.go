@@ -3,11 +3,16 @@ package redis
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/chat-socio/backend/internal/domain"
 	"github.com/redis/go-redis/v9"
 )
 
+// defaultSessionTTL is used for sessions that carry no expiry so that the
+// cache never holds a key forever.
+const defaultSessionTTL = 24 * time.Hour
+
 type sessionCacheRepository struct {
 	client *redis.Client
 }
@@ -19,10 +24,29 @@ func NewSessionCacheRepository(client *redis.Client) domain.SessionCacheReposito
 	}
 }
 
+func sessionKey(token string) string {
+	return fmt.Sprintf("session:%s", token)
+}
+
 // CreateSessionWithExpireTime implements domain.SessionCacheRepository.
 func (s *sessionCacheRepository) CreateSessionWithExpireTime(ctx context.Context, session *domain.Session) error {
+	key := sessionKey(session.SessionToken)
+	if session.IsExpired(time.Now()) {
+		return s.DeleteSession(ctx, session.SessionToken)
+	}
+
 	mapSession := session.ConvertToMapString()
-	_, err := s.client.HSet(ctx, fmt.Sprintf("session:%s", session.SessionToken), mapSession).Result()
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		// drop stale fields before writing the new state
+		pipe.Del(ctx, key)
+		pipe.HSet(ctx, key, mapSession)
+		if session.ExpiredAt != nil {
+			pipe.ExpireAt(ctx, key, *session.ExpiredAt)
+		} else {
+			pipe.Expire(ctx, key, defaultSessionTTL)
+		}
+		return nil
+	})
 	if err != nil {
 		return err
 	}
@@ -31,7 +55,7 @@ func (s *sessionCacheRepository) CreateSessionWithExpireTime(ctx context.Context
 
 // DeleteSession implements domain.SessionCacheRepository.
 func (s *sessionCacheRepository) DeleteSession(ctx context.Context, token string) error {
-	_, err := s.client.Del(ctx, fmt.Sprintf("session:%s", token)).Result()
+	_, err := s.client.Del(ctx, sessionKey(token)).Result()
 	if err != nil {
 		return err
 	}
@@ -40,7 +64,7 @@ func (s *sessionCacheRepository) DeleteSession(ctx context.Context, token string
 
 // GetSessionByToken implements domain.SessionCacheRepository.
 func (s *sessionCacheRepository) GetSessionByToken(ctx context.Context, token string) (*domain.Session, error) {
-	sessionMap, err := s.client.HGetAll(ctx, fmt.Sprintf("session:%s", token)).Result()
+	sessionMap, err := s.client.HGetAll(ctx, sessionKey(token)).Result()
 	if err != nil && err != redis.Nil {
 		return nil, err
 	}
@@ -0,0 +1,78 @@
+package domain
+
+import "time"
+
+// DeletedUserID is the placeholder user that messages of deleted accounts are
+// reassigned to, so history stays readable without pointing at the author.
+const DeletedUserID = "00000000-0000-0000-0000-000000000000"
+
+const DeletedUserFullName = "Deleted user"
+
+const (
+	AccountDeletionStatusPending   = "pending"
+	AccountDeletionStatusCompleted = "completed"
+)
+
+const (
+	AccountDeletionStepSessions  = "sessions"
+	AccountDeletionStepBots      = "bots"
+	AccountDeletionStepFcmTokens = "fcm_tokens"
+	AccountDeletionStepContacts  = "contacts"
+	AccountDeletionStepPresence  = "presence"
+	AccountDeletionStepMessages  = "messages"
+	AccountDeletionStepProfile   = "profile"
+	AccountDeletionStepDone      = "done"
+)
+
+// AccountDeletionSteps lists the steps of an account deletion job in the order
+// they run. A job records the step it is on so a redelivered job resumes there.
+var AccountDeletionSteps = []string{
+	AccountDeletionStepSessions,
+	AccountDeletionStepBots,
+	AccountDeletionStepFcmTokens,
+	AccountDeletionStepContacts,
+	AccountDeletionStepPresence,
+	AccountDeletionStepMessages,
+	AccountDeletionStepProfile,
+	AccountDeletionStepDone,
+}
+
+type AccountDeletionJob struct {
+	ID                string     `json:"id,omitempty"`
+	UserID            string     `json:"user_id,omitempty"`
+	AccountID         string     `json:"account_id,omitempty"`
+	Status            string     `json:"status,omitempty"`
+	Step              string     `json:"step,omitempty"`
+	ProcessedMessages int64      `json:"processed_messages,omitempty"`
+	CreatedAt         *time.Time `json:"created_at,omitempty"`
+	UpdatedAt         *time.Time `json:"updated_at,omitempty"`
+	CompletedAt       *time.Time `json:"completed_at,omitempty"`
+}
+
+func (a *AccountDeletionJob) TableName() string {
+	return "account_deletion_job"
+}
+
+func (a *AccountDeletionJob) MapFields() ([]string, []any) {
+	return []string{
+			"id",
+			"user_id",
+			"account_id",
+			"status",
+			"step",
+			"processed_messages",
+			"created_at",
+			"updated_at",
+			"completed_at",
+		}, []any{
+			&a.ID,
+			&a.UserID,
+			&a.AccountID,
+			&a.Status,
+			&a.Step,
+			&a.ProcessedMessages,
+			&a.CreatedAt,
+			&a.UpdatedAt,
+			&a.CompletedAt,
+		}
+}
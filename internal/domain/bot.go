@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+const (
+	ApiKeyScopeSendMessage      = "message:write"
+	ApiKeyScopeReadConversation = "conversation:read"
+)
+
+var ApiKeyScopes = []string{
+	ApiKeyScopeSendMessage,
+	ApiKeyScopeReadConversation,
+}
+
+// Bot is an internal user owned by a human user. The bot shares its id with
+// its user_info row.
+type Bot struct {
+	ID        string     `json:"id,omitempty"`
+	OwnerID   string     `json:"owner_id,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	User      *UserInfo  `json:"-"`
+}
+
+func (b *Bot) TableName() string {
+	return "bot"
+}
+
+func (b *Bot) MapFields() ([]string, []any) {
+	return []string{
+			"id",
+			"owner_id",
+			"created_at",
+			"updated_at",
+			"deleted_at",
+		}, []any{
+			&b.ID,
+			&b.OwnerID,
+			&b.CreatedAt,
+			&b.UpdatedAt,
+			&b.DeletedAt,
+		}
+}
+
+// ApiKey is a long lived credential of a bot. Only the sha256 of the key is
+// stored, the prefix is kept so owners can tell keys apart.
+type ApiKey struct {
+	ID         string     `json:"id,omitempty"`
+	BotID      string     `json:"bot_id,omitempty"`
+	Name       string     `json:"name,omitempty"`
+	Prefix     string     `json:"prefix,omitempty"`
+	KeyHash    string     `json:"-"`
+	Scopes     []string   `json:"scopes,omitempty"`
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	AccountID  string     `json:"-"` // account of the bot, for authentication
+}
+
+func (a *ApiKey) TableName() string {
+	return "api_key"
+}
+
+func (a *ApiKey) MapFields() ([]string, []any) {
+	return []string{
+			"id",
+			"bot_id",
+			"name",
+			"prefix",
+			"key_hash",
+			"scopes",
+			"created_at",
+			"last_used_at",
+			"revoked_at",
+		}, []any{
+			&a.ID,
+			&a.BotID,
+			&a.Name,
+			&a.Prefix,
+			&a.KeyHash,
+			&a.Scopes,
+			&a.CreatedAt,
+			&a.LastUsedAt,
+			&a.RevokedAt,
+		}
+}
+
+func (a *ApiKey) HasScope(scope string) bool {
+	return slices.Contains(a.Scopes, scope)
+}
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	CommandMe    = "me"
+	CommandShrug = "shrug"
+	CommandPoll  = "poll"
+	CommandMute  = "mute"
+)
+
+// BuiltinCommands are handled by the server, bots can not register them.
+var BuiltinCommands = []string{
+	CommandMe,
+	CommandShrug,
+	CommandPoll,
+	CommandMute,
+}
+
+// BotCommand is a slash command registered by a bot. It is available in the
+// conversations the bot is a member of.
+type BotCommand struct {
+	ID          string     `json:"id,omitempty"`
+	BotID       string     `json:"bot_id,omitempty"`
+	Command     string     `json:"command,omitempty"`
+	Description string     `json:"description,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+}
+
+func (b *BotCommand) TableName() string {
+	return "bot_command"
+}
+
+func (b *BotCommand) MapFields() ([]string, []any) {
+	return []string{
+			"id",
+			"bot_id",
+			"command",
+			"description",
+			"created_at",
+		}, []any{
+			&b.ID,
+			&b.BotID,
+			&b.Command,
+			&b.Description,
+			&b.CreatedAt,
+		}
+}
+
+// BotCommandInvocation is published to the bot when a member runs one of its
+// commands. The bot answers with a BotCommandReply carrying the same id.
+type BotCommandInvocation struct {
+	ID             string     `json:"id,omitempty"`
+	BotID          string     `json:"bot_id,omitempty"`
+	Command        string     `json:"command,omitempty"`
+	Args           string     `json:"args,omitempty"`
+	ConversationID string     `json:"conversation_id,omitempty"`
+	UserID         string     `json:"user_id,omitempty"`
+	CreatedAt      *time.Time `json:"created_at,omitempty"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+}
+
+func (b *BotCommandInvocation) TableName() string {
+	return "bot_command_invocation"
+}
+
+func (b *BotCommandInvocation) MapFields() ([]string, []any) {
+	return []string{
+			"id",
+			"bot_id",
+			"command",
+			"args",
+			"conversation_id",
+			"user_id",
+			"created_at",
+			"expires_at",
+		}, []any{
+			&b.ID,
+			&b.BotID,
+			&b.Command,
+			&b.Args,
+			&b.ConversationID,
+			&b.UserID,
+			&b.CreatedAt,
+			&b.ExpiresAt,
+		}
+}
+
+// BotCommandReply is published by a bot on SUBJECT_BOT_COMMAND_REPLY.
+// Ephemeral replies are only shown to the member who ran the command.
+type BotCommandReply struct {
+	InvocationID string `json:"invocation_id,omitempty"`
+	BotID        string `json:"bot_id,omitempty"`
+	Type         string `json:"type,omitempty"`
+	Body         string `json:"body,omitempty"`
+	Ephemeral    bool   `json:"ephemeral,omitempty"`
+}
+
+// BotCommandSubject returns the subject the invocations of a bot are published on.
+func BotCommandSubject(botID string) string {
+	return fmt.Sprintf("%s.%s", SUBJECT_PREFIX_BOT_COMMAND, botID)
+}
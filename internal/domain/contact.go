@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type Contact struct {
 	ID        string     `json:"id,omitempty"`
@@ -30,3 +33,34 @@ func (c *Contact) MapFields() ([]string, []any) {
 			&c.UpdatedAt,
 		}
 }
+
+const (
+	ContactCardActionOpenDM            = "open_dm"
+	ContactCardActionStartDM           = "start_dm"
+	ContactCardActionSendFriendRequest = "send_friend_request"
+)
+
+// ContactCardContent is the body of a contact message, stored as json. Only
+// the user id is kept, the profile is resolved when the message is listed so
+// that cards follow renames and account deletion.
+type ContactCardContent struct {
+	UserID string `json:"user_id"`
+}
+
+func ParseContactCardContent(body string) (*ContactCardContent, error) {
+	var card ContactCardContent
+	err := json.Unmarshal([]byte(body), &card)
+	if err != nil {
+		return nil, err
+	}
+	return &card, nil
+}
+
+// ContactRelation is how a user relates to another one, it decides which
+// actions a contact card offers.
+type ContactRelation struct {
+	UserID            string
+	IsContact         bool
+	HasPendingRequest bool
+	DMConversationID  *string
+}
@@ -2,10 +2,20 @@ package domain
 
 import "time"
 
+const (
+	ConversationMemberRoleOwner  = "owner"
+	ConversationMemberRoleAdmin  = "admin"
+	ConversationMemberRoleMember = "member"
+)
+
 type ConversationMember struct {
 	ID             string     `json:"id,omitempty"`
 	ConversationID string     `json:"conversation_id,omitempty"`
 	UserID         string     `json:"user_id,omitempty"`
+	Role           string     `json:"role,omitempty"`
+	MutedUntil     *time.Time `json:"muted_until,omitempty"`
+	ArchivedAt     *time.Time `json:"archived_at,omitempty"`
+	PinOrder       *int       `json:"pin_order,omitempty"`
 	CreatedAt      *time.Time `json:"created_at,omitempty"`
 	UpdatedAt      *time.Time `json:"updated_at,omitempty"`
 	DeletedAt      *time.Time `json:"deleted_at,omitempty"`
@@ -21,6 +31,10 @@ func (c *ConversationMember) MapFields() ([]string, []any) {
 			"id",
 			"conversation_id",
 			"user_id",
+			"role",
+			"muted_until",
+			"archived_at",
+			"pin_order",
 			"created_at",
 			"updated_at",
 			"deleted_at",
@@ -28,16 +42,42 @@ func (c *ConversationMember) MapFields() ([]string, []any) {
 			&c.ID,
 			&c.ConversationID,
 			&c.UserID,
+			&c.Role,
+			&c.MutedUntil,
+			&c.ArchivedAt,
+			&c.PinOrder,
 			&c.CreatedAt,
 			&c.UpdatedAt,
 			&c.DeletedAt,
 		}
 }
 
+// IsAdmin reports whether the member can manage the conversation settings.
+func (c *ConversationMember) IsAdmin() bool {
+	return c.Role == ConversationMemberRoleOwner || c.Role == ConversationMemberRoleAdmin
+}
+
+// IsMuted reports whether notifications of the conversation are muted at t.
+func (c *ConversationMember) IsMuted(t time.Time) bool {
+	return c.MutedUntil != nil && c.MutedUntil.After(t)
+}
+
+// IsArchived reports whether the member moved the conversation to the archive.
+func (c *ConversationMember) IsArchived() bool {
+	return c.ArchivedAt != nil
+}
+
+// IsPinned reports whether the member pinned the conversation to the top of the list.
+func (c *ConversationMember) IsPinned() bool {
+	return c.PinOrder != nil
+}
+
 type ConversationMemberWithUser struct {
 	ConversationID string
 	UserID         string
 	FullName       string
 	Avatar         string
 	UserType       string
+	Role           string
+	MutedUntil     *time.Time
 }
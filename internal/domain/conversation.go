@@ -7,6 +7,28 @@ const (
 	ConversationTypeGroup = "GROUP"
 )
 
+const MessageTTLOff = "off"
+
+// MessageTTLs maps the disappearing message settings of a conversation to
+// how long new messages live, off keeps them forever.
+var MessageTTLs = map[string]time.Duration{
+	MessageTTLOff: 0,
+	"1h":          time.Hour,
+	"1d":          24 * time.Hour,
+	"7d":          7 * 24 * time.Hour,
+}
+
+// MaxPinnedConversations is how many conversations a user can pin to the top
+// of the conversation list.
+const MaxPinnedConversations = 5
+
+// ConversationListFilter selects which conversations of the user are listed,
+// archived conversations are never pinned.
+type ConversationListFilter struct {
+	Archived bool
+	Pinned   bool
+}
+
 type Conversation struct {
 	ID            string      `json:"id,omitempty"`
 	CreatedAt     *time.Time  `json:"created_at,omitempty"`
@@ -16,6 +38,11 @@ type Conversation struct {
 	UpdatedAt     *time.Time  `json:"updated_at,omitempty"`
 	DeletedAt     *time.Time  `json:"deleted_at,omitempty"`
 	LastMessageID string      `json:"last_message_id,omitempty"`
+	MessageTTL    string      `json:"message_ttl,omitempty"`
+	UnreadCount   int         `json:"-"` // for get list conversation by user
+	MutedUntil    *time.Time  `json:"-"` // settings of the user for get list conversation by user
+	ArchivedAt    *time.Time  `json:"-"`
+	PinOrder      *int        `json:"-"`
 	LastMessage   *Message    `json:"-"`
 	Members       []*UserInfo `json:"members,omitempty"`
 }
@@ -34,6 +61,7 @@ func (c *Conversation) MapFields() ([]string, []any) {
 			"updated_at",
 			"deleted_at",
 			"last_message_id",
+			"message_ttl",
 		}, []any{
 			&c.ID,
 			&c.CreatedAt,
@@ -43,5 +71,6 @@ func (c *Conversation) MapFields() ([]string, []any) {
 			&c.UpdatedAt,
 			&c.DeletedAt,
 			&c.LastMessageID,
+			&c.MessageTTL,
 		}
 }
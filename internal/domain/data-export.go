@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+const (
+	DataExportStatusPending   = "pending"
+	DataExportStatusCompleted = "completed"
+)
+
+type DataExportJob struct {
+	ID          string     `json:"id,omitempty"`
+	UserID      string     `json:"user_id,omitempty"`
+	AccountID   string     `json:"account_id,omitempty"`
+	Status      string     `json:"status,omitempty"`
+	ObjectName  string     `json:"object_name,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+func (d *DataExportJob) TableName() string {
+	return "data_export_job"
+}
+
+func (d *DataExportJob) MapFields() ([]string, []any) {
+	return []string{
+			"id",
+			"user_id",
+			"account_id",
+			"status",
+			"object_name",
+			"created_at",
+			"updated_at",
+			"completed_at",
+		}, []any{
+			&d.ID,
+			&d.UserID,
+			&d.AccountID,
+			&d.Status,
+			&d.ObjectName,
+			&d.CreatedAt,
+			&d.UpdatedAt,
+			&d.CompletedAt,
+		}
+}
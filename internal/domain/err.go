@@ -7,5 +7,7 @@ var (
 
 	ErrNotFoundMemberOfConversation = errors.New("user is not a member of conversation")
 
+	ErrNotAdminOfConversation = errors.New("user is not an admin of conversation")
+
 	ErrConversationAlreadyExist = errors.New("conversation already exist")
 )
@@ -0,0 +1,110 @@
+package domain
+
+import "time"
+
+const (
+	JoinRequestStatusPending  = "pending"
+	JoinRequestStatusApproved = "approved"
+	JoinRequestStatusRejected = "rejected"
+)
+
+// ConversationInvite is a shareable link to join a group. Every join or join
+// request counts as a use, a MaxUses of 0 is unlimited.
+type ConversationInvite struct {
+	ID               string     `json:"id,omitempty"`
+	ConversationID   string     `json:"conversation_id,omitempty"`
+	Code             string     `json:"code,omitempty"`
+	CreatedBy        string     `json:"created_by,omitempty"`
+	ExpiresAt        *time.Time `json:"expires_at,omitempty"`
+	MaxUses          int        `json:"max_uses"`
+	UseCount         int        `json:"use_count"`
+	RequiresApproval bool       `json:"requires_approval"`
+	RevokedAt        *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt        *time.Time `json:"created_at,omitempty"`
+	UpdatedAt        *time.Time `json:"updated_at,omitempty"`
+}
+
+func (c *ConversationInvite) TableName() string {
+	return "conversation_invite"
+}
+
+func (c *ConversationInvite) MapFields() ([]string, []any) {
+	return []string{
+			"id",
+			"conversation_id",
+			"code",
+			"created_by",
+			"expires_at",
+			"max_uses",
+			"use_count",
+			"requires_approval",
+			"revoked_at",
+			"created_at",
+			"updated_at",
+		}, []any{
+			&c.ID,
+			&c.ConversationID,
+			&c.Code,
+			&c.CreatedBy,
+			&c.ExpiresAt,
+			&c.MaxUses,
+			&c.UseCount,
+			&c.RequiresApproval,
+			&c.RevokedAt,
+			&c.CreatedAt,
+			&c.UpdatedAt,
+		}
+}
+
+// IsUsable reports whether the invite can still be used at t.
+func (c *ConversationInvite) IsUsable(t time.Time) bool {
+	if c.RevokedAt != nil {
+		return false
+	}
+	if c.ExpiresAt != nil && !c.ExpiresAt.After(t) {
+		return false
+	}
+	return c.MaxUses == 0 || c.UseCount < c.MaxUses
+}
+
+// ConversationJoinRequest is a join through an invite that requires approval,
+// the user becomes a member once an admin approves it.
+type ConversationJoinRequest struct {
+	ID             string     `json:"id,omitempty"`
+	InviteID       string     `json:"invite_id,omitempty"`
+	ConversationID string     `json:"conversation_id,omitempty"`
+	UserID         string     `json:"user_id,omitempty"`
+	Status         string     `json:"status,omitempty"`
+	ReviewedBy     string     `json:"reviewed_by,omitempty"`
+	ReviewedAt     *time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt      *time.Time `json:"created_at,omitempty"`
+	UpdatedAt      *time.Time `json:"updated_at,omitempty"`
+}
+
+func (c *ConversationJoinRequest) TableName() string {
+	return "conversation_join_request"
+}
+
+func (c *ConversationJoinRequest) MapFields() ([]string, []any) {
+	return []string{
+			"id",
+			"invite_id",
+			"conversation_id",
+			"user_id",
+			"status",
+			"reviewed_by",
+			"reviewed_at",
+			"created_at",
+			"updated_at",
+		}, []any{
+			&c.ID,
+			&c.InviteID,
+			&c.ConversationID,
+			&c.UserID,
+			&c.Status,
+			&c.ReviewedBy,
+			&c.ReviewedAt,
+			&c.CreatedAt,
+			&c.UpdatedAt,
+		}
+}
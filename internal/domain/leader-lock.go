@@ -0,0 +1,11 @@
+package domain
+
+import "context"
+
+// LeaderLock elects a single replica to run a background job.
+type LeaderLock interface {
+	// TryLock reports whether the caller holds the lock. Once acquired the
+	// lock is kept until Unlock or until the connection holding it is lost.
+	TryLock(ctx context.Context) (bool, error)
+	Unlock(ctx context.Context)
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"time"
+)
+
+const (
+	maxLocationLabelLength = 200
+
+	MinLiveLocationDuration = time.Minute
+	MaxLiveLocationDuration = 8 * time.Hour
+)
+
+// LocationContent is the body of a location message, stored as json. A live
+// location keeps its latest point in the body until it ends.
+type LocationContent struct {
+	Latitude  float64    `json:"latitude"`
+	Longitude float64    `json:"longitude"`
+	Accuracy  float64    `json:"accuracy,omitempty"` // radius in meters
+	Label     string     `json:"label,omitempty"`
+	Live      bool       `json:"live,omitempty"`
+	LiveUntil *time.Time `json:"live_until,omitempty"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+func ParseLocationContent(body string) (*LocationContent, error) {
+	var location LocationContent
+	err := json.Unmarshal([]byte(body), &location)
+	if err != nil {
+		return nil, err
+	}
+	return &location, nil
+}
+
+func (l *LocationContent) Validate() error {
+	return ValidateLocationPoint(l.Latitude, l.Longitude, l.Accuracy, l.Label)
+}
+
+// IsLive reports whether the location still accepts position updates.
+func (l *LocationContent) IsLive(t time.Time) bool {
+	return l.Live && l.EndedAt == nil && l.LiveUntil != nil && l.LiveUntil.After(t)
+}
+
+func ValidateLocationPoint(latitude, longitude, accuracy float64, label string) error {
+	if math.IsNaN(latitude) || latitude < -90 || latitude > 90 {
+		return errors.New("latitude must be between -90 and 90")
+	}
+	if math.IsNaN(longitude) || longitude < -180 || longitude > 180 {
+		return errors.New("longitude must be between -180 and 180")
+	}
+	if math.IsNaN(accuracy) || accuracy < 0 {
+		return errors.New("accuracy must not be negative")
+	}
+	if len([]rune(label)) > maxLocationLabelLength {
+		return errors.New("label is too long")
+	}
+	return nil
+}
+
+// LiveLocation tracks a live location message until it expires or its sender
+// stops sharing.
+type LiveLocation struct {
+	MessageID      string     `json:"message_id,omitempty"`
+	ConversationID string     `json:"conversation_id,omitempty"`
+	UserID         string     `json:"user_id,omitempty"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	EndedAt        *time.Time `json:"ended_at,omitempty"`
+	CreatedAt      *time.Time `json:"created_at,omitempty"`
+}
+
+func (l *LiveLocation) TableName() string {
+	return "live_location"
+}
+
+func (l *LiveLocation) MapFields() ([]string, []any) {
+	return []string{
+			"message_id",
+			"conversation_id",
+			"user_id",
+			"expires_at",
+			"ended_at",
+			"created_at",
+		}, []any{
+			&l.MessageID,
+			&l.ConversationID,
+			&l.UserID,
+			&l.ExpiresAt,
+			&l.EndedAt,
+			&l.CreatedAt,
+		}
+}
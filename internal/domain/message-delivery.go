@@ -0,0 +1,42 @@
+package domain
+
+import "time"
+
+// statuses of a message for one recipient, seen implies delivered.
+const (
+	MessageStatusSent      = "sent"
+	MessageStatusDelivered = "delivered"
+	MessageStatusSeen      = "seen"
+)
+
+type MessageDelivery struct {
+	MessageID   string     `json:"message_id,omitempty"`
+	UserID      string     `json:"user_id,omitempty"`
+	DeliveredAt *time.Time `json:"delivered_at,omitempty"`
+}
+
+func (m *MessageDelivery) TableName() string {
+	return "message_delivery"
+}
+
+func (m *MessageDelivery) MapFields() ([]string, []any) {
+	return []string{
+			"message_id",
+			"user_id",
+			"delivered_at",
+		}, []any{
+			&m.MessageID,
+			&m.UserID,
+			&m.DeliveredAt,
+		}
+}
+
+// DeliveredMessage is published when a message reached devices of UserIDs,
+// over a websocket or acknowledged from a push notification.
+type DeliveredMessage struct {
+	ConversationID string     `json:"conversation_id,omitempty"`
+	MessageID      string     `json:"message_id,omitempty"`
+	SenderID       string     `json:"sender_id,omitempty"`
+	UserIDs        []string   `json:"user_ids,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+}
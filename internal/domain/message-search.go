@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// MessageSearchTypes are the message types whose body is searched.
+var MessageSearchTypes = []string{MessageTypeText, MessageTypeAction}
+
+// MessageSearchFilter narrows a search to the conversations of UserID. The
+// cursor is the rank and id of the last result of the previous page.
+type MessageSearchFilter struct {
+	UserID         string
+	Query          string
+	ConversationID string
+	FromUserID     string
+	Before         *time.Time
+	After          *time.Time
+	Type           string
+	CursorRank     float32
+	CursorID       string
+	Limit          int
+}
+
+type MessageSearchResult struct {
+	Message *Message
+	Rank    float32
+	Snippet string // HTML escaped body with the matched words wrapped in <mark></mark>
+}
@@ -13,6 +13,7 @@ const (
 	MessageTypeSticker  = "sticker"
 	MessageTypePoll     = "poll"
 	MessageTypeSystem   = "system"
+	MessageTypeAction   = "action" // sent by /me, rendered as "<sender> <body>"
 )
 
 type Message struct {
@@ -25,6 +26,10 @@ type Message struct {
 	UpdatedAt      *time.Time `json:"updated_at,omitempty"`
 	DeletedAt      *time.Time `json:"deleted_at,omitempty"`
 	ReplyTo        string     `json:"reply_to,omitempty"`
+	DisplayName    string     `json:"display_name,omitempty"`   // overrides the sender name, set by incoming webhooks
+	ThreadID       string     `json:"thread_id,omitempty"`      // id of the root message of the thread of a reply
+	ForwardedFrom  string     `json:"forwarded_from,omitempty"` // id of the original message of a forwarded copy
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`     // set in conversations with disappearing messages
 	User           *UserInfo  `json:"-"`
 	IgnoreSend     string     `json:"-"`
 	IsRead         bool       `json:"-"` // for get list conversation by user
@@ -46,6 +51,10 @@ func (m *Message) MapFields() ([]string, []any) {
 			"updated_at",
 			"deleted_at",
 			"reply_to",
+			"display_name",
+			"thread_id",
+			"forwarded_from",
+			"expires_at",
 		}, []any{
 			&m.ID,
 			&m.ConversationID,
@@ -56,5 +65,9 @@ func (m *Message) MapFields() ([]string, []any) {
 			&m.UpdatedAt,
 			&m.DeletedAt,
 			&m.ReplyTo,
+			&m.DisplayName,
+			&m.ThreadID,
+			&m.ForwardedFrom,
+			&m.ExpiresAt,
 		}
 }
@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+// MaxPinnedMessages caps the pins of a conversation.
+const MaxPinnedMessages = 50
+
+type PinnedMessage struct {
+	ConversationID string     `json:"conversation_id,omitempty"`
+	MessageID      string     `json:"message_id,omitempty"`
+	PinnedBy       string     `json:"pinned_by,omitempty"`
+	PinnedAt       *time.Time `json:"pinned_at,omitempty"`
+}
+
+func (p *PinnedMessage) TableName() string {
+	return "pinned_message"
+}
+
+func (p *PinnedMessage) MapFields() ([]string, []any) {
+	return []string{
+			"conversation_id",
+			"message_id",
+			"pinned_by",
+			"pinned_at",
+		}, []any{
+			&p.ConversationID,
+			&p.MessageID,
+			&p.PinnedBy,
+			&p.PinnedAt,
+		}
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+)
+
+// MaxPollOptions caps the number of options of a poll.
+const MaxPollOptions = 10
+
+// PollContent is the body of a poll message, stored as json.
+type PollContent struct {
+	Question       string        `json:"question"`
+	Options        []*PollOption `json:"options"`
+	MultipleChoice bool          `json:"multiple_choice,omitempty"`
+	Anonymous      bool          `json:"anonymous,omitempty"`
+	ClosesAt       *time.Time    `json:"closes_at,omitempty"`
+}
+
+type PollOption struct {
+	ID   string `json:"id"`
+	Text string `json:"text"`
+}
+
+func ParsePollContent(body string) (*PollContent, error) {
+	var poll PollContent
+	err := json.Unmarshal([]byte(body), &poll)
+	if err != nil {
+		return nil, err
+	}
+	return &poll, nil
+}
+
+func (p *PollContent) IsClosed(t time.Time) bool {
+	return p.ClosesAt != nil && !p.ClosesAt.After(t)
+}
+
+func (p *PollContent) HasOption(optionID string) bool {
+	return slices.ContainsFunc(p.Options, func(option *PollOption) bool {
+		return option.ID == optionID
+	})
+}
+
+type PollVote struct {
+	ID        string     `json:"id,omitempty"`
+	MessageID string     `json:"message_id,omitempty"`
+	OptionID  string     `json:"option_id,omitempty"`
+	UserID    string     `json:"user_id,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	User      *UserInfo  `json:"-"`
+}
+
+func (p *PollVote) TableName() string {
+	return "poll_vote"
+}
+
+func (p *PollVote) MapFields() ([]string, []any) {
+	return []string{
+			"id",
+			"message_id",
+			"option_id",
+			"user_id",
+			"created_at",
+		}, []any{
+			&p.ID,
+			&p.MessageID,
+			&p.OptionID,
+			&p.UserID,
+			&p.CreatedAt,
+		}
+}
@@ -11,6 +11,7 @@ type AccountRepository interface {
 	GetAccountByID(ctx context.Context, id string) (*Account, error)
 	UpdatePassword(ctx context.Context, id string, password string) error
 	CreateAccountUser(ctx context.Context, account *Account, user *UserInfo) error
+	AnonymizeAccount(ctx context.Context, id string) error
 }
 
 type UserRepository interface {
@@ -21,12 +22,15 @@ type UserRepository interface {
 	UpdateUser(ctx context.Context, user *UserInfo) error
 	GetListUser(ctx context.Context, keyword string, limit int, lastID string) ([]*UserInfo, error)
 	GetListUserWithConversation(ctx context.Context, userID string, keyword string, limit int, lastID string) ([]*UserInfo, error)
+	AnonymizeUser(ctx context.Context, userID string, deletedAt time.Time) error
+	GetListUserByIDs(ctx context.Context, ids []string) ([]*UserInfo, error)
 }
 
 type SessionRepository interface {
 	CreateSession(ctx context.Context, session *Session) error
 	GetSessionByToken(ctx context.Context, token string) (*Session, error)
 	GetListSessionByAccountID(ctx context.Context, accountID string) ([]*Session, error)
+	GetListSessionHistoryByAccountID(ctx context.Context, accountID string) ([]*Session, error)
 	DeactivateSession(ctx context.Context, token string) error
 	DeactiveAllSessionByAccountID(ctx context.Context, accountID string) error
 	UpdateExpiredAt(ctx context.Context, token string, newExpiredAt *time.Time) error
@@ -42,21 +46,45 @@ type UserOnlineRepository interface {
 	CreateUserOnline(ctx context.Context, userOnline *UserOnline) error
 	DeleteUserOnline(ctx context.Context, id string) error
 	GetUserOnlineByConversationID(ctx context.Context, conversationID string) ([]*UserOnline, error)
+	GetUserOnlineByUserID(ctx context.Context, userID string) ([]*UserOnline, error)
+	DeleteUserOnlineByUserID(ctx context.Context, userID string) error
 }
 
 type ConversationRepository interface {
 	CreateConversation(ctx context.Context, conversation *Conversation, conversationMembers []*ConversationMember) (*Conversation, error)
-	GetListConversationByUserID(ctx context.Context, userID string, lastMessageID string, limit int) ([]*Conversation, error)
+	GetListConversationByUserID(ctx context.Context, userID string, lastMessageID string, limit int, filter ConversationListFilter) ([]*Conversation, error)
 	GetConversationByID(ctx context.Context, id string) (*Conversation, []*ConversationMemberWithUser, error)
 	UpdateLastMessageID(ctx context.Context, conversationID string, lastMessageID string) error
 	CheckIsMemberOfConversation(ctx context.Context, userID string, conversationID string) (bool, error)
 	CheckDMConversationExist(ctx context.Context, userID1 string, userID2 string) (*Conversation, error)
+	GetListConversationIDByUserID(ctx context.Context, userID string) ([]string, error)
+	AddConversationMember(ctx context.Context, member *ConversationMember) (bool, error)
+	DeleteConversationMember(ctx context.Context, conversationID string, userID string) (bool, error)
+	GetConversationMember(ctx context.Context, conversationID string, userID string) (*ConversationMember, error)
+	UpdateConversationMemberMutedUntil(ctx context.Context, conversationID string, userID string, mutedUntil *time.Time) error
+	UpdateConversationMemberArchivedAt(ctx context.Context, conversationID string, userID string, archivedAt *time.Time) error
+	GetListPinnedConversationID(ctx context.Context, userID string) ([]string, error)
+	UpdatePinnedConversationOrder(ctx context.Context, userID string, conversationIDs []string) error
+	GetConversationMessageTTL(ctx context.Context, conversationID string) (string, error)
+	UpdateConversationMessageTTL(ctx context.Context, conversationID string, messageTTL string) error
+	UpdateConversationInfo(ctx context.Context, conversationID string, title string, avatar string) error
 }
 
 type MessageRepository interface {
 	CreateMessage(ctx context.Context, message *Message) (*Message, error)
+	CreateMessages(ctx context.Context, messages []*Message) error
 	GetListMessageByConversationID(ctx context.Context, conversationID string, lastID string, limit int) ([]*Message, error)
+	GetListMessageAfterID(ctx context.Context, conversationID string, afterID string, limit int) ([]*Message, error)
 	GetMessageByID(ctx context.Context, id string) (*Message, error)
+	GetListMessageByUserID(ctx context.Context, userID string, afterID string, limit int) ([]*Message, error)
+	ReassignMessagesByUserID(ctx context.Context, fromUserID string, toUserID string, limit int) (int64, error)
+	UpdateMessageBody(ctx context.Context, id string, body string, updatedAt time.Time) error
+	GetListMessageByThreadID(ctx context.Context, threadID string, lastID string, limit int) ([]*Message, error)
+	GetListMessageByIDs(ctx context.Context, ids []string) ([]*Message, error)
+	GetListThreadSummary(ctx context.Context, rootIDs []string) ([]*ThreadSummary, error)
+	DeleteExpiredMessages(ctx context.Context, now time.Time, limit int) ([]*Message, error)
+	CheckMessageBodyInUse(ctx context.Context, body string) (bool, error)
+	SearchMessages(ctx context.Context, filter *MessageSearchFilter) ([]*MessageSearchResult, error)
 }
 
 type UserCacheRepository interface {
@@ -67,6 +95,8 @@ type UserCacheRepository interface {
 type SeenMessageRepository interface {
 	CreateSeenMessage(ctx context.Context, seenMessage *SeenMessage) error
 	GetListSeenMessageByConversationID(ctx context.Context, conversationID string) ([]*SeenMessage, error)
+	DeleteSeenMessageByUserID(ctx context.Context, userID string) error
+	DeleteSeenMessage(ctx context.Context, userID string, conversationID string) error
 }
 
 type ContactRepository interface {
@@ -77,6 +107,9 @@ type ContactRepository interface {
 	GetListRequestFriendReceivedByUserID(ctx context.Context, userID string, limit int, lastID string) ([]*RequestFriend, error)
 	AcceptRequestFriend(ctx context.Context, requestFriendID string) error
 	UpdateRequestFriendStatus(ctx context.Context, id string, status string) error
+	DeleteContactsByUserID(ctx context.Context, userID string) error
+	DeletePendingRequestFriendByUserID(ctx context.Context, userID string) error
+	GetListContactRelation(ctx context.Context, userID string, otherUserIDs []string) ([]*ContactRelation, error)
 }
 
 type FcmTokenRepository interface {
@@ -84,4 +117,137 @@ type FcmTokenRepository interface {
 	GetFcmTokenByUserID(ctx context.Context, userID string) ([]*FcmToken, error)
 	DeleteFcmToken(ctx context.Context, id string) error
 	DeleteFcmTokenByUserIDAndToken(ctx context.Context, userID, token string) error
+	DeleteFcmTokenByUserID(ctx context.Context, userID string) error
+}
+
+type DataExportRepository interface {
+	CreateDataExportJob(ctx context.Context, job *DataExportJob) error
+	GetDataExportJobByID(ctx context.Context, id string) (*DataExportJob, error)
+	UpdateDataExportJob(ctx context.Context, job *DataExportJob) error
+}
+
+type AccountDeletionRepository interface {
+	CreateAccountDeletionJob(ctx context.Context, job *AccountDeletionJob) error
+	GetAccountDeletionJobByID(ctx context.Context, id string) (*AccountDeletionJob, error)
+	GetAccountDeletionJobByUserID(ctx context.Context, userID string) (*AccountDeletionJob, error)
+	GetListAccountDeletionJobByStatus(ctx context.Context, status string) ([]*AccountDeletionJob, error)
+	UpdateAccountDeletionJob(ctx context.Context, job *AccountDeletionJob) error
+}
+
+type BotRepository interface {
+	CreateBot(ctx context.Context, account *Account, user *UserInfo, bot *Bot) error
+	GetBotByID(ctx context.Context, id string) (*Bot, error)
+	GetListBotByOwnerID(ctx context.Context, ownerID string) ([]*Bot, error)
+	DeleteBot(ctx context.Context, id string, deletedAt time.Time) error
+}
+
+type ApiKeyRepository interface {
+	CreateApiKey(ctx context.Context, apiKey *ApiKey) error
+	GetApiKeyByID(ctx context.Context, id string) (*ApiKey, error)
+	GetActiveApiKeyByHash(ctx context.Context, keyHash string) (*ApiKey, error)
+	GetListApiKeyByBotID(ctx context.Context, botID string) ([]*ApiKey, error)
+	RevokeApiKey(ctx context.Context, id string, revokedAt time.Time) error
+	RevokeApiKeysByBotID(ctx context.Context, botID string, revokedAt time.Time) error
+	UpdateLastUsedAt(ctx context.Context, id string, lastUsedAt time.Time) error
+}
+
+type BotCommandRepository interface {
+	CreateBotCommand(ctx context.Context, command *BotCommand) error
+	GetBotCommandByID(ctx context.Context, id string) (*BotCommand, error)
+	GetListBotCommandByBotID(ctx context.Context, botID string) ([]*BotCommand, error)
+	GetBotCommandInConversation(ctx context.Context, conversationID string, command string) (*BotCommand, error)
+	DeleteBotCommand(ctx context.Context, id string) error
+	CreateBotCommandInvocation(ctx context.Context, invocation *BotCommandInvocation) error
+	GetBotCommandInvocationByID(ctx context.Context, id string) (*BotCommandInvocation, error)
+}
+
+type PollVoteRepository interface {
+	CreatePollVotes(ctx context.Context, messageID string, userID string, votes []*PollVote, replace bool) error
+	DeletePollVotes(ctx context.Context, messageID string, userID string, optionID string) error
+	GetListPollVoteByMessageIDs(ctx context.Context, messageIDs []string) ([]*PollVote, error)
+}
+
+type StickerRepository interface {
+	CreateStickerPack(ctx context.Context, pack *StickerPack, stickers []*Sticker) error
+	GetStickerPackByID(ctx context.Context, id string) (*StickerPack, error)
+	GetListStickerPack(ctx context.Context, userID string) ([]*StickerPack, error)
+	GetListInstalledStickerPack(ctx context.Context, userID string) ([]*StickerPack, error)
+	GetListStickerByPackIDs(ctx context.Context, packIDs []string) ([]*Sticker, error)
+	GetAvailableSticker(ctx context.Context, userID string, stickerID string) (*Sticker, error)
+	InstallStickerPack(ctx context.Context, userID string, packID string, installedAt time.Time) error
+	UninstallStickerPack(ctx context.Context, userID string, packID string) error
+	UpdateStickerPackPositions(ctx context.Context, userID string, packIDs []string) error
+}
+
+type LiveLocationRepository interface {
+	CreateLiveLocation(ctx context.Context, liveLocation *LiveLocation) error
+	GetLiveLocationByMessageID(ctx context.Context, messageID string) (*LiveLocation, error)
+	EndLiveLocation(ctx context.Context, messageID string, endedAt time.Time) (bool, error)
+	EndExpiredLiveLocations(ctx context.Context, now time.Time, limit int) ([]*LiveLocation, error)
+}
+
+type WebhookRepository interface {
+	CreateWebhook(ctx context.Context, webhook *Webhook) error
+	GetWebhookByID(ctx context.Context, id string) (*Webhook, error)
+	GetListWebhookByConversationID(ctx context.Context, conversationID string) ([]*Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook *Webhook) error
+	DeleteWebhook(ctx context.Context, id string) error
+	ResetWebhookFailure(ctx context.Context, id string) error
+	IncreaseWebhookFailure(ctx context.Context, id string, disableThreshold int, at time.Time) (bool, error)
+	CreateWebhookDeliveries(ctx context.Context, deliveries []*WebhookDelivery) error
+	GetWebhookDeliveryByID(ctx context.Context, id string) (*WebhookDelivery, error)
+	GetListWebhookDeliveryByWebhookID(ctx context.Context, webhookID string, lastID string, limit int) ([]*WebhookDelivery, error)
+	UpdateWebhookDelivery(ctx context.Context, delivery *WebhookDelivery) error
+	ClaimDueWebhookDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*WebhookDelivery, error)
+}
+
+type IncomingWebhookRepository interface {
+	CreateIncomingWebhook(ctx context.Context, account *Account, user *UserInfo, bot *Bot, member *ConversationMember, incomingWebhook *IncomingWebhook) error
+	GetIncomingWebhookByID(ctx context.Context, id string) (*IncomingWebhook, error)
+	GetActiveIncomingWebhookByHash(ctx context.Context, tokenHash string) (*IncomingWebhook, error)
+	GetListIncomingWebhookByConversationID(ctx context.Context, conversationID string) ([]*IncomingWebhook, error)
+	RevokeIncomingWebhook(ctx context.Context, id string, revokedAt time.Time) error
+	UpdateIncomingWebhookLastUsedAt(ctx context.Context, id string, lastUsedAt time.Time) error
+}
+
+type PinnedMessageRepository interface {
+	CreatePinnedMessage(ctx context.Context, pin *PinnedMessage, limit int) (bool, int, error)
+	DeletePinnedMessage(ctx context.Context, conversationID string, messageID string) (bool, error)
+	GetListPinnedMessage(ctx context.Context, conversationID string) ([]*PinnedMessage, error)
+	CountPinnedMessage(ctx context.Context, conversationID string) (int, error)
+}
+
+type ScheduledMessageRepository interface {
+	CreateScheduledMessage(ctx context.Context, scheduledMessage *ScheduledMessage) error
+	GetListScheduledMessageByUserID(ctx context.Context, userID string, conversationID string) ([]*ScheduledMessage, error)
+	CancelScheduledMessage(ctx context.Context, id string, userID string, cancelledAt time.Time) (bool, error)
+	ClaimDueScheduledMessages(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*ScheduledMessage, error)
+	UpdateScheduledMessageStatus(ctx context.Context, scheduledMessage *ScheduledMessage) error
+}
+
+type UnreadCountRepository interface {
+	IncrementUnreadCount(ctx context.Context, message *Message) ([]*UnreadCount, error)
+	RecountUnreadCount(ctx context.Context, conversationID string, userID string) (*UnreadCount, error)
+	RecountConversationUnreadCount(ctx context.Context, conversationID string) ([]*UnreadCount, error)
+	GetTotalUnreadCounts(ctx context.Context, userIDs []string) (map[string]int, error)
+	GetListLatestUnreadMessage(ctx context.Context, userID string) ([]*SeenMessage, error)
+}
+
+type MessageDeliveryRepository interface {
+	CreateMessageDeliveries(ctx context.Context, messageID string, userIDs []string, deliveredAt time.Time) ([]string, error)
+	GetListMessageDelivery(ctx context.Context, messageID string) ([]*MessageDelivery, error)
+}
+
+type ConversationInviteRepository interface {
+	CreateConversationInvite(ctx context.Context, invite *ConversationInvite) error
+	GetConversationInviteByID(ctx context.Context, id string) (*ConversationInvite, error)
+	GetConversationInviteByCode(ctx context.Context, code string) (*ConversationInvite, error)
+	GetListConversationInvite(ctx context.Context, conversationID string) ([]*ConversationInvite, error)
+	RevokeConversationInvite(ctx context.Context, id string, revokedAt time.Time) error
+	JoinConversationInvite(ctx context.Context, inviteID string, member *ConversationMember, now time.Time) (bool, bool, error)
+	CreateConversationJoinRequest(ctx context.Context, joinRequest *ConversationJoinRequest, now time.Time) (bool, bool, error)
+	GetConversationJoinRequestByID(ctx context.Context, id string) (*ConversationJoinRequest, error)
+	GetPendingConversationJoinRequest(ctx context.Context, conversationID string, userID string) (*ConversationJoinRequest, error)
+	GetListPendingConversationJoinRequest(ctx context.Context, conversationID string) ([]*ConversationJoinRequest, error)
+	ReviewConversationJoinRequest(ctx context.Context, id string, status string, reviewedBy string, reviewedAt time.Time) (bool, error)
 }
@@ -0,0 +1,75 @@
+package domain
+
+import "time"
+
+const (
+	ScheduledMessageStatusPending   = "pending"
+	ScheduledMessageStatusSending   = "sending"
+	ScheduledMessageStatusSent      = "sent"
+	ScheduledMessageStatusCancelled = "cancelled"
+	ScheduledMessageStatusFailed    = "failed"
+)
+
+// MaxScheduleAhead caps how far in the future a message can be scheduled.
+const MaxScheduleAhead = 365 * 24 * time.Hour
+
+// ScheduledMessageTypes lists the message types that can be scheduled.
+var ScheduledMessageTypes = []string{
+	MessageTypeText,
+	MessageTypeImage,
+	MessageTypeVideo,
+	MessageTypeAudio,
+	MessageTypeFile,
+	MessageTypeSticker,
+}
+
+// ScheduledMessage is a message written now and sent by the scheduler at
+// SendAt on behalf of its author.
+type ScheduledMessage struct {
+	ID             string     `json:"id,omitempty"`
+	ConversationID string     `json:"conversation_id,omitempty"`
+	UserID         string     `json:"user_id,omitempty"`
+	Type           string     `json:"type,omitempty"`
+	Body           string     `json:"body,omitempty"`
+	ReplyTo        string     `json:"reply_to,omitempty"`
+	SendAt         *time.Time `json:"send_at,omitempty"`
+	Status         string     `json:"status,omitempty"`
+	MessageID      string     `json:"message_id,omitempty"` // the sent message
+	Error          string     `json:"error,omitempty"`      // why the message could not be sent
+	CreatedAt      *time.Time `json:"created_at,omitempty"`
+	UpdatedAt      *time.Time `json:"updated_at,omitempty"`
+}
+
+func (s *ScheduledMessage) TableName() string {
+	return "scheduled_message"
+}
+
+func (s *ScheduledMessage) MapFields() ([]string, []any) {
+	return []string{
+			"id",
+			"conversation_id",
+			"user_id",
+			"type",
+			"body",
+			"reply_to",
+			"send_at",
+			"status",
+			"message_id",
+			"error",
+			"created_at",
+			"updated_at",
+		}, []any{
+			&s.ID,
+			&s.ConversationID,
+			&s.UserID,
+			&s.Type,
+			&s.Body,
+			&s.ReplyTo,
+			&s.SendAt,
+			&s.Status,
+			&s.MessageID,
+			&s.Error,
+			&s.CreatedAt,
+			&s.UpdatedAt,
+		}
+}
@@ -9,6 +9,7 @@ type SeenMessage struct {
 	ConversationID string     `json:"conversation_id,omitempty"`
 	CreatedAt      *time.Time `json:"created_at,omitempty"`
 	UpdatedAt      *time.Time `json:"updated_at,omitempty"`
+	MarkedUnread   bool       `json:"marked_unread,omitempty"` // moved back by the user, other members are not told
 }
 
 func (s *SeenMessage) TableName() string {
@@ -63,6 +63,14 @@ func (s Session) ConvertToMapString() map[string]string {
 		result["is_active"] = fmt.Sprintf("%t", *s.IsActive)
 	}
 
+	if s.CreatedAt != nil {
+		result["created_at"] = s.CreatedAt.Format(time.RFC3339Nano)
+	}
+
+	if s.ExpiredAt != nil {
+		result["expired_at"] = s.ExpiredAt.Format(time.RFC3339Nano)
+	}
+
 	return result
 }
 
@@ -81,4 +89,21 @@ func (s *Session) FromMap(m map[string]string) {
 			s.IsActive = &active
 		}
 	}
+
+	if createdAt, ok := m["created_at"]; ok {
+		if t, err := time.Parse(time.RFC3339Nano, createdAt); err == nil {
+			s.CreatedAt = &t
+		}
+	}
+
+	if expiredAt, ok := m["expired_at"]; ok {
+		if t, err := time.Parse(time.RFC3339Nano, expiredAt); err == nil {
+			s.ExpiredAt = &t
+		}
+	}
+}
+
+// IsExpired reports whether the session has passed its expiry time.
+func (s *Session) IsExpired(now time.Time) bool {
+	return s.ExpiredAt != nil && !s.ExpiredAt.After(now)
 }
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// MaxStickersPerPack caps the size of an imported pack.
+const MaxStickersPerPack = 120
+
+// StickerPack is a set of stickers. Default packs are available to everyone,
+// other packs have to be installed by the user first.
+type StickerPack struct {
+	ID           string     `json:"id,omitempty"`
+	Name         string     `json:"name,omitempty"`
+	Description  string     `json:"description,omitempty"`
+	ThumbnailURL string     `json:"thumbnail_url,omitempty"`
+	IsDefault    bool       `json:"is_default,omitempty"`
+	CreatedAt    *time.Time `json:"created_at,omitempty"`
+	DeletedAt    *time.Time `json:"deleted_at,omitempty"`
+	Installed    bool       `json:"-"` // for the pack list of a user
+}
+
+func (s *StickerPack) TableName() string {
+	return "sticker_pack"
+}
+
+func (s *StickerPack) MapFields() ([]string, []any) {
+	return []string{
+			"id",
+			"name",
+			"description",
+			"thumbnail_url",
+			"is_default",
+			"created_at",
+			"deleted_at",
+		}, []any{
+			&s.ID,
+			&s.Name,
+			&s.Description,
+			&s.ThumbnailURL,
+			&s.IsDefault,
+			&s.CreatedAt,
+			&s.DeletedAt,
+		}
+}
+
+type Sticker struct {
+	ID        string     `json:"id,omitempty"`
+	PackID    string     `json:"pack_id,omitempty"`
+	Emoji     string     `json:"emoji,omitempty"`
+	URL       string     `json:"url,omitempty"`
+	Position  int        `json:"position,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+func (s *Sticker) TableName() string {
+	return "sticker"
+}
+
+func (s *Sticker) MapFields() ([]string, []any) {
+	return []string{
+			"id",
+			"pack_id",
+			"emoji",
+			"url",
+			"position",
+			"created_at",
+		}, []any{
+			&s.ID,
+			&s.PackID,
+			&s.Emoji,
+			&s.URL,
+			&s.Position,
+			&s.CreatedAt,
+		}
+}
+
+// StickerContent is the body of a sticker message, stored as json. Clients
+// send the sticker id, the rest is filled in from the sticker so the message
+// renders without a lookup.
+type StickerContent struct {
+	StickerID string `json:"sticker_id"`
+	PackID    string `json:"pack_id,omitempty"`
+	URL       string `json:"url,omitempty"`
+	Emoji     string `json:"emoji,omitempty"`
+}
+
+func ParseStickerContent(body string) (*StickerContent, error) {
+	var sticker StickerContent
+	err := json.Unmarshal([]byte(body), &sticker)
+	if err != nil {
+		return nil, err
+	}
+	return &sticker, nil
+}
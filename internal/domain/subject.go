@@ -5,10 +5,14 @@ const (
 	STREAM_NAME_WS_MESSAGE   = "WS_MESSAGE"
 	STREAM_NAME_CONVERSATION = "CONVERSATION"
 	STREAM_NAME_FCM          = "FCM"
+	STREAM_NAME_USER         = "USER"
+	STREAM_NAME_BOT          = "BOT"
 
 	//subject for conversation
 	SUBJECT_WILDCARD_CONVERSATION  = "conversation.*"
 	SUBJECT_UPDATE_LAST_MESSAGE_ID = "conversation.update_last_message_id"
+	SUBJECT_WEBHOOK_DELIVERY       = "conversation.webhook_delivery"
+	SUBJECT_MESSAGE_DELIVERED      = "conversation.message_delivered"
 
 	//subject for websocket
 	SUBJECT_WILDCARD_MESSAGE          = "ws_message.*"
@@ -19,9 +23,15 @@ const (
 	CONSUMER_NAME_WS_MESSAGE_NEW                 = "ws_message_new_consumer"
 	CONSUMER_NAME_WS_MESSAGE_UPDATE_LAST_MESSAGE = "ws_message_update_last_message_consumer"
 	CONSUMER_NAME_SEEN_MESSAGE                   = "seen_message_consumer"
+	CONSUMER_NAME_WEBHOOK_EVENT                  = "ws_message_webhook_event_consumer"
+	CONSUMER_NAME_WEBHOOK_DELIVERY               = "webhook_delivery_consumer"
+	CONSUMER_NAME_MESSAGE_DELIVERED              = "message_delivered_consumer"
 	//queue name
 	QUEUE_NAME_WS_MESSAGE_UPDATE_LAST_MESSAGE = "ws_message_update_last_message_queue"
 	QUEUE_NAME_SEEN_MESSAGE                   = "seen_message_queue"
+	QUEUE_NAME_WEBHOOK_EVENT                  = "ws_message_webhook_event_queue"
+	QUEUE_NAME_WEBHOOK_DELIVERY               = "webhook_delivery_queue"
+	QUEUE_NAME_MESSAGE_DELIVERED              = "message_delivered_queue"
 
 	//subject for seen message
 	SUBJECT_SEEN_MESSAGE = "conversation.seen_message"
@@ -35,4 +45,28 @@ const (
 
 	//queue name for fcm
 	QUEUE_NAME_FCM_MESSAGE = "fcm_message_queue"
+
+	//subject for user
+	SUBJECT_WILDCARD_USER  = "user.*"
+	SUBJECT_DELETE_ACCOUNT = "user.delete_account"
+	SUBJECT_EXPORT_DATA    = "user.export_data"
+
+	//consumer name for user
+	CONSUMER_NAME_DELETE_ACCOUNT = "user_delete_account_consumer"
+	CONSUMER_NAME_EXPORT_DATA    = "user_export_data_consumer"
+
+	//queue name for user
+	QUEUE_NAME_DELETE_ACCOUNT = "user_delete_account_queue"
+	QUEUE_NAME_EXPORT_DATA    = "user_export_data_queue"
+
+	//subject for bot, invocations are published on bot.command.<bot_id>
+	SUBJECT_WILDCARD_BOT       = "bot.>"
+	SUBJECT_PREFIX_BOT_COMMAND = "bot.command"
+	SUBJECT_BOT_COMMAND_REPLY  = "bot.command_reply"
+
+	//consumer name for bot
+	CONSUMER_NAME_BOT_COMMAND_REPLY = "bot_command_reply_consumer"
+
+	//queue name for bot
+	QUEUE_NAME_BOT_COMMAND_REPLY = "bot_command_reply_queue"
 )
@@ -0,0 +1,31 @@
+package domain
+
+import "encoding/json"
+
+const (
+	SystemActionMessagePinned   = "message_pinned"
+	SystemActionMessageUnpinned = "message_unpinned"
+	SystemActionMessageTTL      = "message_ttl_updated"
+	SystemActionTitleUpdated    = "title_updated"
+	SystemActionAvatarUpdated   = "avatar_updated"
+	SystemActionMemberJoined    = "member_joined"
+	SystemActionMemberRemoved   = "member_removed"
+	SystemActionMemberLeft      = "member_left"
+)
+
+// SystemMessageContent is the body of a system message, stored as json.
+// Clients render it from the action, the sender of the message is the user
+// who did it.
+type SystemMessageContent struct {
+	Action     string `json:"action"`
+	MessageID  string `json:"message_id,omitempty"`
+	MessageTTL string `json:"message_ttl,omitempty"`
+	Title      string `json:"title,omitempty"`
+	Avatar     string `json:"avatar,omitempty"`  // empty when the avatar was removed
+	UserID     string `json:"user_id,omitempty"` // the member who joined, left or was removed
+}
+
+func (s SystemMessageContent) String() string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
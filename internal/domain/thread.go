@@ -0,0 +1,12 @@
+package domain
+
+import "time"
+
+// ThreadSummary describes the replies of a thread for its root message.
+type ThreadSummary struct {
+	RootID         string
+	ReplyCount     int
+	LastReplyID    string
+	LastReplyAt    *time.Time
+	ParticipantIDs []string // most recent repliers first
+}
@@ -0,0 +1,9 @@
+package domain
+
+// UnreadCount is the number of messages of others a user has not seen yet in
+// a conversation, system messages are not counted.
+type UnreadCount struct {
+	UserID         string `json:"user_id,omitempty"`
+	ConversationID string `json:"conversation_id,omitempty"`
+	UnreadCount    int    `json:"unread_count"`
+}
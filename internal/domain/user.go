@@ -36,6 +36,7 @@ func (u *UserInfo) MapFields() ([]string, []any) {
 			"avatar",
 			"created_at",
 			"updated_at",
+			"deleted_at",
 		}, []any{
 			&u.ID,
 			&u.AccountID,
@@ -45,6 +46,7 @@ func (u *UserInfo) MapFields() ([]string, []any) {
 			&u.Avatar,
 			&u.CreatedAt,
 			&u.UpdatedAt,
+			&u.DeletedAt,
 		}
 }
 
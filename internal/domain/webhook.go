@@ -0,0 +1,173 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+const (
+	WebhookEventMessageCreated = "message.created"
+	WebhookEventMessageUpdated = "message.updated"
+	WebhookEventMemberAdded    = "member.added"
+	WebhookEventMemberRemoved  = "member.removed"
+	WebhookEventMemberLeft     = "member.left"
+)
+
+var WebhookEvents = []string{
+	WebhookEventMessageCreated,
+	WebhookEventMessageUpdated,
+	WebhookEventMemberAdded,
+	WebhookEventMemberRemoved,
+	WebhookEventMemberLeft,
+}
+
+const (
+	WebhookDeliveryStatusPending = "pending"
+	WebhookDeliveryStatusSuccess = "success"
+	WebhookDeliveryStatusFailed  = "failed"
+)
+
+// Webhook is an outgoing callback registered on a conversation. The secret
+// signs every payload so receivers can verify where it came from.
+type Webhook struct {
+	ID             string     `json:"id,omitempty"`
+	ConversationID string     `json:"conversation_id,omitempty"`
+	CreatedBy      string     `json:"created_by,omitempty"`
+	URL            string     `json:"url,omitempty"`
+	Secret         string     `json:"-"`
+	Events         []string   `json:"events,omitempty"`
+	IsActive       bool       `json:"is_active"`
+	FailureCount   int        `json:"failure_count"`
+	DisabledAt     *time.Time `json:"disabled_at,omitempty"`
+	CreatedAt      *time.Time `json:"created_at,omitempty"`
+	UpdatedAt      *time.Time `json:"updated_at,omitempty"`
+}
+
+func (w *Webhook) TableName() string {
+	return "webhook"
+}
+
+func (w *Webhook) MapFields() ([]string, []any) {
+	return []string{
+			"id",
+			"conversation_id",
+			"created_by",
+			"url",
+			"secret",
+			"events",
+			"is_active",
+			"failure_count",
+			"disabled_at",
+			"created_at",
+			"updated_at",
+		}, []any{
+			&w.ID,
+			&w.ConversationID,
+			&w.CreatedBy,
+			&w.URL,
+			&w.Secret,
+			&w.Events,
+			&w.IsActive,
+			&w.FailureCount,
+			&w.DisabledAt,
+			&w.CreatedAt,
+			&w.UpdatedAt,
+		}
+}
+
+func (w *Webhook) HasEvent(event string) bool {
+	return slices.Contains(w.Events, event)
+}
+
+// WebhookDelivery is one event sent to one webhook, it doubles as the
+// delivery log and as the retry queue.
+type WebhookDelivery struct {
+	ID             string     `json:"id,omitempty"`
+	WebhookID      string     `json:"webhook_id,omitempty"`
+	Event          string     `json:"event,omitempty"`
+	Payload        string     `json:"payload,omitempty"`
+	Status         string     `json:"status,omitempty"`
+	Attempt        int        `json:"attempt"`
+	ResponseStatus int        `json:"response_status,omitempty"`
+	Error          string     `json:"error,omitempty"`
+	NextRetryAt    *time.Time `json:"next_retry_at,omitempty"`
+	CreatedAt      *time.Time `json:"created_at,omitempty"`
+	UpdatedAt      *time.Time `json:"updated_at,omitempty"`
+}
+
+func (w *WebhookDelivery) TableName() string {
+	return "webhook_delivery"
+}
+
+func (w *WebhookDelivery) MapFields() ([]string, []any) {
+	return []string{
+			"id",
+			"webhook_id",
+			"event",
+			"payload",
+			"status",
+			"attempt",
+			"response_status",
+			"error",
+			"next_retry_at",
+			"created_at",
+			"updated_at",
+		}, []any{
+			&w.ID,
+			&w.WebhookID,
+			&w.Event,
+			&w.Payload,
+			&w.Status,
+			&w.Attempt,
+			&w.ResponseStatus,
+			&w.Error,
+			&w.NextRetryAt,
+			&w.CreatedAt,
+			&w.UpdatedAt,
+		}
+}
+
+// IncomingWebhook lets external services post into a conversation through a
+// secret token. Messages are sent by the bot user created with the webhook.
+type IncomingWebhook struct {
+	ID             string     `json:"id,omitempty"`
+	ConversationID string     `json:"conversation_id,omitempty"`
+	BotID          string     `json:"bot_id,omitempty"`
+	CreatedBy      string     `json:"created_by,omitempty"`
+	Name           string     `json:"name,omitempty"`
+	Prefix         string     `json:"prefix,omitempty"`
+	TokenHash      string     `json:"-"`
+	CreatedAt      *time.Time `json:"created_at,omitempty"`
+	LastUsedAt     *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+}
+
+func (i *IncomingWebhook) TableName() string {
+	return "incoming_webhook"
+}
+
+func (i *IncomingWebhook) MapFields() ([]string, []any) {
+	return []string{
+			"id",
+			"conversation_id",
+			"bot_id",
+			"created_by",
+			"name",
+			"prefix",
+			"token_hash",
+			"created_at",
+			"last_used_at",
+			"revoked_at",
+		}, []any{
+			&i.ID,
+			&i.ConversationID,
+			&i.BotID,
+			&i.CreatedBy,
+			&i.Name,
+			&i.Prefix,
+			&i.TokenHash,
+			&i.CreatedAt,
+			&i.LastUsedAt,
+			&i.RevokedAt,
+		}
+}
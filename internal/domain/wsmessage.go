@@ -5,12 +5,28 @@ import "encoding/json"
 type WebSocketMessageType string
 
 const (
-	WsAuthorization     = "AUTHORIZATION"
-	WsMessage           = "MESSAGE"
-	WsPing              = "PING"
-	WsPong              = "PONG"
-	WsUpdateLastMessage = "UPDATE_LAST_MESSAGE"
-	WsSeenMessage       = "SEEN_MESSAGE"
+	WsAuthorization               = "AUTHORIZATION"
+	WsMessage                     = "MESSAGE"
+	WsPing                        = "PING"
+	WsPong                        = "PONG"
+	WsUpdateLastMessage           = "UPDATE_LAST_MESSAGE"
+	WsSeenMessage                 = "SEEN_MESSAGE"
+	WsDataExportReady             = "DATA_EXPORT_READY"
+	WsMemberAdded                 = "MEMBER_ADDED"
+	WsEphemeralMessage            = "EPHEMERAL_MESSAGE"
+	WsPollUpdated                 = "POLL_UPDATED"
+	WsLocationUpdated             = "LOCATION_UPDATED"
+	WsThreadUpdated               = "THREAD_UPDATED"
+	WsPinUpdated                  = "PIN_UPDATED"
+	WsScheduledFailed             = "SCHEDULED_MESSAGE_FAILED"
+	WsMessageExpired              = "MESSAGE_EXPIRED"
+	WsUnreadCountUpdated          = "UNREAD_COUNT_UPDATED"
+	WsMessageDelivered            = "MESSAGE_DELIVERED"
+	WsReadMarkerUpdated           = "READ_MARKER_UPDATED"
+	WsConversationSettingsUpdated = "CONVERSATION_SETTINGS_UPDATED"
+	WsConversationUpdated         = "CONVERSATION_UPDATED"
+	WsMessageUpdated              = "MESSAGE_UPDATED"
+	WsMemberRemoved               = "MEMBER_REMOVED"
 )
 
 // WebSocketMessage represents a message sent over a WebSocket connection.
@@ -0,0 +1,277 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/internal/presenter"
+	"github.com/chat-socio/backend/internal/usecase"
+	"github.com/chat-socio/backend/pkg/observability"
+	"github.com/cloudwego/hertz/pkg/app"
+)
+
+type BotHandler struct {
+	BotUseCase usecase.BotUseCase
+	Obs        *observability.Observability
+}
+
+func (bh *BotHandler) CreateBot(ctx context.Context, c *app.RequestContext) {
+	ctx, span := bh.Obs.StartSpan(ctx, "BotHandler.CreateBot")
+	defer span()
+
+	var request presenter.CreateBotRequest
+	if err := c.Bind(&request); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Invalid request: %v", err),
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Validation error: %v", err),
+		})
+		return
+	}
+
+	response, err := bh.BotUseCase.CreateBot(ctx, &request)
+	if err != nil {
+		writeBotError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[*presenter.BotResponse]{
+		Message: "Bot created successfully",
+		Data:    response,
+	})
+}
+
+func (bh *BotHandler) GetListBot(ctx context.Context, c *app.RequestContext) {
+	ctx, span := bh.Obs.StartSpan(ctx, "BotHandler.GetListBot")
+	defer span()
+
+	response, err := bh.BotUseCase.GetListBot(ctx)
+	if err != nil {
+		writeBotError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[[]*presenter.BotResponse]{
+		Message: "Bot list retrieved successfully",
+		Data:    response,
+	})
+}
+
+func (bh *BotHandler) DeleteBot(ctx context.Context, c *app.RequestContext) {
+	ctx, span := bh.Obs.StartSpan(ctx, "BotHandler.DeleteBot")
+	defer span()
+
+	botID := c.Query("bot_id")
+	if botID == "" {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{Message: "bot_id is required"})
+		return
+	}
+
+	err := bh.BotUseCase.DeleteBot(ctx, botID)
+	if err != nil {
+		writeBotError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[any]{
+		Message: "Bot deleted successfully",
+	})
+}
+
+func (bh *BotHandler) CreateApiKey(ctx context.Context, c *app.RequestContext) {
+	ctx, span := bh.Obs.StartSpan(ctx, "BotHandler.CreateApiKey")
+	defer span()
+
+	var request presenter.CreateApiKeyRequest
+	if err := c.Bind(&request); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Invalid request: %v", err),
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Validation error: %v", err),
+		})
+		return
+	}
+
+	response, err := bh.BotUseCase.CreateApiKey(ctx, &request)
+	if err != nil {
+		writeBotError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[*presenter.ApiKeyResponse]{
+		Message: "Api key created successfully, store it now as it will not be shown again",
+		Data:    response,
+	})
+}
+
+func (bh *BotHandler) GetListApiKey(ctx context.Context, c *app.RequestContext) {
+	ctx, span := bh.Obs.StartSpan(ctx, "BotHandler.GetListApiKey")
+	defer span()
+
+	botID := c.Query("bot_id")
+	if botID == "" {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{Message: "bot_id is required"})
+		return
+	}
+
+	response, err := bh.BotUseCase.GetListApiKey(ctx, botID)
+	if err != nil {
+		writeBotError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[[]*presenter.ApiKeyResponse]{
+		Message: "Api key list retrieved successfully",
+		Data:    response,
+	})
+}
+
+func (bh *BotHandler) RevokeApiKey(ctx context.Context, c *app.RequestContext) {
+	ctx, span := bh.Obs.StartSpan(ctx, "BotHandler.RevokeApiKey")
+	defer span()
+
+	apiKeyID := c.Query("id")
+	if apiKeyID == "" {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{Message: "id is required"})
+		return
+	}
+
+	err := bh.BotUseCase.RevokeApiKey(ctx, apiKeyID)
+	if err != nil {
+		writeBotError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[any]{
+		Message: "Api key revoked successfully",
+	})
+}
+
+func (bh *BotHandler) AddBotToConversation(ctx context.Context, c *app.RequestContext) {
+	ctx, span := bh.Obs.StartSpan(ctx, "BotHandler.AddBotToConversation")
+	defer span()
+
+	var request presenter.AddBotToConversationRequest
+	if err := c.Bind(&request); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Invalid request: %v", err),
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Validation error: %v", err),
+		})
+		return
+	}
+
+	err := bh.BotUseCase.AddBotToConversation(ctx, &request)
+	if err != nil {
+		writeBotError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[any]{
+		Message: "Bot added to conversation successfully",
+	})
+}
+
+func (bh *BotHandler) CreateBotCommand(ctx context.Context, c *app.RequestContext) {
+	ctx, span := bh.Obs.StartSpan(ctx, "BotHandler.CreateBotCommand")
+	defer span()
+
+	var request presenter.CreateBotCommandRequest
+	if err := c.Bind(&request); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Invalid request: %v", err),
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Validation error: %v", err),
+		})
+		return
+	}
+
+	response, err := bh.BotUseCase.CreateBotCommand(ctx, &request)
+	if err != nil {
+		writeBotError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[*presenter.BotCommandResponse]{
+		Message: "Bot command registered successfully",
+		Data:    response,
+	})
+}
+
+func (bh *BotHandler) GetListBotCommand(ctx context.Context, c *app.RequestContext) {
+	ctx, span := bh.Obs.StartSpan(ctx, "BotHandler.GetListBotCommand")
+	defer span()
+
+	botID := c.Query("bot_id")
+	if botID == "" {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{Message: "bot_id is required"})
+		return
+	}
+
+	response, err := bh.BotUseCase.GetListBotCommand(ctx, botID)
+	if err != nil {
+		writeBotError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[[]*presenter.BotCommandResponse]{
+		Message: "Bot command list retrieved successfully",
+		Data:    response,
+	})
+}
+
+func (bh *BotHandler) DeleteBotCommand(ctx context.Context, c *app.RequestContext) {
+	ctx, span := bh.Obs.StartSpan(ctx, "BotHandler.DeleteBotCommand")
+	defer span()
+
+	botCommandID := c.Query("id")
+	if botCommandID == "" {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{Message: "id is required"})
+		return
+	}
+
+	err := bh.BotUseCase.DeleteBotCommand(ctx, botCommandID)
+	if err != nil {
+		writeBotError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[any]{
+		Message: "Bot command deleted successfully",
+	})
+}
+
+func writeBotError(c *app.RequestContext, err error) {
+	switch err {
+	case usecase.ErrNotFoundAccount, usecase.ErrNotFoundBot, usecase.ErrNotFoundApiKey, usecase.ErrNotFoundBotCommand:
+		c.JSON(http.StatusNotFound, presenter.BaseResponse[any]{Message: err.Error()})
+	case domain.ErrNotFoundMemberOfConversation:
+		c.JSON(http.StatusForbidden, presenter.BaseResponse[any]{Message: err.Error()})
+	case usecase.ErrNotGroupConversation:
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{Message: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, presenter.BaseResponse[any]{Message: fmt.Sprintf("Internal server error: %v", err)})
+	}
+}
@@ -2,8 +2,12 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/chat-socio/backend/internal/domain"
 	"github.com/chat-socio/backend/internal/presenter"
@@ -49,6 +53,7 @@ func (ch *ConversationHandler) SendMessage(ctx context.Context, c *app.RequestCo
 	}
 
 	request.UserID = userID
+	request.IsBot = ctx.Value(utils.ApiKeyIDKey) != nil
 
 	if err := request.Validate(); err != nil {
 		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
@@ -58,6 +63,18 @@ func (ch *ConversationHandler) SendMessage(ctx context.Context, c *app.RequestCo
 	}
 
 	sendMessageResponse, err := ch.ConversationUseCase.SendMessage(ctx, &request)
+	if err == domain.ErrNotFoundMemberOfConversation {
+		c.JSON(http.StatusForbidden, presenter.BaseResponse[any]{
+			Message: err.Error(),
+		})
+		return
+	}
+	if err == usecase.ErrUnknownCommand || errors.Is(err, usecase.ErrInvalidCommandArgs) || errors.Is(err, usecase.ErrInvalidPoll) || errors.Is(err, usecase.ErrInvalidLocation) || errors.Is(err, usecase.ErrInvalidContact) || errors.Is(err, usecase.ErrInvalidSticker) || errors.Is(err, usecase.ErrInvalidReplyTo) {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: err.Error(),
+		})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, presenter.BaseResponse[any]{
 			Message: err.Error(),
@@ -90,6 +107,18 @@ func (ch *ConversationHandler) CreateConversation(ctx context.Context, c *app.Re
 		return
 	}
 
+	// the creator becomes the owner of the conversation
+	if accountID, ok := ctx.Value(utils.AccountIDKey).(string); ok {
+		userID, err := ch.UserUseCase.GetUserIDByAccountID(ctx, accountID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, presenter.BaseResponse[*presenter.ConversationResponse]{
+				Message: err.Error(),
+			})
+			return
+		}
+		request.CreatorID = userID
+	}
+
 	createConversationResponse, err := ch.ConversationUseCase.CreateConversation(ctx, &request)
 	if err != nil && err != domain.ErrConversationAlreadyExist {
 		c.JSON(http.StatusInternalServerError, presenter.BaseResponse[*presenter.ConversationResponse]{
@@ -146,7 +175,9 @@ func (ch *ConversationHandler) GetListConversation(ctx context.Context, c *app.R
 		limit = 20
 	}
 
-	listConversation, err := ch.ConversationUseCase.GetListConversationByUserID(ctx, userID, lastMessageID, limit)
+	archived, _ := strconv.ParseBool(c.Query("archived"))
+
+	listConversation, err := ch.ConversationUseCase.GetListConversationByUserID(ctx, userID, lastMessageID, limit, archived)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, presenter.BaseResponse[[]*presenter.GetListConversationResponse]{
 			Message: err.Error(),
@@ -187,6 +218,10 @@ func (ch *ConversationHandler) GetListMessage(ctx context.Context, c *app.Reques
 		})
 		return
 	}
+	if c.Query("around_message_id") != "" || c.Query("after_id") != "" {
+		ch.getMessagePage(ctx, c, userID, conversationID)
+		return
+	}
 	lastMessageID := c.Query("last_message_id")
 	limit, err := strconv.Atoi(c.Query("limit"))
 	if err != nil {
@@ -207,6 +242,126 @@ func (ch *ConversationHandler) GetListMessage(ctx context.Context, c *app.Reques
 	})
 }
 
+// getMessagePage answers GetListMessage when the page is opened around a
+// message or read forward, the response tells whether more messages are left
+// on each side.
+func (ch *ConversationHandler) getMessagePage(ctx context.Context, c *app.RequestContext, userID string, conversationID string) {
+	request := presenter.GetMessagePageRequest{
+		ConversationID:  conversationID,
+		AroundMessageID: c.Query("around_message_id"),
+		AfterID:         c.Query("after_id"),
+	}
+	request.Limit, _ = strconv.Atoi(c.Query("limit"))
+	if err := request.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Validation error: %v", err),
+		})
+		return
+	}
+
+	page, err := ch.ConversationUseCase.GetMessagePage(ctx, userID, &request)
+	switch {
+	case errors.Is(err, usecase.ErrNotFoundMessage):
+		c.JSON(http.StatusNotFound, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	case errors.Is(err, domain.ErrNotFoundMemberOfConversation):
+		c.JSON(http.StatusForbidden, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[*presenter.MessagePageResponse]{
+		Data:    page,
+		Message: "List message fetched successfully",
+	})
+}
+
+func (ch *ConversationHandler) ForwardMessage(ctx context.Context, c *app.RequestContext) {
+	ctx, span := ch.Obs.StartSpan(ctx, "ConversationHandler.ForwardMessage")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, ch.UserUseCase)
+	if !ok {
+		return
+	}
+
+	var request presenter.ForwardMessageRequest
+	if err := c.Bind(&request); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Invalid request: %v", err),
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Validation error: %v", err),
+		})
+		return
+	}
+	request.UserID = userID
+
+	messages, err := ch.ConversationUseCase.ForwardMessages(ctx, &request)
+	switch {
+	case errors.Is(err, usecase.ErrInvalidForward), errors.Is(err, usecase.ErrInvalidPoll), errors.Is(err, usecase.ErrInvalidLocation), errors.Is(err, usecase.ErrInvalidContact), errors.Is(err, usecase.ErrInvalidSticker):
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	case errors.Is(err, domain.ErrNotFoundMemberOfConversation):
+		c.JSON(http.StatusForbidden, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[[]*presenter.MessageResponse]{
+		Data:    messages,
+		Message: "Messages forwarded successfully",
+	})
+}
+
+// GetMessageThread lists the replies of the thread of root_id after last_id,
+// oldest first.
+func (ch *ConversationHandler) GetMessageThread(ctx context.Context, c *app.RequestContext) {
+	ctx, span := ch.Obs.StartSpan(ctx, "ConversationHandler.GetMessageThread")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, ch.UserUseCase)
+	if !ok {
+		return
+	}
+
+	rootID := c.Query("root_id")
+	if rootID == "" {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{Message: "root_id is required"})
+		return
+	}
+	limit, err := strconv.Atoi(c.Query("limit"))
+	if err != nil {
+		limit = 20
+	}
+
+	thread, err := ch.ConversationUseCase.GetMessageThread(ctx, userID, rootID, c.Query("last_id"), limit)
+	switch {
+	case errors.Is(err, usecase.ErrNotFoundThread):
+		c.JSON(http.StatusNotFound, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	case errors.Is(err, domain.ErrNotFoundMemberOfConversation):
+		c.JSON(http.StatusForbidden, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[*presenter.MessageThreadResponse]{
+		Data:    thread,
+		Message: "Thread fetched successfully",
+	})
+}
+
 func (ch *ConversationHandler) GetConversationByID(ctx context.Context, c *app.RequestContext) {
 	ctx, span := ch.Obs.StartSpan(ctx, "ConversationHandler.GetConversationByID")
 	defer span()
@@ -239,6 +394,13 @@ func (ch *ConversationHandler) SeenMessage(ctx context.Context, c *app.RequestCo
 		return
 	}
 
+	if err := request.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: err.Error(),
+		})
+		return
+	}
+
 	accountID := ctx.Value(utils.AccountIDKey)
 	if accountID == nil {
 		c.JSON(http.StatusUnauthorized, presenter.BaseResponse[any]{
@@ -267,3 +429,569 @@ func (ch *ConversationHandler) SeenMessage(ctx context.Context, c *app.RequestCo
 		Message: "Seen message successfully",
 	})
 }
+
+// UpdateMessageTTL sets how long new messages of the conversation live.
+func (ch *ConversationHandler) UpdateMessageTTL(ctx context.Context, c *app.RequestContext) {
+	ctx, span := ch.Obs.StartSpan(ctx, "ConversationHandler.UpdateMessageTTL")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, ch.UserUseCase)
+	if !ok {
+		return
+	}
+
+	var request presenter.UpdateMessageTTLRequest
+	if err := c.Bind(&request); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Invalid request: %v", err),
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Validation error: %v", err),
+		})
+		return
+	}
+
+	conversation, err := ch.ConversationUseCase.UpdateMessageTTL(ctx, userID, &request)
+	switch {
+	case errors.Is(err, domain.ErrNotFoundMemberOfConversation), errors.Is(err, domain.ErrNotAdminOfConversation):
+		c.JSON(http.StatusForbidden, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[*presenter.ConversationResponse]{
+		Data:    conversation,
+		Message: "Message ttl updated successfully",
+	})
+}
+
+// SearchMessages searches the messages of the conversations of the user, the
+// next page is requested with the returned cursor.
+func (ch *ConversationHandler) SearchMessages(ctx context.Context, c *app.RequestContext) {
+	ctx, span := ch.Obs.StartSpan(ctx, "ConversationHandler.SearchMessages")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, ch.UserUseCase)
+	if !ok {
+		return
+	}
+
+	request := presenter.SearchMessageRequest{
+		Query:          strings.TrimSpace(c.Query("q")),
+		ConversationID: c.Query("conversation_id"),
+		FromUserID:     c.Query("from_user"),
+		Type:           c.Query("type"),
+		Cursor:         c.Query("cursor"),
+		UserID:         userID,
+	}
+	request.Limit, _ = strconv.Atoi(c.Query("limit"))
+	var err error
+	request.Before, err = parseTimeQuery(c, "before")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Invalid request: %v", err),
+		})
+		return
+	}
+	request.After, err = parseTimeQuery(c, "after")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Invalid request: %v", err),
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Validation error: %v", err),
+		})
+		return
+	}
+
+	result, err := ch.ConversationUseCase.SearchMessages(ctx, &request)
+	switch {
+	case errors.Is(err, usecase.ErrInvalidSearchCursor):
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	case errors.Is(err, domain.ErrNotFoundMemberOfConversation):
+		c.JSON(http.StatusForbidden, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[*presenter.SearchMessageResponse]{
+		Data:    result,
+		Message: "Messages searched successfully",
+	})
+}
+
+// parseTimeQuery reads an optional RFC 3339 time from the query string.
+func parseTimeQuery(c *app.RequestContext, name string) (*time.Time, error) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be an RFC 3339 time", name)
+	}
+	return &t, nil
+}
+
+// GetTotalUnreadCount returns the number of unread messages of the user in
+// all conversations.
+func (ch *ConversationHandler) GetTotalUnreadCount(ctx context.Context, c *app.RequestContext) {
+	ctx, span := ch.Obs.StartSpan(ctx, "ConversationHandler.GetTotalUnreadCount")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, ch.UserUseCase)
+	if !ok {
+		return
+	}
+
+	unreadCount, err := ch.ConversationUseCase.GetTotalUnreadCount(ctx, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[*presenter.UnreadCountResponse]{
+		Data:    unreadCount,
+		Message: "Unread count fetched successfully",
+	})
+}
+
+// AckDeliveredMessages records that messages received from push notifications
+// reached the device of the user.
+func (ch *ConversationHandler) AckDeliveredMessages(ctx context.Context, c *app.RequestContext) {
+	ctx, span := ch.Obs.StartSpan(ctx, "ConversationHandler.AckDeliveredMessages")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, ch.UserUseCase)
+	if !ok {
+		return
+	}
+
+	var request presenter.DeliveredMessageRequest
+	if err := c.Bind(&request); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Invalid request: %v", err),
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Validation error: %v", err),
+		})
+		return
+	}
+
+	err := ch.ConversationUseCase.AckDeliveredMessages(ctx, userID, &request)
+	switch {
+	case errors.Is(err, domain.ErrNotFoundMemberOfConversation):
+		c.JSON(http.StatusForbidden, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[any]{
+		Message: "Messages acknowledged successfully",
+	})
+}
+
+// GetMessageStatus returns the delivery and seen status of a message for
+// each recipient, only to its sender.
+func (ch *ConversationHandler) GetMessageStatus(ctx context.Context, c *app.RequestContext) {
+	ctx, span := ch.Obs.StartSpan(ctx, "ConversationHandler.GetMessageStatus")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, ch.UserUseCase)
+	if !ok {
+		return
+	}
+
+	messageID := c.Query("message_id")
+	if messageID == "" {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{Message: "message_id is required"})
+		return
+	}
+
+	status, err := ch.ConversationUseCase.GetMessageStatus(ctx, userID, messageID)
+	switch {
+	case errors.Is(err, usecase.ErrNotFoundMessage):
+		c.JSON(http.StatusNotFound, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	case errors.Is(err, usecase.ErrNotMessageSender):
+		c.JSON(http.StatusForbidden, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[*presenter.MessageStatusResponse]{
+		Data:    status,
+		Message: "Message status fetched successfully",
+	})
+}
+
+// GetListSeenMessage lists the last seen message of every member of the
+// conversation.
+func (ch *ConversationHandler) GetListSeenMessage(ctx context.Context, c *app.RequestContext) {
+	ctx, span := ch.Obs.StartSpan(ctx, "ConversationHandler.GetListSeenMessage")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, ch.UserUseCase)
+	if !ok {
+		return
+	}
+
+	conversationID := c.Query("conversation_id")
+	if conversationID == "" {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{Message: "conversation_id is required"})
+		return
+	}
+
+	seenMessages, err := ch.ConversationUseCase.GetListSeenMessageByConversationID(ctx, userID, conversationID)
+	switch {
+	case errors.Is(err, domain.ErrNotFoundMemberOfConversation):
+		c.JSON(http.StatusForbidden, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[[]*presenter.SeenMessageResponse]{
+		Data:    seenMessages,
+		Message: "List seen message fetched successfully",
+	})
+}
+
+// GetListSeenBy lists the members who have seen message_id.
+func (ch *ConversationHandler) GetListSeenBy(ctx context.Context, c *app.RequestContext) {
+	ctx, span := ch.Obs.StartSpan(ctx, "ConversationHandler.GetListSeenBy")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, ch.UserUseCase)
+	if !ok {
+		return
+	}
+
+	messageID := c.Query("message_id")
+	if messageID == "" {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{Message: "message_id is required"})
+		return
+	}
+
+	seenBy, err := ch.ConversationUseCase.GetListSeenByMessageID(ctx, userID, messageID)
+	switch {
+	case errors.Is(err, usecase.ErrNotFoundMessage):
+		c.JSON(http.StatusNotFound, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	case errors.Is(err, domain.ErrNotFoundMemberOfConversation):
+		c.JSON(http.StatusForbidden, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[[]*presenter.SeenMessageResponse]{
+		Data:    seenBy,
+		Message: "Seen by fetched successfully",
+	})
+}
+
+// MarkUnread marks the conversation unread from message_id, or its last
+// message when message_id is empty.
+func (ch *ConversationHandler) MarkUnread(ctx context.Context, c *app.RequestContext) {
+	ctx, span := ch.Obs.StartSpan(ctx, "ConversationHandler.MarkUnread")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, ch.UserUseCase)
+	if !ok {
+		return
+	}
+
+	var request presenter.MarkUnreadRequest
+	if err := c.Bind(&request); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Invalid request: %v", err),
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Validation error: %v", err),
+		})
+		return
+	}
+
+	err := ch.ConversationUseCase.MarkUnread(ctx, userID, &request)
+	switch {
+	case errors.Is(err, usecase.ErrNotFoundMessage):
+		c.JSON(http.StatusNotFound, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	case errors.Is(err, domain.ErrNotFoundMemberOfConversation):
+		c.JSON(http.StatusForbidden, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[any]{
+		Message: "Conversation marked unread successfully",
+	})
+}
+
+// MarkAllRead marks every conversation of the user read.
+func (ch *ConversationHandler) MarkAllRead(ctx context.Context, c *app.RequestContext) {
+	ctx, span := ch.Obs.StartSpan(ctx, "ConversationHandler.MarkAllRead")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, ch.UserUseCase)
+	if !ok {
+		return
+	}
+
+	response, err := ch.ConversationUseCase.MarkAllRead(ctx, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[*presenter.MarkAllReadResponse]{
+		Data:    response,
+		Message: "Conversations marked read successfully",
+	})
+}
+
+// UpdateConversationSettings changes the mute, archive and pin settings of a
+// conversation for the current user.
+func (ch *ConversationHandler) UpdateConversationSettings(ctx context.Context, c *app.RequestContext) {
+	ctx, span := ch.Obs.StartSpan(ctx, "ConversationHandler.UpdateConversationSettings")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, ch.UserUseCase)
+	if !ok {
+		return
+	}
+
+	var request presenter.UpdateConversationSettingsRequest
+	if err := c.Bind(&request); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Invalid request: %v", err),
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Validation error: %v", err),
+		})
+		return
+	}
+
+	settings, err := ch.ConversationUseCase.UpdateConversationSettings(ctx, userID, &request)
+	switch {
+	case errors.Is(err, domain.ErrNotFoundMemberOfConversation):
+		c.JSON(http.StatusForbidden, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	case errors.Is(err, usecase.ErrInvalidMuteDuration), errors.Is(err, usecase.ErrTooManyPinnedConversations):
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[*presenter.ConversationSettingsResponse]{
+		Data:    settings,
+		Message: "Conversation settings updated successfully",
+	})
+}
+
+// ReorderPinnedConversations changes the order of the pinned conversations
+// of the current user.
+func (ch *ConversationHandler) ReorderPinnedConversations(ctx context.Context, c *app.RequestContext) {
+	ctx, span := ch.Obs.StartSpan(ctx, "ConversationHandler.ReorderPinnedConversations")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, ch.UserUseCase)
+	if !ok {
+		return
+	}
+
+	var request presenter.ReorderPinnedConversationRequest
+	if err := c.Bind(&request); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Invalid request: %v", err),
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Validation error: %v", err),
+		})
+		return
+	}
+
+	response, err := ch.ConversationUseCase.ReorderPinnedConversations(ctx, userID, &request)
+	switch {
+	case errors.Is(err, usecase.ErrInvalidPinnedConversationIDs):
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[*presenter.PinnedConversationResponse]{
+		Data:    response,
+		Message: "Pinned conversations reordered successfully",
+	})
+}
+
+// UpdateConversation changes the title and avatar of a group.
+func (ch *ConversationHandler) UpdateConversation(ctx context.Context, c *app.RequestContext) {
+	ctx, span := ch.Obs.StartSpan(ctx, "ConversationHandler.UpdateConversation")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, ch.UserUseCase)
+	if !ok {
+		return
+	}
+
+	var request presenter.UpdateConversationRequest
+	if err := c.Bind(&request); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Invalid request: %v", err),
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Validation error: %v", err),
+		})
+		return
+	}
+
+	conversation, err := ch.ConversationUseCase.UpdateConversation(ctx, userID, &request)
+	switch {
+	case errors.Is(err, domain.ErrNotFoundMemberOfConversation), errors.Is(err, domain.ErrNotAdminOfConversation):
+		c.JSON(http.StatusForbidden, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	case errors.Is(err, usecase.ErrNotGroupConversation), errors.Is(err, usecase.ErrInvalidAvatarURL):
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[*presenter.ConversationResponse]{
+		Data:    conversation,
+		Message: "Conversation updated successfully",
+	})
+}
+
+func (ch *ConversationHandler) EditMessage(ctx context.Context, c *app.RequestContext) {
+	ctx, span := ch.Obs.StartSpan(ctx, "ConversationHandler.EditMessage")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, ch.UserUseCase)
+	if !ok {
+		return
+	}
+
+	var request presenter.EditMessageRequest
+	if err := c.Bind(&request); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Invalid request: %v", err),
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Validation error: %v", err),
+		})
+		return
+	}
+
+	message, err := ch.ConversationUseCase.EditMessage(ctx, userID, &request)
+	switch {
+	case errors.Is(err, usecase.ErrNotFoundMessage):
+		c.JSON(http.StatusNotFound, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	case errors.Is(err, usecase.ErrMessageNotEditable):
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	case errors.Is(err, domain.ErrNotFoundMemberOfConversation):
+		c.JSON(http.StatusForbidden, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[*presenter.MessageResponse]{
+		Data:    message,
+		Message: "Message updated successfully",
+	})
+}
+
+// RemoveConversationMember removes user_id from the group, members remove
+// themselves to leave it.
+func (ch *ConversationHandler) RemoveConversationMember(ctx context.Context, c *app.RequestContext) {
+	ctx, span := ch.Obs.StartSpan(ctx, "ConversationHandler.RemoveConversationMember")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, ch.UserUseCase)
+	if !ok {
+		return
+	}
+
+	conversationID := c.Query("conversation_id")
+	memberID := c.Query("user_id")
+	if conversationID == "" || memberID == "" {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{Message: "conversation_id and user_id are required"})
+		return
+	}
+
+	err := ch.ConversationUseCase.RemoveConversationMember(ctx, userID, conversationID, memberID)
+	switch {
+	case errors.Is(err, usecase.ErrNotFoundMember):
+		c.JSON(http.StatusNotFound, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	case errors.Is(err, domain.ErrNotFoundMemberOfConversation), errors.Is(err, domain.ErrNotAdminOfConversation), errors.Is(err, usecase.ErrCanNotRemoveMember):
+		c.JSON(http.StatusForbidden, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	case errors.Is(err, usecase.ErrNotGroupConversation), errors.Is(err, usecase.ErrOwnerCanNotLeave):
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, presenter.BaseResponse[any]{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[any]{
+		Message: "Member removed successfully",
+	})
+}
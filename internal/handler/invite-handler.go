@@ -0,0 +1,258 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/internal/presenter"
+	"github.com/chat-socio/backend/internal/usecase"
+	"github.com/chat-socio/backend/pkg/observability"
+	"github.com/cloudwego/hertz/pkg/app"
+)
+
+type InviteHandler struct {
+	InviteUseCase usecase.InviteUseCase
+	UserUseCase   usecase.UserUseCase
+	Obs           *observability.Observability
+}
+
+func (ih *InviteHandler) CreateInvite(ctx context.Context, c *app.RequestContext) {
+	ctx, span := ih.Obs.StartSpan(ctx, "InviteHandler.CreateInvite")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, ih.UserUseCase)
+	if !ok {
+		return
+	}
+
+	var request presenter.CreateInviteRequest
+	if err := c.Bind(&request); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Invalid request: %v", err),
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Validation error: %v", err),
+		})
+		return
+	}
+
+	response, err := ih.InviteUseCase.CreateInvite(ctx, userID, &request)
+	if err != nil {
+		writeInviteError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[*presenter.InviteResponse]{
+		Message: "Invite created successfully",
+		Data:    response,
+	})
+}
+
+func (ih *InviteHandler) GetListInvite(ctx context.Context, c *app.RequestContext) {
+	ctx, span := ih.Obs.StartSpan(ctx, "InviteHandler.GetListInvite")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, ih.UserUseCase)
+	if !ok {
+		return
+	}
+
+	conversationID := c.Query("conversation_id")
+	if conversationID == "" {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{Message: "conversation_id is required"})
+		return
+	}
+
+	response, err := ih.InviteUseCase.GetListInvite(ctx, userID, conversationID)
+	if err != nil {
+		writeInviteError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[[]*presenter.InviteResponse]{
+		Message: "List invite fetched successfully",
+		Data:    response,
+	})
+}
+
+func (ih *InviteHandler) RevokeInvite(ctx context.Context, c *app.RequestContext) {
+	ctx, span := ih.Obs.StartSpan(ctx, "InviteHandler.RevokeInvite")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, ih.UserUseCase)
+	if !ok {
+		return
+	}
+
+	inviteID := c.Query("id")
+	if inviteID == "" {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{Message: "id is required"})
+		return
+	}
+
+	err := ih.InviteUseCase.RevokeInvite(ctx, userID, inviteID)
+	if err != nil {
+		writeInviteError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[any]{
+		Message: "Invite revoked successfully",
+	})
+}
+
+// GetInvitePreview shows the group behind an invite code to users who are
+// not members yet.
+func (ih *InviteHandler) GetInvitePreview(ctx context.Context, c *app.RequestContext) {
+	ctx, span := ih.Obs.StartSpan(ctx, "InviteHandler.GetInvitePreview")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, ih.UserUseCase)
+	if !ok {
+		return
+	}
+
+	code := c.Query("code")
+	if code == "" {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{Message: "code is required"})
+		return
+	}
+
+	response, err := ih.InviteUseCase.GetInvitePreview(ctx, userID, code)
+	if err != nil {
+		writeInviteError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[*presenter.InvitePreviewResponse]{
+		Message: "Invite preview fetched successfully",
+		Data:    response,
+	})
+}
+
+func (ih *InviteHandler) JoinInvite(ctx context.Context, c *app.RequestContext) {
+	ctx, span := ih.Obs.StartSpan(ctx, "InviteHandler.JoinInvite")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, ih.UserUseCase)
+	if !ok {
+		return
+	}
+
+	var request presenter.JoinInviteRequest
+	if err := c.Bind(&request); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Invalid request: %v", err),
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Validation error: %v", err),
+		})
+		return
+	}
+
+	response, err := ih.InviteUseCase.JoinInvite(ctx, userID, &request)
+	if err != nil {
+		writeInviteError(c, err)
+		return
+	}
+
+	message := "Conversation joined successfully"
+	if response.Status == presenter.JoinStatusPending {
+		message = "Join request sent, waiting for approval"
+	}
+	c.JSON(http.StatusOK, presenter.BaseResponse[*presenter.JoinInviteResponse]{
+		Message: message,
+		Data:    response,
+	})
+}
+
+func (ih *InviteHandler) GetListJoinRequest(ctx context.Context, c *app.RequestContext) {
+	ctx, span := ih.Obs.StartSpan(ctx, "InviteHandler.GetListJoinRequest")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, ih.UserUseCase)
+	if !ok {
+		return
+	}
+
+	conversationID := c.Query("conversation_id")
+	if conversationID == "" {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{Message: "conversation_id is required"})
+		return
+	}
+
+	response, err := ih.InviteUseCase.GetListJoinRequest(ctx, userID, conversationID)
+	if err != nil {
+		writeInviteError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[[]*presenter.JoinRequestResponse]{
+		Message: "List join request fetched successfully",
+		Data:    response,
+	})
+}
+
+func (ih *InviteHandler) ReviewJoinRequest(ctx context.Context, c *app.RequestContext) {
+	ctx, span := ih.Obs.StartSpan(ctx, "InviteHandler.ReviewJoinRequest")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, ih.UserUseCase)
+	if !ok {
+		return
+	}
+
+	var request presenter.ReviewJoinRequestRequest
+	if err := c.Bind(&request); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Invalid request: %v", err),
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Validation error: %v", err),
+		})
+		return
+	}
+
+	response, err := ih.InviteUseCase.ReviewJoinRequest(ctx, userID, &request)
+	if err != nil {
+		writeInviteError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[*presenter.JoinRequestResponse]{
+		Message: "Join request reviewed successfully",
+		Data:    response,
+	})
+}
+
+func writeInviteError(c *app.RequestContext, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrNotFoundInvite), errors.Is(err, usecase.ErrNotFoundJoinRequest):
+		c.JSON(http.StatusNotFound, presenter.BaseResponse[any]{Message: err.Error()})
+	case errors.Is(err, usecase.ErrInviteExpired):
+		c.JSON(http.StatusGone, presenter.BaseResponse[any]{Message: err.Error()})
+	case errors.Is(err, domain.ErrNotFoundMemberOfConversation), errors.Is(err, domain.ErrNotAdminOfConversation):
+		c.JSON(http.StatusForbidden, presenter.BaseResponse[any]{Message: err.Error()})
+	case errors.Is(err, usecase.ErrNotGroupConversation):
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{Message: err.Error()})
+	case errors.Is(err, usecase.ErrJoinRequestReviewed):
+		c.JSON(http.StatusConflict, presenter.BaseResponse[any]{Message: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, presenter.BaseResponse[any]{Message: fmt.Sprintf("Internal server error: %v", err)})
+	}
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/internal/presenter"
+	"github.com/chat-socio/backend/internal/usecase"
+	"github.com/chat-socio/backend/internal/utils"
+	"github.com/chat-socio/backend/pkg/observability"
+	"github.com/cloudwego/hertz/pkg/app"
+)
+
+type LocationHandler struct {
+	LocationUseCase usecase.LocationUseCase
+	UserUseCase     usecase.UserUseCase
+	Obs             *observability.Observability
+}
+
+func (lh *LocationHandler) StartLiveLocation(ctx context.Context, c *app.RequestContext) {
+	ctx, span := lh.Obs.StartSpan(ctx, "LocationHandler.StartLiveLocation")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, lh.UserUseCase)
+	if !ok {
+		return
+	}
+
+	var request presenter.StartLiveLocationRequest
+	if err := c.Bind(&request); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Invalid request: %v", err),
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Validation error: %v", err),
+		})
+		return
+	}
+	request.UserID = userID
+	request.IsBot = ctx.Value(utils.ApiKeyIDKey) != nil
+
+	response, err := lh.LocationUseCase.StartLiveLocation(ctx, &request)
+	if err != nil {
+		writeLocationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[*presenter.MessageResponse]{
+		Message: "Live location started successfully",
+		Data:    response,
+	})
+}
+
+func (lh *LocationHandler) UpdateLiveLocation(ctx context.Context, c *app.RequestContext) {
+	ctx, span := lh.Obs.StartSpan(ctx, "LocationHandler.UpdateLiveLocation")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, lh.UserUseCase)
+	if !ok {
+		return
+	}
+
+	var request presenter.UpdateLiveLocationRequest
+	if err := c.Bind(&request); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Invalid request: %v", err),
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Validation error: %v", err),
+		})
+		return
+	}
+
+	response, err := lh.LocationUseCase.UpdateLiveLocation(ctx, userID, &request)
+	if err != nil {
+		writeLocationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[*presenter.LocationResponse]{
+		Message: "Live location updated successfully",
+		Data:    response,
+	})
+}
+
+func (lh *LocationHandler) StopLiveLocation(ctx context.Context, c *app.RequestContext) {
+	ctx, span := lh.Obs.StartSpan(ctx, "LocationHandler.StopLiveLocation")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, lh.UserUseCase)
+	if !ok {
+		return
+	}
+
+	messageID := c.Query("message_id")
+	if messageID == "" {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{Message: "message_id is required"})
+		return
+	}
+
+	response, err := lh.LocationUseCase.StopLiveLocation(ctx, userID, messageID)
+	if err != nil {
+		writeLocationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[*presenter.LocationResponse]{
+		Message: "Live location stopped successfully",
+		Data:    response,
+	})
+}
+
+func writeLocationError(c *app.RequestContext, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrNotFoundLiveLocation):
+		c.JSON(http.StatusNotFound, presenter.BaseResponse[any]{Message: err.Error()})
+	case errors.Is(err, domain.ErrNotFoundMemberOfConversation):
+		c.JSON(http.StatusForbidden, presenter.BaseResponse[any]{Message: err.Error()})
+	case errors.Is(err, usecase.ErrLiveLocationEnded):
+		c.JSON(http.StatusConflict, presenter.BaseResponse[any]{Message: err.Error()})
+	case errors.Is(err, usecase.ErrInvalidLocation):
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{Message: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, presenter.BaseResponse[any]{Message: fmt.Sprintf("Internal server error: %v", err)})
+	}
+}
@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/internal/presenter"
+	"github.com/chat-socio/backend/internal/usecase"
+	"github.com/chat-socio/backend/pkg/observability"
+	"github.com/cloudwego/hertz/pkg/app"
+)
+
+type PinHandler struct {
+	PinUseCase  usecase.PinUseCase
+	UserUseCase usecase.UserUseCase
+	Obs         *observability.Observability
+}
+
+func (ph *PinHandler) PinMessage(ctx context.Context, c *app.RequestContext) {
+	ctx, span := ph.Obs.StartSpan(ctx, "PinHandler.PinMessage")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, ph.UserUseCase)
+	if !ok {
+		return
+	}
+
+	var request presenter.PinMessageRequest
+	if err := c.Bind(&request); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Invalid request: %v", err),
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Validation error: %v", err),
+		})
+		return
+	}
+
+	response, err := ph.PinUseCase.PinMessage(ctx, userID, &request)
+	if err != nil {
+		writePinError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[*presenter.PinnedMessageResponse]{
+		Message: "Message pinned successfully",
+		Data:    response,
+	})
+}
+
+func (ph *PinHandler) UnpinMessage(ctx context.Context, c *app.RequestContext) {
+	ctx, span := ph.Obs.StartSpan(ctx, "PinHandler.UnpinMessage")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, ph.UserUseCase)
+	if !ok {
+		return
+	}
+
+	request := presenter.PinMessageRequest{
+		ConversationID: c.Query("conversation_id"),
+		MessageID:      c.Query("message_id"),
+	}
+	if err := request.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Validation error: %v", err),
+		})
+		return
+	}
+
+	err := ph.PinUseCase.UnpinMessage(ctx, userID, request.ConversationID, request.MessageID)
+	if err != nil {
+		writePinError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[any]{
+		Message: "Message unpinned successfully",
+	})
+}
+
+func (ph *PinHandler) GetListPinnedMessage(ctx context.Context, c *app.RequestContext) {
+	ctx, span := ph.Obs.StartSpan(ctx, "PinHandler.GetListPinnedMessage")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, ph.UserUseCase)
+	if !ok {
+		return
+	}
+
+	conversationID := c.Query("conversation_id")
+	if conversationID == "" {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{Message: "conversation_id is required"})
+		return
+	}
+
+	response, err := ph.PinUseCase.GetListPinnedMessage(ctx, userID, conversationID)
+	if err != nil {
+		writePinError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[[]*presenter.PinnedMessageResponse]{
+		Message: "List pinned message fetched successfully",
+		Data:    response,
+	})
+}
+
+func writePinError(c *app.RequestContext, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrNotFoundMessage), errors.Is(err, usecase.ErrNotPinned):
+		c.JSON(http.StatusNotFound, presenter.BaseResponse[any]{Message: err.Error()})
+	case errors.Is(err, domain.ErrNotFoundMemberOfConversation), errors.Is(err, domain.ErrNotAdminOfConversation):
+		c.JSON(http.StatusForbidden, presenter.BaseResponse[any]{Message: err.Error()})
+	case errors.Is(err, usecase.ErrTooManyPins):
+		c.JSON(http.StatusConflict, presenter.BaseResponse[any]{Message: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, presenter.BaseResponse[any]{Message: fmt.Sprintf("Internal server error: %v", err)})
+	}
+}
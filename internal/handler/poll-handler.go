@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/internal/presenter"
+	"github.com/chat-socio/backend/internal/usecase"
+	"github.com/chat-socio/backend/internal/utils"
+	"github.com/chat-socio/backend/pkg/observability"
+	"github.com/cloudwego/hertz/pkg/app"
+)
+
+type PollHandler struct {
+	PollUseCase usecase.PollUseCase
+	UserUseCase usecase.UserUseCase
+	Obs         *observability.Observability
+}
+
+func (ph *PollHandler) CreatePoll(ctx context.Context, c *app.RequestContext) {
+	ctx, span := ph.Obs.StartSpan(ctx, "PollHandler.CreatePoll")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, ph.UserUseCase)
+	if !ok {
+		return
+	}
+
+	var request presenter.CreatePollRequest
+	if err := c.Bind(&request); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Invalid request: %v", err),
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Validation error: %v", err),
+		})
+		return
+	}
+	request.UserID = userID
+	request.IsBot = ctx.Value(utils.ApiKeyIDKey) != nil
+
+	response, err := ph.PollUseCase.CreatePoll(ctx, &request)
+	if err != nil {
+		writePollError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[*presenter.MessageResponse]{
+		Message: "Poll created successfully",
+		Data:    response,
+	})
+}
+
+func (ph *PollHandler) VotePoll(ctx context.Context, c *app.RequestContext) {
+	ctx, span := ph.Obs.StartSpan(ctx, "PollHandler.VotePoll")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, ph.UserUseCase)
+	if !ok {
+		return
+	}
+
+	var request presenter.VotePollRequest
+	if err := c.Bind(&request); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Invalid request: %v", err),
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Validation error: %v", err),
+		})
+		return
+	}
+
+	response, err := ph.PollUseCase.VotePoll(ctx, userID, &request)
+	if err != nil {
+		writePollError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[*presenter.PollResponse]{
+		Message: "Vote recorded successfully",
+		Data:    response,
+	})
+}
+
+// UnvotePoll removes the vote on option_id, or every vote of the user when
+// option_id is not given.
+func (ph *PollHandler) UnvotePoll(ctx context.Context, c *app.RequestContext) {
+	ctx, span := ph.Obs.StartSpan(ctx, "PollHandler.UnvotePoll")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, ph.UserUseCase)
+	if !ok {
+		return
+	}
+
+	messageID := c.Query("message_id")
+	if messageID == "" {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{Message: "message_id is required"})
+		return
+	}
+
+	response, err := ph.PollUseCase.UnvotePoll(ctx, userID, messageID, c.Query("option_id"))
+	if err != nil {
+		writePollError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[*presenter.PollResponse]{
+		Message: "Vote removed successfully",
+		Data:    response,
+	})
+}
+
+func writePollError(c *app.RequestContext, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrNotFoundPoll):
+		c.JSON(http.StatusNotFound, presenter.BaseResponse[any]{Message: err.Error()})
+	case errors.Is(err, domain.ErrNotFoundMemberOfConversation):
+		c.JSON(http.StatusForbidden, presenter.BaseResponse[any]{Message: err.Error()})
+	case errors.Is(err, usecase.ErrPollClosed):
+		c.JSON(http.StatusConflict, presenter.BaseResponse[any]{Message: err.Error()})
+	case errors.Is(err, usecase.ErrInvalidPoll), errors.Is(err, usecase.ErrInvalidPollVote):
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{Message: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, presenter.BaseResponse[any]{Message: fmt.Sprintf("Internal server error: %v", err)})
+	}
+}
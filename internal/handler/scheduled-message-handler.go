@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/internal/presenter"
+	"github.com/chat-socio/backend/internal/usecase"
+	"github.com/chat-socio/backend/pkg/observability"
+	"github.com/cloudwego/hertz/pkg/app"
+)
+
+type ScheduledMessageHandler struct {
+	ScheduledMessageUseCase usecase.ScheduledMessageUseCase
+	UserUseCase             usecase.UserUseCase
+	Obs                     *observability.Observability
+}
+
+func (sh *ScheduledMessageHandler) CreateScheduledMessage(ctx context.Context, c *app.RequestContext) {
+	ctx, span := sh.Obs.StartSpan(ctx, "ScheduledMessageHandler.CreateScheduledMessage")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, sh.UserUseCase)
+	if !ok {
+		return
+	}
+
+	var request presenter.CreateScheduledMessageRequest
+	if err := c.Bind(&request); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Invalid request: %v", err),
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Validation error: %v", err),
+		})
+		return
+	}
+	request.UserID = userID
+
+	response, err := sh.ScheduledMessageUseCase.CreateScheduledMessage(ctx, &request)
+	if err != nil {
+		writeScheduledMessageError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[*presenter.ScheduledMessageResponse]{
+		Message: "Message scheduled successfully",
+		Data:    response,
+	})
+}
+
+// GetListScheduledMessage lists the pending messages of the user, optionally
+// of a single conversation.
+func (sh *ScheduledMessageHandler) GetListScheduledMessage(ctx context.Context, c *app.RequestContext) {
+	ctx, span := sh.Obs.StartSpan(ctx, "ScheduledMessageHandler.GetListScheduledMessage")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, sh.UserUseCase)
+	if !ok {
+		return
+	}
+
+	response, err := sh.ScheduledMessageUseCase.GetListScheduledMessage(ctx, userID, c.Query("conversation_id"))
+	if err != nil {
+		writeScheduledMessageError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[[]*presenter.ScheduledMessageResponse]{
+		Message: "List scheduled message fetched successfully",
+		Data:    response,
+	})
+}
+
+func (sh *ScheduledMessageHandler) CancelScheduledMessage(ctx context.Context, c *app.RequestContext) {
+	ctx, span := sh.Obs.StartSpan(ctx, "ScheduledMessageHandler.CancelScheduledMessage")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, sh.UserUseCase)
+	if !ok {
+		return
+	}
+
+	id := c.Query("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{Message: "id is required"})
+		return
+	}
+
+	err := sh.ScheduledMessageUseCase.CancelScheduledMessage(ctx, userID, id)
+	if err != nil {
+		writeScheduledMessageError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[any]{
+		Message: "Scheduled message cancelled successfully",
+	})
+}
+
+func writeScheduledMessageError(c *app.RequestContext, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrNotFoundScheduledMessage):
+		c.JSON(http.StatusNotFound, presenter.BaseResponse[any]{Message: err.Error()})
+	case errors.Is(err, domain.ErrNotFoundMemberOfConversation):
+		c.JSON(http.StatusForbidden, presenter.BaseResponse[any]{Message: err.Error()})
+	case errors.Is(err, usecase.ErrInvalidScheduledMessage):
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{Message: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, presenter.BaseResponse[any]{Message: fmt.Sprintf("Internal server error: %v", err)})
+	}
+}
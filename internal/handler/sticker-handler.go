@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/chat-socio/backend/internal/presenter"
+	"github.com/chat-socio/backend/internal/usecase"
+	"github.com/chat-socio/backend/pkg/observability"
+	"github.com/cloudwego/hertz/pkg/app"
+)
+
+type StickerHandler struct {
+	StickerUseCase usecase.StickerUseCase
+	UserUseCase    usecase.UserUseCase
+	Obs            *observability.Observability
+}
+
+// GetListSticker returns the packs the user can send stickers from, in the
+// user's order.
+func (sh *StickerHandler) GetListSticker(ctx context.Context, c *app.RequestContext) {
+	ctx, span := sh.Obs.StartSpan(ctx, "StickerHandler.GetListSticker")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, sh.UserUseCase)
+	if !ok {
+		return
+	}
+
+	response, err := sh.StickerUseCase.GetListInstalledStickerPack(ctx, userID)
+	if err != nil {
+		writeStickerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[[]*presenter.StickerPackResponse]{
+		Message: "Sticker list retrieved successfully",
+		Data:    response,
+	})
+}
+
+func (sh *StickerHandler) GetListStickerPack(ctx context.Context, c *app.RequestContext) {
+	ctx, span := sh.Obs.StartSpan(ctx, "StickerHandler.GetListStickerPack")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, sh.UserUseCase)
+	if !ok {
+		return
+	}
+
+	response, err := sh.StickerUseCase.GetListStickerPack(ctx, userID)
+	if err != nil {
+		writeStickerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[[]*presenter.StickerPackResponse]{
+		Message: "Sticker pack list retrieved successfully",
+		Data:    response,
+	})
+}
+
+func (sh *StickerHandler) InstallStickerPack(ctx context.Context, c *app.RequestContext) {
+	ctx, span := sh.Obs.StartSpan(ctx, "StickerHandler.InstallStickerPack")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, sh.UserUseCase)
+	if !ok {
+		return
+	}
+
+	var request presenter.InstallStickerPackRequest
+	if err := c.Bind(&request); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Invalid request: %v", err),
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Validation error: %v", err),
+		})
+		return
+	}
+
+	err := sh.StickerUseCase.InstallStickerPack(ctx, userID, request.PackID)
+	if err != nil {
+		writeStickerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[any]{
+		Message: "Sticker pack installed successfully",
+	})
+}
+
+func (sh *StickerHandler) UninstallStickerPack(ctx context.Context, c *app.RequestContext) {
+	ctx, span := sh.Obs.StartSpan(ctx, "StickerHandler.UninstallStickerPack")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, sh.UserUseCase)
+	if !ok {
+		return
+	}
+
+	packID := c.Query("pack_id")
+	if packID == "" {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{Message: "pack_id is required"})
+		return
+	}
+
+	err := sh.StickerUseCase.UninstallStickerPack(ctx, userID, packID)
+	if err != nil {
+		writeStickerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[any]{
+		Message: "Sticker pack uninstalled successfully",
+	})
+}
+
+func (sh *StickerHandler) ReorderStickerPack(ctx context.Context, c *app.RequestContext) {
+	ctx, span := sh.Obs.StartSpan(ctx, "StickerHandler.ReorderStickerPack")
+	defer span()
+
+	userID, ok := currentUserID(ctx, c, sh.UserUseCase)
+	if !ok {
+		return
+	}
+
+	var request presenter.ReorderStickerPackRequest
+	if err := c.Bind(&request); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Invalid request: %v", err),
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Validation error: %v", err),
+		})
+		return
+	}
+
+	response, err := sh.StickerUseCase.ReorderStickerPack(ctx, userID, &request)
+	if err != nil {
+		writeStickerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[[]*presenter.StickerPackResponse]{
+		Message: "Sticker packs reordered successfully",
+		Data:    response,
+	})
+}
+
+func writeStickerError(c *app.RequestContext, err error) {
+	switch err {
+	case usecase.ErrNotFoundStickerPack:
+		c.JSON(http.StatusNotFound, presenter.BaseResponse[any]{Message: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, presenter.BaseResponse[any]{Message: fmt.Sprintf("Internal server error: %v", err)})
+	}
+}
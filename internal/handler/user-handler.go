@@ -16,8 +16,10 @@ import (
 )
 
 type UserHandler struct {
-	UserUseCase usecase.UserUseCase
-	Obs         *observability.Observability
+	UserUseCase            usecase.UserUseCase
+	AccountDeletionUseCase usecase.AccountDeletionUseCase
+	DataExportUseCase      usecase.DataExportUseCase
+	Obs                    *observability.Observability
 }
 
 func (uh *UserHandler) Register(ctx context.Context, c *app.RequestContext) {
@@ -197,3 +199,112 @@ func (uh *UserHandler) UpdateUser(ctx context.Context, c *app.RequestContext) {
 		Data:    response,
 	})
 }
+
+func (uh *UserHandler) Logout(ctx context.Context, c *app.RequestContext) {
+	ctx, span := uh.Obs.StartSpan(ctx, "UserHandler.Logout")
+	defer span()
+
+	err := uh.UserUseCase.Logout(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, presenter.BaseResponse[any]{Message: fmt.Sprintf("Internal server error: %v", err)})
+		return
+	}
+
+	c.SetCookie("access_token", "", -1, "/", configuration.ConfigInstance.Server.Origin, protocol.CookieSameSiteNoneMode, true, true)
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[any]{
+		Message: "User logged out successfully",
+	})
+}
+
+func (uh *UserHandler) DeleteAccount(ctx context.Context, c *app.RequestContext) {
+	ctx, span := uh.Obs.StartSpan(ctx, "UserHandler.DeleteAccount")
+	defer span()
+
+	response, err := uh.AccountDeletionUseCase.DeleteAccount(ctx)
+	if err != nil && err != usecase.ErrNotFoundAccount {
+		c.JSON(http.StatusInternalServerError, presenter.BaseResponse[any]{Message: fmt.Sprintf("Internal server error: %v", err)})
+		return
+	}
+
+	if err == usecase.ErrNotFoundAccount {
+		c.JSON(http.StatusNotFound, presenter.BaseResponse[any]{Message: "Account not found"})
+		return
+	}
+
+	c.SetCookie("access_token", "", -1, "/", configuration.ConfigInstance.Server.Origin, protocol.CookieSameSiteNoneMode, true, true)
+
+	c.JSON(http.StatusAccepted, presenter.BaseResponse[*presenter.DeleteAccountResponse]{
+		Message: "Account deletion scheduled",
+		Data:    response,
+	})
+}
+
+func (uh *UserHandler) ExportData(ctx context.Context, c *app.RequestContext) {
+	ctx, span := uh.Obs.StartSpan(ctx, "UserHandler.ExportData")
+	defer span()
+
+	response, err := uh.DataExportUseCase.ExportData(ctx)
+	if err != nil && err != usecase.ErrNotFoundAccount {
+		c.JSON(http.StatusInternalServerError, presenter.BaseResponse[any]{Message: fmt.Sprintf("Internal server error: %v", err)})
+		return
+	}
+
+	if err == usecase.ErrNotFoundAccount {
+		c.JSON(http.StatusNotFound, presenter.BaseResponse[any]{Message: "Account not found"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, presenter.BaseResponse[*presenter.DataExportResponse]{
+		Message: "Data export scheduled",
+		Data:    response,
+	})
+}
+
+func (uh *UserHandler) GetDataExport(ctx context.Context, c *app.RequestContext) {
+	ctx, span := uh.Obs.StartSpan(ctx, "UserHandler.GetDataExport")
+	defer span()
+
+	jobID := c.Query("job_id")
+	if jobID == "" {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{Message: "job_id is required"})
+		return
+	}
+
+	response, err := uh.DataExportUseCase.GetDataExport(ctx, jobID)
+	if err != nil && err != usecase.ErrNotFoundDataExport {
+		c.JSON(http.StatusInternalServerError, presenter.BaseResponse[any]{Message: fmt.Sprintf("Internal server error: %v", err)})
+		return
+	}
+
+	if err == usecase.ErrNotFoundDataExport {
+		c.JSON(http.StatusNotFound, presenter.BaseResponse[any]{Message: "Data export not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[*presenter.DataExportResponse]{
+		Message: "Data export retrieved successfully",
+		Data:    response,
+	})
+}
+
+// currentUserID resolves the user of the request, writing the error response
+// when it can not.
+func currentUserID(ctx context.Context, c *app.RequestContext, userUseCase usecase.UserUseCase) (string, bool) {
+	accountID := ctx.Value(utils.AccountIDKey)
+	if accountID == nil {
+		c.JSON(http.StatusUnauthorized, presenter.BaseResponse[any]{
+			Message: "Unauthorized",
+		})
+		return "", false
+	}
+
+	userID, err := userUseCase.GetUserIDByAccountID(ctx, accountID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, presenter.BaseResponse[any]{
+			Message: err.Error(),
+		})
+		return "", false
+	}
+	return userID, true
+}
@@ -0,0 +1,272 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/internal/presenter"
+	"github.com/chat-socio/backend/internal/usecase"
+	"github.com/chat-socio/backend/pkg/observability"
+	"github.com/cloudwego/hertz/pkg/app"
+)
+
+type WebhookHandler struct {
+	WebhookUseCase         usecase.WebhookUseCase
+	IncomingWebhookUseCase usecase.IncomingWebhookUseCase
+	Obs                    *observability.Observability
+}
+
+func (wh *WebhookHandler) CreateWebhook(ctx context.Context, c *app.RequestContext) {
+	ctx, span := wh.Obs.StartSpan(ctx, "WebhookHandler.CreateWebhook")
+	defer span()
+
+	var request presenter.CreateWebhookRequest
+	if err := c.Bind(&request); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Invalid request: %v", err),
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Validation error: %v", err),
+		})
+		return
+	}
+
+	response, err := wh.WebhookUseCase.CreateWebhook(ctx, &request)
+	if err != nil {
+		writeWebhookError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[*presenter.WebhookResponse]{
+		Message: "Webhook created successfully, store the secret now as it will not be shown again",
+		Data:    response,
+	})
+}
+
+func (wh *WebhookHandler) GetListWebhook(ctx context.Context, c *app.RequestContext) {
+	ctx, span := wh.Obs.StartSpan(ctx, "WebhookHandler.GetListWebhook")
+	defer span()
+
+	conversationID := c.Query("conversation_id")
+	if conversationID == "" {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{Message: "conversation_id is required"})
+		return
+	}
+
+	response, err := wh.WebhookUseCase.GetListWebhook(ctx, conversationID)
+	if err != nil {
+		writeWebhookError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[[]*presenter.WebhookResponse]{
+		Message: "Webhook list retrieved successfully",
+		Data:    response,
+	})
+}
+
+func (wh *WebhookHandler) UpdateWebhook(ctx context.Context, c *app.RequestContext) {
+	ctx, span := wh.Obs.StartSpan(ctx, "WebhookHandler.UpdateWebhook")
+	defer span()
+
+	var request presenter.UpdateWebhookRequest
+	if err := c.Bind(&request); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Invalid request: %v", err),
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Validation error: %v", err),
+		})
+		return
+	}
+
+	response, err := wh.WebhookUseCase.UpdateWebhook(ctx, &request)
+	if err != nil {
+		writeWebhookError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[*presenter.WebhookResponse]{
+		Message: "Webhook updated successfully",
+		Data:    response,
+	})
+}
+
+func (wh *WebhookHandler) DeleteWebhook(ctx context.Context, c *app.RequestContext) {
+	ctx, span := wh.Obs.StartSpan(ctx, "WebhookHandler.DeleteWebhook")
+	defer span()
+
+	webhookID := c.Query("id")
+	if webhookID == "" {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{Message: "id is required"})
+		return
+	}
+
+	err := wh.WebhookUseCase.DeleteWebhook(ctx, webhookID)
+	if err != nil {
+		writeWebhookError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[any]{
+		Message: "Webhook deleted successfully",
+	})
+}
+
+func (wh *WebhookHandler) GetListWebhookDelivery(ctx context.Context, c *app.RequestContext) {
+	ctx, span := wh.Obs.StartSpan(ctx, "WebhookHandler.GetListWebhookDelivery")
+	defer span()
+
+	webhookID := c.Query("webhook_id")
+	if webhookID == "" {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{Message: "webhook_id is required"})
+		return
+	}
+	lastID := c.Query("last_id")
+	limit, err := strconv.Atoi(c.Query("limit"))
+	if err != nil {
+		limit = 20
+	}
+
+	response, err := wh.WebhookUseCase.GetListWebhookDelivery(ctx, webhookID, lastID, limit)
+	if err != nil {
+		writeWebhookError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[[]*presenter.WebhookDeliveryResponse]{
+		Message: "Webhook delivery list retrieved successfully",
+		Data:    response,
+	})
+}
+
+func (wh *WebhookHandler) CreateIncomingWebhook(ctx context.Context, c *app.RequestContext) {
+	ctx, span := wh.Obs.StartSpan(ctx, "WebhookHandler.CreateIncomingWebhook")
+	defer span()
+
+	var request presenter.CreateIncomingWebhookRequest
+	if err := c.Bind(&request); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Invalid request: %v", err),
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Validation error: %v", err),
+		})
+		return
+	}
+
+	response, err := wh.IncomingWebhookUseCase.CreateIncomingWebhook(ctx, &request)
+	if err != nil {
+		writeWebhookError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[*presenter.IncomingWebhookResponse]{
+		Message: "Incoming webhook created successfully, store the token now as it will not be shown again",
+		Data:    response,
+	})
+}
+
+func (wh *WebhookHandler) GetListIncomingWebhook(ctx context.Context, c *app.RequestContext) {
+	ctx, span := wh.Obs.StartSpan(ctx, "WebhookHandler.GetListIncomingWebhook")
+	defer span()
+
+	conversationID := c.Query("conversation_id")
+	if conversationID == "" {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{Message: "conversation_id is required"})
+		return
+	}
+
+	response, err := wh.IncomingWebhookUseCase.GetListIncomingWebhook(ctx, conversationID)
+	if err != nil {
+		writeWebhookError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[[]*presenter.IncomingWebhookResponse]{
+		Message: "Incoming webhook list retrieved successfully",
+		Data:    response,
+	})
+}
+
+func (wh *WebhookHandler) RevokeIncomingWebhook(ctx context.Context, c *app.RequestContext) {
+	ctx, span := wh.Obs.StartSpan(ctx, "WebhookHandler.RevokeIncomingWebhook")
+	defer span()
+
+	incomingWebhookID := c.Query("id")
+	if incomingWebhookID == "" {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{Message: "id is required"})
+		return
+	}
+
+	err := wh.IncomingWebhookUseCase.RevokeIncomingWebhook(ctx, incomingWebhookID)
+	if err != nil {
+		writeWebhookError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[any]{
+		Message: "Incoming webhook revoked successfully",
+	})
+}
+
+// PostIncomingWebhook is called by external services, the token in the path
+// is the only credential.
+func (wh *WebhookHandler) PostIncomingWebhook(ctx context.Context, c *app.RequestContext) {
+	ctx, span := wh.Obs.StartSpan(ctx, "WebhookHandler.PostIncomingWebhook")
+	defer span()
+
+	var request presenter.IncomingWebhookMessageRequest
+	if err := c.Bind(&request); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Invalid request: %v", err),
+		})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{
+			Message: fmt.Sprintf("Validation error: %v", err),
+		})
+		return
+	}
+
+	response, err := wh.IncomingWebhookUseCase.PostMessage(ctx, c.Param("token"), &request)
+	if err != nil {
+		writeWebhookError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presenter.BaseResponse[[]*presenter.MessageResponse]{
+		Message: "Message sent successfully",
+		Data:    response,
+	})
+}
+
+func writeWebhookError(c *app.RequestContext, err error) {
+	switch err {
+	case usecase.ErrNotFoundAccount, usecase.ErrNotFoundWebhook, usecase.ErrNotFoundIncomingWebhook:
+		c.JSON(http.StatusNotFound, presenter.BaseResponse[any]{Message: err.Error()})
+	case domain.ErrNotFoundMemberOfConversation, domain.ErrNotAdminOfConversation:
+		c.JSON(http.StatusForbidden, presenter.BaseResponse[any]{Message: err.Error()})
+	case usecase.ErrNotGroupConversation:
+		c.JSON(http.StatusBadRequest, presenter.BaseResponse[any]{Message: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, presenter.BaseResponse[any]{Message: fmt.Sprintf("Internal server error: %v", err)})
+	}
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/internal/presenter"
+	"github.com/chat-socio/backend/internal/utils"
+	"github.com/chat-socio/backend/pkg/hash"
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/jackc/pgx/v5"
+)
+
+const ApiKeyHeader = "X-Api-Key"
+
+// apiKeyRouteScopes lists the routes a bot may call with the scope each one
+// needs, every other route is closed to api keys.
+var apiKeyRouteScopes = map[string]string{
+	"POST /auth/message":       domain.ApiKeyScopeSendMessage,
+	"POST /auth/poll":          domain.ApiKeyScopeSendMessage,
+	"GET /auth/message":        domain.ApiKeyScopeReadConversation,
+	"GET /auth/message/thread": domain.ApiKeyScopeReadConversation,
+	"GET /auth/message/search": domain.ApiKeyScopeReadConversation,
+	"GET /auth/conversation":   domain.ApiKeyScopeReadConversation,
+}
+
+func (m *Middleware) apiKeyAuth(ctx context.Context, c *app.RequestContext, key string) {
+	apiKey, err := m.apiKeyRepository.GetActiveApiKeyByHash(ctx, hash.HashToken(key))
+	if err != nil && err != pgx.ErrNoRows {
+		c.JSON(http.StatusInternalServerError, presenter.BaseResponse[any]{
+			Message: "Internal server error",
+		})
+		c.Abort()
+		return
+	}
+	if err == pgx.ErrNoRows {
+		c.JSON(http.StatusUnauthorized, presenter.BaseResponse[any]{
+			Message: "Unauthorized",
+		})
+		c.Abort()
+		return
+	}
+
+	scope, ok := apiKeyRouteScopes[string(c.Method())+" "+c.FullPath()]
+	if !ok || !apiKey.HasScope(scope) {
+		c.JSON(http.StatusForbidden, presenter.BaseResponse[any]{
+			Message: "Api key is not allowed to access this resource",
+		})
+		c.Abort()
+		return
+	}
+
+	// last used is informational only, a failed write must not block the bot
+	_ = m.apiKeyRepository.UpdateLastUsedAt(ctx, apiKey.ID, time.Now())
+
+	ctx = context.WithValue(ctx, utils.AccountIDKey, apiKey.AccountID)
+	ctx = context.WithValue(ctx, utils.ApiKeyIDKey, apiKey.ID)
+	c.Next(ctx)
+}
@@ -17,17 +17,25 @@ import (
 type Middleware struct {
 	sessionCacheRepository domain.SessionCacheRepository
 	sessionRepository      domain.SessionRepository
+	apiKeyRepository       domain.ApiKeyRepository
 }
 
-func NewMiddleware(sessionCacheRepository domain.SessionCacheRepository, sessionRepository domain.SessionRepository) *Middleware {
+func NewMiddleware(sessionCacheRepository domain.SessionCacheRepository, sessionRepository domain.SessionRepository, apiKeyRepository domain.ApiKeyRepository) *Middleware {
 	return &Middleware{
 		sessionCacheRepository: sessionCacheRepository,
 		sessionRepository:      sessionRepository,
+		apiKeyRepository:       apiKeyRepository,
 	}
 }
 
 func (m *Middleware) AuthMiddleware() app.HandlerFunc {
 	return func(ctx context.Context, c *app.RequestContext) {
+		// Bots authenticate with an api key instead of a session
+		if apiKey := c.Request.Header.Get(ApiKeyHeader); apiKey != "" {
+			m.apiKeyAuth(ctx, c, apiKey)
+			return
+		}
+
 		var accessToken string
 		// Extract the session token from the request header
 		bearToken := c.Request.Header.Get("Authorization")
@@ -113,7 +121,7 @@ func (m *Middleware) AuthMiddleware() app.HandlerFunc {
 				return
 			}
 			// Check if the session is expired
-			if session.ExpiredAt != nil && session.ExpiredAt.Before(time.Now()) {
+			if session.IsExpired(time.Now()) {
 				c.JSON(http.StatusUnauthorized, presenter.BaseResponse[any]{
 					Message: "Session expired",
 				})
@@ -139,6 +147,16 @@ func (m *Middleware) AuthMiddleware() app.HandlerFunc {
 			}
 		}
 
+		// Check if the cached session is expired
+		if session.IsExpired(time.Now()) {
+			m.sessionCacheRepository.DeleteSession(ctx, claims.Jit)
+			c.JSON(http.StatusUnauthorized, presenter.BaseResponse[any]{
+				Message: "Session expired",
+			})
+			c.Abort()
+			return
+		}
+
 		// Check session is active
 		if session.IsActive != nil && !*session.IsActive {
 			c.JSON(http.StatusUnauthorized, presenter.BaseResponse[any]{
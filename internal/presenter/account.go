@@ -101,3 +101,9 @@ func (r *UpdateUserRequest) Validate() error {
 	}
 	return nil
 }
+
+type DeleteAccountResponse struct {
+	JobID  string `json:"job_id,omitempty"`
+	Status string `json:"status,omitempty"`
+	Step   string `json:"step,omitempty"`
+}
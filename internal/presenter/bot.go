@@ -0,0 +1,116 @@
+package presenter
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/chat-socio/backend/internal/domain"
+)
+
+type CreateBotRequest struct {
+	FullName string `json:"full_name,omitempty"`
+	Avatar   string `json:"avatar,omitempty"`
+}
+
+func (r *CreateBotRequest) Validate() error {
+	if r.FullName == "" {
+		return errors.New("full_name is required")
+	}
+	return nil
+}
+
+type BotResponse struct {
+	BotID     string     `json:"bot_id,omitempty"`
+	OwnerID   string     `json:"owner_id,omitempty"`
+	FullName  string     `json:"full_name,omitempty"`
+	Avatar    string     `json:"avatar,omitempty"`
+	UserType  string     `json:"user_type,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+type CreateApiKeyRequest struct {
+	BotID  string   `json:"bot_id,omitempty"`
+	Name   string   `json:"name,omitempty"`
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+func (r *CreateApiKeyRequest) Validate() error {
+	if r.BotID == "" {
+		return errors.New("bot_id is required")
+	}
+	if len(r.Scopes) == 0 {
+		return errors.New("scopes is required")
+	}
+	for _, scope := range r.Scopes {
+		if !slices.Contains(domain.ApiKeyScopes, scope) {
+			return fmt.Errorf("invalid scope %q", scope)
+		}
+	}
+	return nil
+}
+
+// ApiKeyResponse carries the plain key only when the key is created, it can
+// not be retrieved afterwards.
+type ApiKeyResponse struct {
+	ID         string     `json:"id,omitempty"`
+	BotID      string     `json:"bot_id,omitempty"`
+	Name       string     `json:"name,omitempty"`
+	Prefix     string     `json:"prefix,omitempty"`
+	Key        string     `json:"key,omitempty"`
+	Scopes     []string   `json:"scopes,omitempty"`
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+type AddBotToConversationRequest struct {
+	BotID          string `json:"bot_id,omitempty"`
+	ConversationID string `json:"conversation_id,omitempty"`
+}
+
+func (r *AddBotToConversationRequest) Validate() error {
+	if r.BotID == "" {
+		return errors.New("bot_id is required")
+	}
+	if r.ConversationID == "" {
+		return errors.New("conversation_id is required")
+	}
+	return nil
+}
+
+var botCommandPattern = regexp.MustCompile(`^[a-z0-9_-]{1,32}$`)
+
+type CreateBotCommandRequest struct {
+	BotID       string `json:"bot_id,omitempty"`
+	Command     string `json:"command,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+func (r *CreateBotCommandRequest) Validate() error {
+	if r.BotID == "" {
+		return errors.New("bot_id is required")
+	}
+	r.Command = strings.TrimPrefix(strings.ToLower(r.Command), "/")
+	if !botCommandPattern.MatchString(r.Command) {
+		return errors.New("command must be 1 to 32 lowercase letters, digits, '-' or '_'")
+	}
+	if slices.Contains(domain.BuiltinCommands, r.Command) {
+		return fmt.Errorf("command %q is reserved", r.Command)
+	}
+	return nil
+}
+
+// BotCommandResponse tells the bot owner which subject the invocations of the
+// command are published on.
+type BotCommandResponse struct {
+	ID          string     `json:"id,omitempty"`
+	BotID       string     `json:"bot_id,omitempty"`
+	Command     string     `json:"command,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Subject     string     `json:"subject,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+}
@@ -67,3 +67,21 @@ type GetListContactResponse struct {
 	CreatedAt *time.Time    `json:"created_at,omitempty"`
 	UpdatedAt *time.Time    `json:"updated_at,omitempty"`
 }
+
+// ContactCardResponse is the profile a contact message points to, resolved
+// for the user reading it. Unavailable cards keep only the user id.
+type ContactCardResponse struct {
+	UserID    string                       `json:"user_id,omitempty"`
+	FullName  string                       `json:"full_name,omitempty"`
+	Avatar    string                       `json:"avatar,omitempty"`
+	Available bool                         `json:"available"`
+	Actions   []*ContactCardActionResponse `json:"actions,omitempty"`
+}
+
+// ContactCardActionResponse carries what a client needs to run the action,
+// start_dm and send_friend_request target UserID, open_dm ConversationID.
+type ContactCardActionResponse struct {
+	Type           string `json:"type,omitempty"`
+	UserID         string `json:"user_id,omitempty"`
+	ConversationID string `json:"conversation_id,omitempty"`
+}
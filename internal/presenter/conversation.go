@@ -2,11 +2,15 @@ package presenter
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/chat-socio/backend/internal/domain"
 )
 
+const maxConversationTitleLength = 100
+
 type ConversationResponse struct {
 	ConversationID string                        `json:"conversation_id,omitempty"`
 	Title          string                        `json:"title,omitempty"`
@@ -16,6 +20,8 @@ type ConversationResponse struct {
 	UpdatedAt      *time.Time                    `json:"updated_at,omitempty"`
 	Type           string                        `json:"type,omitempty"`
 	Members        []*ConversationMemberResponse `json:"members,omitempty"`
+	PinnedCount    int                           `json:"pinned_count"`
+	MessageTTL     string                        `json:"message_ttl,omitempty"`
 }
 
 type ConversationMemberResponse struct {
@@ -23,13 +29,15 @@ type ConversationMemberResponse struct {
 	FullName string `json:"full_name,omitempty"`
 	Avatar   string `json:"avatar,omitempty"`
 	UserType string `json:"user_type,omitempty"`
+	Role     string `json:"role,omitempty"`
 }
 
 type CreateConversationRequest struct {
-	Title   string   `json:"title,omitempty"`
-	Type    string   `json:"type,omitempty"`
-	Avatar  string   `json:"avatar,omitempty"`
-	Members []string `json:"members,omitempty"`
+	Title     string   `json:"title,omitempty"`
+	Type      string   `json:"type,omitempty"`
+	Avatar    string   `json:"avatar,omitempty"`
+	Members   []string `json:"members,omitempty"`
+	CreatorID string   `json:"-"`
 }
 
 func (c *CreateConversationRequest) Validate() error {
@@ -48,13 +56,42 @@ func (c *CreateConversationRequest) Validate() error {
 	return nil
 }
 
+type UpdateConversationRequest struct {
+	ConversationID string  `json:"conversation_id,omitempty"`
+	Title          *string `json:"title,omitempty"`
+	Avatar         *string `json:"avatar,omitempty"` // url of an uploaded file, empty removes the avatar
+}
+
+func (u *UpdateConversationRequest) Validate() error {
+	if u.ConversationID == "" {
+		return errors.New("conversation_id is required")
+	}
+	if u.Title == nil && u.Avatar == nil {
+		return errors.New("title or avatar is required")
+	}
+	if u.Title != nil {
+		title := strings.TrimSpace(*u.Title)
+		if title == "" {
+			return errors.New("title must not be empty")
+		}
+		if len([]rune(title)) > maxConversationTitleLength {
+			return fmt.Errorf("title must be at most %d characters", maxConversationTitleLength)
+		}
+		u.Title = &title
+	}
+	return nil
+}
+
 type SendMessageRequest struct {
-	ConversationID string `json:"conversation_id,omitempty"`
-	UserID         string `json:"user_id,omitempty"`
-	Type           string `json:"type,omitempty"`
-	Body           string `json:"body,omitempty"`
-	ReplyTo        string `json:"reply_to,omitempty"`
-	UserOnlineID   string `json:"user_online_id,omitempty"`   // for ignore user online id
+	ConversationID string     `json:"conversation_id,omitempty"`
+	UserID         string     `json:"user_id,omitempty"`
+	Type           string     `json:"type,omitempty"`
+	Body           string     `json:"body,omitempty"`
+	ReplyTo        string     `json:"reply_to,omitempty"`
+	UserOnlineID   string     `json:"user_online_id,omitempty"` // for ignore user online id
+	IsBot          bool       `json:"-"`                        // bots post without a websocket connection
+	DisplayName    string     `json:"-"`                        // sender name override of incoming webhooks
+	LiveUntil      *time.Time `json:"-"`                        // set when the location message is shared live
 	// IgnoreFCMToken string `json:"ignore_fcm_token,omitempty"` // for ignore fcm token
 }
 
@@ -71,23 +108,47 @@ func (s *SendMessageRequest) Validate() error {
 	if s.Body == "" {
 		return errors.New("body is required")
 	}
-	if s.UserOnlineID == "" {
+	if s.UserOnlineID == "" && !s.IsBot {
 		return errors.New("user_online_id is required")
 	}
 	return nil
 }
 
+type EditMessageRequest struct {
+	MessageID string `json:"message_id,omitempty"`
+	Body      string `json:"body,omitempty"`
+}
+
+func (e *EditMessageRequest) Validate() error {
+	if e.MessageID == "" {
+		return errors.New("message_id is required")
+	}
+	if e.Body == "" {
+		return errors.New("body is required")
+	}
+	return nil
+}
+
 type MessageResponse struct {
-	MessageID      string        `json:"message_id,omitempty"`
-	Body           string        `json:"body,omitempty"`
-	CreatedAt      *time.Time    `json:"created_at,omitempty"`
-	UpdatedAt      *time.Time    `json:"updated_at,omitempty"`
-	ConversationID string        `json:"conversation_id,omitempty"`
-	User           *UserResponse `json:"user,omitempty"`
-	Type           string        `json:"type,omitempty"`
-	DeletedAt      *time.Time    `json:"deleted_at,omitempty"`
-	ReplyTo        string        `json:"reply_to,omitempty"`
-	IsRead         bool          `json:"is_read"`
+	MessageID      string                 `json:"message_id,omitempty"`
+	Body           string                 `json:"body,omitempty"`
+	CreatedAt      *time.Time             `json:"created_at,omitempty"`
+	UpdatedAt      *time.Time             `json:"updated_at,omitempty"`
+	ConversationID string                 `json:"conversation_id,omitempty"`
+	User           *UserResponse          `json:"user,omitempty"`
+	Type           string                 `json:"type,omitempty"`
+	DeletedAt      *time.Time             `json:"deleted_at,omitempty"`
+	ReplyTo        string                 `json:"reply_to,omitempty"`
+	DisplayName    string                 `json:"display_name,omitempty"`
+	IsRead         bool                   `json:"is_read"`
+	Ephemeral      bool                   `json:"ephemeral,omitempty"` // only shown to the sender, not stored
+	Poll           *PollResponse          `json:"poll,omitempty"`
+	Contact        *ContactCardResponse   `json:"contact,omitempty"`
+	ThreadID       string                 `json:"thread_id,omitempty"`
+	ForwardedFrom  string                 `json:"forwarded_from,omitempty"` // id of the original message
+	Thread         *ThreadSummaryResponse `json:"thread,omitempty"`         // set on thread roots that have replies
+	Parent         *MessageResponse       `json:"parent,omitempty"`         // the message a reply quotes
+	ExpiresAt      *time.Time             `json:"expires_at,omitempty"`     // set in conversations with disappearing messages
 }
 
 type GetListConversationResponse struct {
@@ -100,17 +161,116 @@ type GetListConversationResponse struct {
 	Type           string                        `json:"type,omitempty"`
 	LastMessage    *MessageResponse              `json:"last_message,omitempty"`
 	Members        []*ConversationMemberResponse `json:"members,omitempty"`
+	UnreadCount    int                           `json:"unread_count"`
+	MutedUntil     *time.Time                    `json:"muted_until,omitempty"`
+	Archived       bool                          `json:"archived"`
+	Pinned         bool                          `json:"pinned"`
 }
 
-type SeenMessageResponse struct {
-	MessageID      string     `json:"message_id,omitempty"`
-	UserID         string     `json:"user_id,omitempty"`
+type UnreadCountResponse struct {
+	TotalUnreadCount int `json:"total_unread_count"`
+}
+
+type UpdateConversationSettingsRequest struct {
+	ConversationID string `json:"conversation_id,omitempty"`
+	MuteDuration   string `json:"mute_duration,omitempty"` // 30m, 8h, 1d, 1w or off, unchanged when empty
+	Archived       *bool  `json:"archived,omitempty"`
+	Pinned         *bool  `json:"pinned,omitempty"`
+}
+
+func (r *UpdateConversationSettingsRequest) Validate() error {
+	if r.ConversationID == "" {
+		return errors.New("conversation_id is required")
+	}
+	if r.MuteDuration == "" && r.Archived == nil && r.Pinned == nil {
+		return errors.New("mute_duration, archived or pinned is required")
+	}
+	if r.Archived != nil && *r.Archived && r.Pinned != nil && *r.Pinned {
+		return errors.New("an archived conversation cannot be pinned")
+	}
+	return nil
+}
+
+type ConversationSettingsResponse struct {
 	ConversationID string     `json:"conversation_id,omitempty"`
-	CreatedAt      *time.Time `json:"created_at,omitempty"`
-	UpdatedAt      *time.Time `json:"updated_at,omitempty"`
+	MutedUntil     *time.Time `json:"muted_until,omitempty"`
+	Archived       bool       `json:"archived"`
+	Pinned         bool       `json:"pinned"`
+}
+
+type ReorderPinnedConversationRequest struct {
+	ConversationIDs []string `json:"conversation_ids"` // every pinned conversation, top first
+}
+
+func (r *ReorderPinnedConversationRequest) Validate() error {
+	seen := make(map[string]bool, len(r.ConversationIDs))
+	for _, conversationID := range r.ConversationIDs {
+		if conversationID == "" {
+			return errors.New("conversation_ids must not contain empty ids")
+		}
+		if seen[conversationID] {
+			return errors.New("conversation_ids must not contain duplicates")
+		}
+		seen[conversationID] = true
+	}
+	return nil
+}
+
+type PinnedConversationResponse struct {
+	ConversationIDs []string `json:"conversation_ids"`
+}
+
+type MarkUnreadRequest struct {
+	ConversationID string `json:"conversation_id,omitempty"`
+	MessageID      string `json:"message_id,omitempty"` // first unread message, the last message when empty
+}
+
+func (r *MarkUnreadRequest) Validate() error {
+	if r.ConversationID == "" {
+		return errors.New("conversation_id is required")
+	}
+	return nil
+}
+
+type MarkAllReadResponse struct {
+	ConversationCount int `json:"conversation_count"` // conversations that had unread messages
+}
+
+type SeenMessageResponse struct {
+	MessageID      string        `json:"message_id,omitempty"`
+	UserID         string        `json:"user_id,omitempty"`
+	ConversationID string        `json:"conversation_id,omitempty"`
+	CreatedAt      *time.Time    `json:"created_at,omitempty"`
+	UpdatedAt      *time.Time    `json:"updated_at,omitempty"` // when the user last moved their seen message
+	User           *UserResponse `json:"user,omitempty"`
 }
 
 type SeenMessageRequest struct {
 	ConversationID string `json:"conversation_id,omitempty"`
 	MessageID      string `json:"message_id,omitempty"`
 }
+
+func (r *SeenMessageRequest) Validate() error {
+	if r.ConversationID == "" {
+		return errors.New("conversation_id is required")
+	}
+	if r.MessageID == "" {
+		return errors.New("message_id is required")
+	}
+	return nil
+}
+
+type UpdateMessageTTLRequest struct {
+	ConversationID string `json:"conversation_id,omitempty"`
+	MessageTTL     string `json:"message_ttl,omitempty"` // off, 1h, 1d or 7d
+}
+
+func (r *UpdateMessageTTLRequest) Validate() error {
+	if r.ConversationID == "" {
+		return errors.New("conversation_id is required")
+	}
+	if _, ok := domain.MessageTTLs[r.MessageTTL]; !ok {
+		return errors.New("invalid message_ttl")
+	}
+	return nil
+}
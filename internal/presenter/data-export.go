@@ -0,0 +1,61 @@
+package presenter
+
+import "time"
+
+type DataExportResponse struct {
+	JobID       string     `json:"job_id,omitempty"`
+	Status      string     `json:"status,omitempty"`
+	URL         string     `json:"url,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+type ExportProfile struct {
+	UserID    string     `json:"user_id,omitempty"`
+	AccountID string     `json:"account_id,omitempty"`
+	Type      string     `json:"type,omitempty"`
+	Email     string     `json:"email,omitempty"`
+	FullName  string     `json:"full_name,omitempty"`
+	Avatar    string     `json:"avatar,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// ExportSession leaves out the session token, the archive must not carry
+// credentials.
+type ExportSession struct {
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	ExpiredAt *time.Time `json:"expired_at,omitempty"`
+	IsActive  bool       `json:"is_active"`
+	UserAgent string     `json:"user_agent,omitempty"`
+	IPAddress string     `json:"ip_address,omitempty"`
+}
+
+type ExportContact struct {
+	UserID    string     `json:"user_id,omitempty"`
+	FullName  string     `json:"full_name,omitempty"`
+	Avatar    string     `json:"avatar,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+type ExportConversation struct {
+	ConversationID string                        `json:"conversation_id,omitempty"`
+	Type           string                        `json:"type,omitempty"`
+	Title          string                        `json:"title,omitempty"`
+	Avatar         string                        `json:"avatar,omitempty"`
+	CreatedAt      *time.Time                    `json:"created_at,omitempty"`
+	Members        []*ConversationMemberResponse `json:"members,omitempty"`
+}
+
+type ExportMessage struct {
+	MessageID      string     `json:"message_id,omitempty"`
+	ConversationID string     `json:"conversation_id,omitempty"`
+	Type           string     `json:"type,omitempty"`
+	Body           string     `json:"body,omitempty"`
+	ReplyTo        string     `json:"reply_to,omitempty"`
+	CreatedAt      *time.Time `json:"created_at,omitempty"`
+	UpdatedAt      *time.Time `json:"updated_at,omitempty"`
+	DeletedAt      *time.Time `json:"deleted_at,omitempty"`
+}
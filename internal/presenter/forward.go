@@ -0,0 +1,37 @@
+package presenter
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+const (
+	maxForwardMessages      = 30
+	maxForwardConversations = 10
+)
+
+type ForwardMessageRequest struct {
+	MessageIDs      []string `json:"message_ids,omitempty"`
+	ConversationIDs []string `json:"conversation_ids,omitempty"` // target conversations
+	UserID          string   `json:"-"`
+}
+
+func (r *ForwardMessageRequest) Validate() error {
+	if len(r.MessageIDs) == 0 {
+		return errors.New("message_ids is required")
+	}
+	if len(r.MessageIDs) > maxForwardMessages {
+		return fmt.Errorf("at most %d messages can be forwarded at once", maxForwardMessages)
+	}
+	if len(r.ConversationIDs) == 0 {
+		return errors.New("conversation_ids is required")
+	}
+	if len(r.ConversationIDs) > maxForwardConversations {
+		return fmt.Errorf("messages can be forwarded to at most %d conversations at once", maxForwardConversations)
+	}
+	if slices.Contains(r.MessageIDs, "") || slices.Contains(r.ConversationIDs, "") {
+		return errors.New("ids must not be empty")
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package presenter
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	JoinStatusJoined  = "joined"
+	JoinStatusPending = "pending"
+)
+
+type CreateInviteRequest struct {
+	ConversationID   string     `json:"conversation_id,omitempty"`
+	ExpiresAt        *time.Time `json:"expires_at,omitempty"` // never expires when empty
+	MaxUses          int        `json:"max_uses,omitempty"`   // unlimited when 0
+	RequiresApproval bool       `json:"requires_approval,omitempty"`
+}
+
+func (r *CreateInviteRequest) Validate() error {
+	if r.ConversationID == "" {
+		return errors.New("conversation_id is required")
+	}
+	if r.ExpiresAt != nil && !r.ExpiresAt.After(time.Now()) {
+		return errors.New("expires_at must be in the future")
+	}
+	if r.MaxUses < 0 {
+		return errors.New("max_uses must not be negative")
+	}
+	return nil
+}
+
+type InviteResponse struct {
+	ID               string     `json:"id,omitempty"`
+	ConversationID   string     `json:"conversation_id,omitempty"`
+	Code             string     `json:"code,omitempty"`
+	CreatedBy        string     `json:"created_by,omitempty"`
+	ExpiresAt        *time.Time `json:"expires_at,omitempty"`
+	MaxUses          int        `json:"max_uses"`
+	UseCount         int        `json:"use_count"`
+	RequiresApproval bool       `json:"requires_approval"`
+	CreatedAt        *time.Time `json:"created_at,omitempty"`
+}
+
+type InvitePreviewResponse struct {
+	Code             string     `json:"code,omitempty"`
+	ConversationID   string     `json:"conversation_id,omitempty"`
+	Title            string     `json:"title,omitempty"`
+	Avatar           string     `json:"avatar,omitempty"`
+	MemberCount      int        `json:"member_count"`
+	RequiresApproval bool       `json:"requires_approval"`
+	ExpiresAt        *time.Time `json:"expires_at,omitempty"`
+	IsMember         bool       `json:"is_member"`
+}
+
+type JoinInviteRequest struct {
+	Code string `json:"code,omitempty"`
+}
+
+func (r *JoinInviteRequest) Validate() error {
+	if r.Code == "" {
+		return errors.New("code is required")
+	}
+	return nil
+}
+
+type JoinInviteResponse struct {
+	Status        string                `json:"status,omitempty"`          // joined, or pending until an admin approves
+	JoinRequestID string                `json:"join_request_id,omitempty"` // set when pending
+	Conversation  *ConversationResponse `json:"conversation,omitempty"`    // set when joined
+}
+
+type JoinRequestResponse struct {
+	ID             string        `json:"id,omitempty"`
+	InviteID       string        `json:"invite_id,omitempty"`
+	ConversationID string        `json:"conversation_id,omitempty"`
+	Status         string        `json:"status,omitempty"`
+	User           *UserResponse `json:"user,omitempty"`
+	ReviewedBy     string        `json:"reviewed_by,omitempty"`
+	ReviewedAt     *time.Time    `json:"reviewed_at,omitempty"`
+	CreatedAt      *time.Time    `json:"created_at,omitempty"`
+}
+
+type ReviewJoinRequestRequest struct {
+	JoinRequestID string `json:"join_request_id,omitempty"`
+	Approve       bool   `json:"approve"`
+}
+
+func (r *ReviewJoinRequestRequest) Validate() error {
+	if r.JoinRequestID == "" {
+		return errors.New("join_request_id is required")
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package presenter
+
+import (
+	"errors"
+	"time"
+
+	"github.com/chat-socio/backend/internal/domain"
+)
+
+type StartLiveLocationRequest struct {
+	ConversationID string  `json:"conversation_id,omitempty"`
+	Latitude       float64 `json:"latitude"`
+	Longitude      float64 `json:"longitude"`
+	Accuracy       float64 `json:"accuracy,omitempty"`
+	Label          string  `json:"label,omitempty"`
+	Duration       int     `json:"duration,omitempty"` // in seconds
+	UserOnlineID   string  `json:"user_online_id,omitempty"`
+	UserID         string  `json:"-"`
+	IsBot          bool    `json:"-"`
+}
+
+func (r *StartLiveLocationRequest) Validate() error {
+	if r.ConversationID == "" {
+		return errors.New("conversation_id is required")
+	}
+	duration := time.Duration(r.Duration) * time.Second
+	if duration < domain.MinLiveLocationDuration || duration > domain.MaxLiveLocationDuration {
+		return errors.New("duration must be between 1 minute and 8 hours")
+	}
+	return domain.ValidateLocationPoint(r.Latitude, r.Longitude, r.Accuracy, r.Label)
+}
+
+type UpdateLiveLocationRequest struct {
+	MessageID string  `json:"message_id,omitempty"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Accuracy  float64 `json:"accuracy,omitempty"`
+}
+
+func (r *UpdateLiveLocationRequest) Validate() error {
+	if r.MessageID == "" {
+		return errors.New("message_id is required")
+	}
+	return domain.ValidateLocationPoint(r.Latitude, r.Longitude, r.Accuracy, "")
+}
+
+type LocationResponse struct {
+	MessageID      string     `json:"message_id,omitempty"`
+	ConversationID string     `json:"conversation_id,omitempty"`
+	UserID         string     `json:"user_id,omitempty"`
+	Latitude       float64    `json:"latitude"`
+	Longitude      float64    `json:"longitude"`
+	Accuracy       float64    `json:"accuracy,omitempty"`
+	Label          string     `json:"label,omitempty"`
+	Live           bool       `json:"live"`
+	LiveUntil      *time.Time `json:"live_until,omitempty"`
+	EndedAt        *time.Time `json:"ended_at,omitempty"`
+	UpdatedAt      *time.Time `json:"updated_at,omitempty"`
+}
@@ -0,0 +1,48 @@
+package presenter
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+const maxDeliveredMessages = 100
+
+// DeliveredMessageRequest acknowledges messages received from push
+// notifications.
+type DeliveredMessageRequest struct {
+	ConversationID string   `json:"conversation_id,omitempty"`
+	MessageIDs     []string `json:"message_ids,omitempty"`
+}
+
+func (r *DeliveredMessageRequest) Validate() error {
+	if r.ConversationID == "" {
+		return errors.New("conversation_id is required")
+	}
+	if len(r.MessageIDs) == 0 {
+		return errors.New("message_ids is required")
+	}
+	if len(r.MessageIDs) > maxDeliveredMessages {
+		return fmt.Errorf("at most %d messages can be acknowledged at once", maxDeliveredMessages)
+	}
+	if slices.Contains(r.MessageIDs, "") {
+		return errors.New("message_ids must not be empty")
+	}
+	return nil
+}
+
+type MessageRecipientStatusResponse struct {
+	User        *UserResponse `json:"user,omitempty"`
+	Status      string        `json:"status,omitempty"` // sent, delivered or seen
+	DeliveredAt *time.Time    `json:"delivered_at,omitempty"`
+	SeenAt      *time.Time    `json:"seen_at,omitempty"`
+}
+
+type MessageStatusResponse struct {
+	MessageID      string                            `json:"message_id,omitempty"`
+	Status         string                            `json:"status,omitempty"` // the least advanced status of all recipients
+	DeliveredCount int                               `json:"delivered_count"`
+	SeenCount      int                               `json:"seen_count"`
+	Recipients     []*MessageRecipientStatusResponse `json:"recipients"`
+}
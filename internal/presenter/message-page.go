@@ -0,0 +1,37 @@
+package presenter
+
+import "errors"
+
+type GetMessagePageRequest struct {
+	ConversationID  string `json:"conversation_id,omitempty"`
+	AroundMessageID string `json:"around_message_id,omitempty"` // the page is centered on this message
+	AfterID         string `json:"after_id,omitempty"`          // the page starts right after this message
+	Limit           int    `json:"limit,omitempty"`
+}
+
+func (r *GetMessagePageRequest) Validate() error {
+	if r.ConversationID == "" {
+		return errors.New("conversation_id is required")
+	}
+	if r.AroundMessageID == "" && r.AfterID == "" {
+		return errors.New("around_message_id or after_id is required")
+	}
+	if r.AroundMessageID != "" && r.AfterID != "" {
+		return errors.New("around_message_id and after_id can not be used together")
+	}
+	if r.Limit <= 0 || r.Limit > 100 {
+		r.Limit = 20
+	}
+	return nil
+}
+
+// MessagePageResponse lists the messages newest first like the backward
+// pages, BeforeID is the last_message_id and AfterID the after_id of the
+// neighbouring pages.
+type MessagePageResponse struct {
+	Messages      []*MessageResponse `json:"messages"`
+	HasMoreBefore bool               `json:"has_more_before"`
+	HasMoreAfter  bool               `json:"has_more_after"`
+	BeforeID      string             `json:"before_id,omitempty"`
+	AfterID       string             `json:"after_id,omitempty"`
+}
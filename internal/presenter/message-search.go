@@ -0,0 +1,58 @@
+package presenter
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+	"unicode/utf8"
+
+	"github.com/chat-socio/backend/internal/domain"
+)
+
+const (
+	maxSearchQueryLength = 200
+	maxSearchLimit       = 50
+)
+
+type SearchMessageRequest struct {
+	Query          string     `json:"q,omitempty"`
+	ConversationID string     `json:"conversation_id,omitempty"`
+	FromUserID     string     `json:"from_user,omitempty"`
+	Before         *time.Time `json:"before,omitempty"`
+	After          *time.Time `json:"after,omitempty"`
+	Type           string     `json:"type,omitempty"`
+	Cursor         string     `json:"cursor,omitempty"` // next_cursor of the previous page
+	Limit          int        `json:"limit,omitempty"`
+	UserID         string     `json:"-"`
+}
+
+func (r *SearchMessageRequest) Validate() error {
+	if r.Query == "" {
+		return errors.New("q is required")
+	}
+	if utf8.RuneCountInString(r.Query) > maxSearchQueryLength {
+		return fmt.Errorf("q must be at most %d characters", maxSearchQueryLength)
+	}
+	if r.Type != "" && !slices.Contains(domain.MessageSearchTypes, r.Type) {
+		return fmt.Errorf("type must be one of %v", domain.MessageSearchTypes)
+	}
+	if r.Before != nil && r.After != nil && !r.After.Before(*r.Before) {
+		return errors.New("after must be before before")
+	}
+	if r.Limit <= 0 || r.Limit > maxSearchLimit {
+		r.Limit = 20
+	}
+	return nil
+}
+
+type SearchMessageResult struct {
+	Message *MessageResponse `json:"message,omitempty"`
+	Snippet string           `json:"snippet,omitempty"` // HTML escaped, matched words are wrapped in <mark></mark>
+	Rank    float32          `json:"rank"`
+}
+
+type SearchMessageResponse struct {
+	Results    []*SearchMessageResult `json:"results"`
+	NextCursor string                 `json:"next_cursor,omitempty"` // empty on the last page
+}
@@ -0,0 +1,27 @@
+package presenter
+
+import (
+	"errors"
+	"time"
+)
+
+type PinMessageRequest struct {
+	ConversationID string `json:"conversation_id,omitempty"`
+	MessageID      string `json:"message_id,omitempty"`
+}
+
+func (r *PinMessageRequest) Validate() error {
+	if r.ConversationID == "" {
+		return errors.New("conversation_id is required")
+	}
+	if r.MessageID == "" {
+		return errors.New("message_id is required")
+	}
+	return nil
+}
+
+type PinnedMessageResponse struct {
+	Message  *MessageResponse `json:"message,omitempty"`
+	PinnedBy string           `json:"pinned_by,omitempty"`
+	PinnedAt *time.Time       `json:"pinned_at,omitempty"`
+}
@@ -0,0 +1,79 @@
+package presenter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chat-socio/backend/internal/domain"
+)
+
+type CreatePollRequest struct {
+	ConversationID string     `json:"conversation_id,omitempty"`
+	Question       string     `json:"question,omitempty"`
+	Options        []string   `json:"options,omitempty"`
+	MultipleChoice bool       `json:"multiple_choice,omitempty"`
+	Anonymous      bool       `json:"anonymous,omitempty"`
+	ClosesAt       *time.Time `json:"closes_at,omitempty"`
+	UserOnlineID   string     `json:"user_online_id,omitempty"`
+	UserID         string     `json:"-"`
+	IsBot          bool       `json:"-"`
+}
+
+func (r *CreatePollRequest) Validate() error {
+	if r.ConversationID == "" {
+		return errors.New("conversation_id is required")
+	}
+	if strings.TrimSpace(r.Question) == "" {
+		return errors.New("question is required")
+	}
+	if len(r.Options) < 2 || len(r.Options) > domain.MaxPollOptions {
+		return fmt.Errorf("a poll needs 2 to %d options", domain.MaxPollOptions)
+	}
+	for _, option := range r.Options {
+		if strings.TrimSpace(option) == "" {
+			return errors.New("options must not be empty")
+		}
+	}
+	if r.ClosesAt != nil && !r.ClosesAt.After(time.Now()) {
+		return errors.New("closes_at must be in the future")
+	}
+	return nil
+}
+
+type VotePollRequest struct {
+	MessageID string   `json:"message_id,omitempty"`
+	OptionIDs []string `json:"option_ids,omitempty"`
+}
+
+func (r *VotePollRequest) Validate() error {
+	if r.MessageID == "" {
+		return errors.New("message_id is required")
+	}
+	if len(r.OptionIDs) == 0 {
+		return errors.New("option_ids is required")
+	}
+	return nil
+}
+
+// PollResponse is the aggregated result of a poll. Voters are left out of
+// anonymous polls, Voted is only set for the user the response is built for.
+type PollResponse struct {
+	MessageID      string                `json:"message_id,omitempty"`
+	Question       string                `json:"question,omitempty"`
+	Options        []*PollOptionResponse `json:"options,omitempty"`
+	MultipleChoice bool                  `json:"multiple_choice"`
+	Anonymous      bool                  `json:"anonymous"`
+	ClosesAt       *time.Time            `json:"closes_at,omitempty"`
+	IsClosed       bool                  `json:"is_closed"`
+	TotalVoters    int                   `json:"total_voters"`
+}
+
+type PollOptionResponse struct {
+	ID        string          `json:"id,omitempty"`
+	Text      string          `json:"text,omitempty"`
+	VoteCount int             `json:"vote_count"`
+	Voted     bool            `json:"voted"`
+	Voters    []*UserResponse `json:"voters,omitempty"`
+}
@@ -0,0 +1,52 @@
+package presenter
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/chat-socio/backend/internal/domain"
+)
+
+type CreateScheduledMessageRequest struct {
+	ConversationID string     `json:"conversation_id,omitempty"`
+	Type           string     `json:"type,omitempty"`
+	Body           string     `json:"body,omitempty"`
+	ReplyTo        string     `json:"reply_to,omitempty"`
+	SendAt         *time.Time `json:"send_at,omitempty"`
+	UserID         string     `json:"-"`
+}
+
+func (r *CreateScheduledMessageRequest) Validate() error {
+	if r.ConversationID == "" {
+		return errors.New("conversation_id is required")
+	}
+	if !slices.Contains(domain.ScheduledMessageTypes, r.Type) {
+		return errors.New("type can not be scheduled")
+	}
+	if r.Body == "" {
+		return errors.New("body is required")
+	}
+	if r.SendAt == nil {
+		return errors.New("send_at is required")
+	}
+	now := time.Now()
+	if !r.SendAt.After(now) {
+		return errors.New("send_at must be in the future")
+	}
+	if r.SendAt.After(now.Add(domain.MaxScheduleAhead)) {
+		return errors.New("send_at must be within a year")
+	}
+	return nil
+}
+
+type ScheduledMessageResponse struct {
+	ID             string     `json:"id,omitempty"`
+	ConversationID string     `json:"conversation_id,omitempty"`
+	Type           string     `json:"type,omitempty"`
+	Body           string     `json:"body,omitempty"`
+	ReplyTo        string     `json:"reply_to,omitempty"`
+	SendAt         *time.Time `json:"send_at,omitempty"`
+	Status         string     `json:"status,omitempty"`
+	CreatedAt      *time.Time `json:"created_at,omitempty"`
+}
@@ -0,0 +1,84 @@
+package presenter
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/chat-socio/backend/internal/domain"
+)
+
+type ImportStickerPackRequest struct {
+	Name        string                  `json:"name,omitempty"`
+	Description string                  `json:"description,omitempty"`
+	IsDefault   bool                    `json:"is_default,omitempty"`
+	Stickers    []*ImportStickerRequest `json:"stickers,omitempty"`
+}
+
+type ImportStickerRequest struct {
+	FileName string    `json:"file,omitempty"`
+	Emoji    string    `json:"emoji,omitempty"`
+	Reader   io.Reader `json:"-"`
+	Size     int64     `json:"-"`
+}
+
+func (r *ImportStickerPackRequest) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return errors.New("name is required")
+	}
+	if len(r.Stickers) == 0 || len(r.Stickers) > domain.MaxStickersPerPack {
+		return fmt.Errorf("a pack needs 1 to %d stickers", domain.MaxStickersPerPack)
+	}
+	for _, sticker := range r.Stickers {
+		if sticker.FileName == "" || sticker.Reader == nil {
+			return errors.New("every sticker needs a file")
+		}
+	}
+	return nil
+}
+
+type InstallStickerPackRequest struct {
+	PackID string `json:"pack_id,omitempty"`
+}
+
+func (r *InstallStickerPackRequest) Validate() error {
+	if r.PackID == "" {
+		return errors.New("pack_id is required")
+	}
+	return nil
+}
+
+type ReorderStickerPackRequest struct {
+	PackIDs []string `json:"pack_ids,omitempty"`
+}
+
+func (r *ReorderStickerPackRequest) Validate() error {
+	if len(r.PackIDs) == 0 {
+		return errors.New("pack_ids is required")
+	}
+	seen := make(map[string]bool, len(r.PackIDs))
+	for _, packID := range r.PackIDs {
+		if packID == "" || seen[packID] {
+			return errors.New("pack_ids must be unique and not empty")
+		}
+		seen[packID] = true
+	}
+	return nil
+}
+
+type StickerPackResponse struct {
+	PackID       string             `json:"pack_id,omitempty"`
+	Name         string             `json:"name,omitempty"`
+	Description  string             `json:"description,omitempty"`
+	ThumbnailURL string             `json:"thumbnail_url,omitempty"`
+	IsDefault    bool               `json:"is_default"`
+	Installed    bool               `json:"installed"`
+	Stickers     []*StickerResponse `json:"stickers,omitempty"`
+}
+
+type StickerResponse struct {
+	StickerID string `json:"sticker_id,omitempty"`
+	Emoji     string `json:"emoji,omitempty"`
+	URL       string `json:"url,omitempty"`
+}
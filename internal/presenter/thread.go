@@ -0,0 +1,16 @@
+package presenter
+
+import "time"
+
+type ThreadSummaryResponse struct {
+	ReplyCount       int             `json:"reply_count"`
+	LastReplyID      string          `json:"last_reply_id,omitempty"`
+	LastReplyAt      *time.Time      `json:"last_reply_at,omitempty"`
+	ParticipantCount int             `json:"participant_count"`
+	Participants     []*UserResponse `json:"participants,omitempty"` // most recent repliers first
+}
+
+type MessageThreadResponse struct {
+	Root    *MessageResponse   `json:"root,omitempty"`
+	Replies []*MessageResponse `json:"replies"`
+}
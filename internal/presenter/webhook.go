@@ -0,0 +1,179 @@
+package presenter
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/chat-socio/backend/internal/domain"
+)
+
+type CreateWebhookRequest struct {
+	ConversationID string   `json:"conversation_id,omitempty"`
+	URL            string   `json:"url,omitempty"`
+	Events         []string `json:"events,omitempty"`
+}
+
+func (r *CreateWebhookRequest) Validate() error {
+	if r.ConversationID == "" {
+		return errors.New("conversation_id is required")
+	}
+	if err := validateWebhookURL(r.URL); err != nil {
+		return err
+	}
+	return validateWebhookEvents(r.Events)
+}
+
+type UpdateWebhookRequest struct {
+	ID       string   `json:"id,omitempty"`
+	URL      string   `json:"url,omitempty"`
+	Events   []string `json:"events,omitempty"`
+	IsActive *bool    `json:"is_active,omitempty"`
+}
+
+func (r *UpdateWebhookRequest) Validate() error {
+	if r.ID == "" {
+		return errors.New("id is required")
+	}
+	if r.URL != "" {
+		if err := validateWebhookURL(r.URL); err != nil {
+			return err
+		}
+	}
+	if r.Events != nil {
+		return validateWebhookEvents(r.Events)
+	}
+	return nil
+}
+
+func validateWebhookURL(rawURL string) error {
+	if rawURL == "" {
+		return errors.New("url is required")
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("url must be an absolute http or https url")
+	}
+	return nil
+}
+
+func validateWebhookEvents(events []string) error {
+	if len(events) == 0 {
+		return errors.New("events is required")
+	}
+	for _, event := range events {
+		if !slices.Contains(domain.WebhookEvents, event) {
+			return fmt.Errorf("invalid event %q", event)
+		}
+	}
+	return nil
+}
+
+// WebhookResponse carries the signing secret only when the webhook is
+// created, it can not be retrieved afterwards.
+type WebhookResponse struct {
+	ID             string     `json:"id,omitempty"`
+	ConversationID string     `json:"conversation_id,omitempty"`
+	CreatedBy      string     `json:"created_by,omitempty"`
+	URL            string     `json:"url,omitempty"`
+	Secret         string     `json:"secret,omitempty"`
+	Events         []string   `json:"events,omitempty"`
+	IsActive       bool       `json:"is_active"`
+	FailureCount   int        `json:"failure_count"`
+	DisabledAt     *time.Time `json:"disabled_at,omitempty"`
+	CreatedAt      *time.Time `json:"created_at,omitempty"`
+	UpdatedAt      *time.Time `json:"updated_at,omitempty"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID             string     `json:"id,omitempty"`
+	WebhookID      string     `json:"webhook_id,omitempty"`
+	Event          string     `json:"event,omitempty"`
+	Payload        string     `json:"payload,omitempty"`
+	Status         string     `json:"status,omitempty"`
+	Attempt        int        `json:"attempt"`
+	ResponseStatus int        `json:"response_status,omitempty"`
+	Error          string     `json:"error,omitempty"`
+	NextRetryAt    *time.Time `json:"next_retry_at,omitempty"`
+	CreatedAt      *time.Time `json:"created_at,omitempty"`
+	UpdatedAt      *time.Time `json:"updated_at,omitempty"`
+}
+
+type CreateIncomingWebhookRequest struct {
+	ConversationID string `json:"conversation_id,omitempty"`
+	Name           string `json:"name,omitempty"`
+	Avatar         string `json:"avatar,omitempty"`
+}
+
+func (r *CreateIncomingWebhookRequest) Validate() error {
+	if r.ConversationID == "" {
+		return errors.New("conversation_id is required")
+	}
+	return nil
+}
+
+// IncomingWebhookResponse carries the token only when the webhook is created,
+// it can not be retrieved afterwards.
+type IncomingWebhookResponse struct {
+	ID             string     `json:"id,omitempty"`
+	ConversationID string     `json:"conversation_id,omitempty"`
+	BotID          string     `json:"bot_id,omitempty"`
+	CreatedBy      string     `json:"created_by,omitempty"`
+	Name           string     `json:"name,omitempty"`
+	Prefix         string     `json:"prefix,omitempty"`
+	Token          string     `json:"token,omitempty"`
+	CreatedAt      *time.Time `json:"created_at,omitempty"`
+	LastUsedAt     *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+}
+
+type IncomingWebhookAttachment struct {
+	Type string `json:"type,omitempty"`
+	URL  string `json:"url,omitempty"`
+}
+
+type IncomingWebhookMessageRequest struct {
+	Text        string                       `json:"text,omitempty"`
+	Attachments []*IncomingWebhookAttachment `json:"attachments,omitempty"`
+	DisplayName string                       `json:"display_name,omitempty"`
+}
+
+const (
+	maxIncomingWebhookTextLength  = 4000
+	maxIncomingWebhookAttachments = 10
+	maxIncomingWebhookDisplayName = 80
+)
+
+var incomingWebhookAttachmentTypes = []string{
+	domain.MessageTypeImage,
+	domain.MessageTypeVideo,
+	domain.MessageTypeAudio,
+	domain.MessageTypeFile,
+}
+
+func (r *IncomingWebhookMessageRequest) Validate() error {
+	if r.Text == "" && len(r.Attachments) == 0 {
+		return errors.New("text or attachments is required")
+	}
+	if len([]rune(r.Text)) > maxIncomingWebhookTextLength {
+		return fmt.Errorf("text must be at most %d characters", maxIncomingWebhookTextLength)
+	}
+	if len([]rune(r.DisplayName)) > maxIncomingWebhookDisplayName {
+		return fmt.Errorf("display_name must be at most %d characters", maxIncomingWebhookDisplayName)
+	}
+	if len(r.Attachments) > maxIncomingWebhookAttachments {
+		return fmt.Errorf("at most %d attachments are allowed", maxIncomingWebhookAttachments)
+	}
+	for _, attachment := range r.Attachments {
+		if attachment == nil || !slices.Contains(incomingWebhookAttachmentTypes, attachment.Type) {
+			return errors.New("attachment type must be one of image, video, audio or file")
+		}
+		u, err := url.Parse(attachment.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.New("attachment url must be an absolute http or https url")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,277 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/internal/presenter"
+	"github.com/chat-socio/backend/internal/utils"
+	"github.com/chat-socio/backend/pkg/observability"
+	"github.com/chat-socio/backend/pkg/pointer"
+	"github.com/chat-socio/backend/pkg/uuid"
+	"github.com/chat-socio/backend/pubsub"
+	"github.com/jackc/pgx/v5"
+)
+
+// accountDeletionMessageBatchSize bounds how many messages are detached per
+// statement so a long history never holds one huge transaction.
+const accountDeletionMessageBatchSize = 500
+
+type AccountDeletionUseCase interface {
+	DeleteAccount(ctx context.Context) (*presenter.DeleteAccountResponse, error)
+	HandleDeleteAccount(ctx context.Context, data domain.AccountDeletionJob) error
+	ResumeAccountDeletionJobs(ctx context.Context) error
+}
+
+type accountDeletionUseCase struct {
+	accountRepository         domain.AccountRepository
+	userRepository            domain.UserRepository
+	sessionRepository         domain.SessionRepository
+	sessionCacheRepository    domain.SessionCacheRepository
+	botRepository             domain.BotRepository
+	apiKeyRepository          domain.ApiKeyRepository
+	fcmRepository             domain.FcmTokenRepository
+	contactRepository         domain.ContactRepository
+	userOnlineRepository      domain.UserOnlineRepository
+	seenMessageRepository     domain.SeenMessageRepository
+	messageRepository         domain.MessageRepository
+	accountDeletionRepository domain.AccountDeletionRepository
+	publisher                 pubsub.Publisher
+	obs                       *observability.Observability
+}
+
+// DeleteAccount implements AccountDeletionUseCase.
+func (a *accountDeletionUseCase) DeleteAccount(ctx context.Context) (*presenter.DeleteAccountResponse, error) {
+	ctx, span := a.obs.StartSpan(ctx, "AccountDeletionUseCase.DeleteAccount")
+	defer span()
+	logger := a.obs.Logger.WithContext(ctx)
+
+	accountID := ctx.Value(utils.AccountIDKey).(string)
+	user, err := a.userRepository.GetUserByAccountID(ctx, accountID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	if err == pgx.ErrNoRows {
+		return nil, ErrNotFoundAccount
+	}
+
+	job, err := a.accountDeletionRepository.GetAccountDeletionJobByUserID(ctx, user.ID)
+	if err != nil && err != pgx.ErrNoRows {
+		logger.Error("failed to get account deletion job", err)
+		return nil, err
+	}
+	if err == pgx.ErrNoRows {
+		id, err := uuid.NewID()
+		if err != nil {
+			return nil, err
+		}
+		job = &domain.AccountDeletionJob{
+			ID:        id,
+			UserID:    user.ID,
+			AccountID: accountID,
+			Status:    domain.AccountDeletionStatusPending,
+			Step:      domain.AccountDeletionSteps[0],
+			CreatedAt: pointer.ToPtr(time.Now()),
+			UpdatedAt: pointer.ToPtr(time.Now()),
+		}
+		err = a.accountDeletionRepository.CreateAccountDeletionJob(ctx, job)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if job.Status != domain.AccountDeletionStatusCompleted {
+		// Cut off access right away, the job repeats the session step to catch
+		// anything created in between.
+		err = a.deactivateAllSessions(ctx, accountID)
+		if err != nil {
+			logger.Error("failed to deactivate sessions", err)
+			return nil, err
+		}
+		err = a.accountRepository.AnonymizeAccount(ctx, accountID)
+		if err != nil {
+			logger.Error("failed to anonymize account", err)
+			return nil, err
+		}
+		err = a.publisher.Publish(ctx, domain.SUBJECT_DELETE_ACCOUNT, job)
+		if err != nil {
+			logger.Error("failed to publish account deletion job", err)
+			return nil, err
+		}
+	}
+
+	return &presenter.DeleteAccountResponse{
+		JobID:  job.ID,
+		Status: job.Status,
+		Step:   job.Step,
+	}, nil
+}
+
+// HandleDeleteAccount implements AccountDeletionUseCase.
+func (a *accountDeletionUseCase) HandleDeleteAccount(ctx context.Context, data domain.AccountDeletionJob) error {
+	logger := a.obs.Logger.WithContext(ctx)
+	job, err := a.accountDeletionRepository.GetAccountDeletionJobByID(ctx, data.ID)
+	if err != nil {
+		logger.Error("failed to get account deletion job", err, data)
+		return err
+	}
+	if job.Status == domain.AccountDeletionStatusCompleted {
+		return nil
+	}
+
+	index := slices.Index(domain.AccountDeletionSteps, job.Step)
+	if index < 0 {
+		index = 0
+	}
+	// the last step is the terminal "done" marker and has no work attached
+	for i := index; i < len(domain.AccountDeletionSteps)-1; i++ {
+		step := domain.AccountDeletionSteps[i]
+		err = a.runAccountDeletionStep(ctx, job, step)
+		if err != nil {
+			logger.Error("failed to run account deletion step", err, step, job)
+			return err
+		}
+
+		job.Step = domain.AccountDeletionSteps[i+1]
+		job.UpdatedAt = pointer.ToPtr(time.Now())
+		if job.Step == domain.AccountDeletionStepDone {
+			job.Status = domain.AccountDeletionStatusCompleted
+			job.CompletedAt = job.UpdatedAt
+		}
+		err = a.accountDeletionRepository.UpdateAccountDeletionJob(ctx, job)
+		if err != nil {
+			logger.Error("failed to update account deletion job", err, job)
+			return err
+		}
+	}
+	return nil
+}
+
+// ResumeAccountDeletionJobs implements AccountDeletionUseCase.
+// It republishes every unfinished job, jobs pick up from their recorded step.
+func (a *accountDeletionUseCase) ResumeAccountDeletionJobs(ctx context.Context) error {
+	logger := a.obs.Logger.WithContext(ctx)
+	jobs, err := a.accountDeletionRepository.GetListAccountDeletionJobByStatus(ctx, domain.AccountDeletionStatusPending)
+	if err != nil {
+		logger.Error("failed to get pending account deletion jobs", err)
+		return err
+	}
+	for _, job := range jobs {
+		err = a.publisher.Publish(ctx, domain.SUBJECT_DELETE_ACCOUNT, job)
+		if err != nil {
+			logger.Error("failed to publish account deletion job", err, job)
+			return err
+		}
+	}
+	return nil
+}
+
+// runAccountDeletionStep performs one step of the job. Every step is
+// idempotent so a redelivered job can safely repeat the step it stopped in.
+func (a *accountDeletionUseCase) runAccountDeletionStep(ctx context.Context, job *domain.AccountDeletionJob, step string) error {
+	switch step {
+	case domain.AccountDeletionStepSessions:
+		return a.deactivateAllSessions(ctx, job.AccountID)
+	case domain.AccountDeletionStepBots:
+		return a.deleteAllBots(ctx, job.UserID)
+	case domain.AccountDeletionStepFcmTokens:
+		return a.fcmRepository.DeleteFcmTokenByUserID(ctx, job.UserID)
+	case domain.AccountDeletionStepContacts:
+		err := a.contactRepository.DeleteContactsByUserID(ctx, job.UserID)
+		if err != nil {
+			return err
+		}
+		return a.contactRepository.DeletePendingRequestFriendByUserID(ctx, job.UserID)
+	case domain.AccountDeletionStepPresence:
+		err := a.userOnlineRepository.DeleteUserOnlineByUserID(ctx, job.UserID)
+		if err != nil {
+			return err
+		}
+		return a.seenMessageRepository.DeleteSeenMessageByUserID(ctx, job.UserID)
+	case domain.AccountDeletionStepMessages:
+		for {
+			affected, err := a.messageRepository.ReassignMessagesByUserID(ctx, job.UserID, domain.DeletedUserID, accountDeletionMessageBatchSize)
+			if err != nil {
+				return err
+			}
+			if affected == 0 {
+				return nil
+			}
+			job.ProcessedMessages += affected
+			job.UpdatedAt = pointer.ToPtr(time.Now())
+			err = a.accountDeletionRepository.UpdateAccountDeletionJob(ctx, job)
+			if err != nil {
+				return err
+			}
+		}
+	case domain.AccountDeletionStepProfile:
+		err := a.accountRepository.AnonymizeAccount(ctx, job.AccountID)
+		if err != nil {
+			return err
+		}
+		return a.userRepository.AnonymizeUser(ctx, job.UserID, time.Now())
+	}
+	return nil
+}
+
+func (a *accountDeletionUseCase) deactivateAllSessions(ctx context.Context, accountID string) error {
+	sessions, err := a.sessionRepository.GetListSessionByAccountID(ctx, accountID)
+	if err != nil {
+		return err
+	}
+	err = a.sessionRepository.DeactiveAllSessionByAccountID(ctx, accountID)
+	if err != nil {
+		return err
+	}
+	for _, session := range sessions {
+		err = a.sessionCacheRepository.DeleteSession(ctx, session.SessionToken)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteAllBots deletes the bots owned by the user and revokes their api keys
+// so nothing keeps acting on behalf of a deleted account.
+func (a *accountDeletionUseCase) deleteAllBots(ctx context.Context, userID string) error {
+	bots, err := a.botRepository.GetListBotByOwnerID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, bot := range bots {
+		err = a.apiKeyRepository.RevokeApiKeysByBotID(ctx, bot.ID, now)
+		if err != nil {
+			return err
+		}
+		err = a.botRepository.DeleteBot(ctx, bot.ID, now)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var _ AccountDeletionUseCase = (*accountDeletionUseCase)(nil)
+
+func NewAccountDeletionUseCase(accountRepository domain.AccountRepository, userRepository domain.UserRepository, sessionRepository domain.SessionRepository, sessionCacheRepository domain.SessionCacheRepository, botRepository domain.BotRepository, apiKeyRepository domain.ApiKeyRepository, fcmRepository domain.FcmTokenRepository, contactRepository domain.ContactRepository, userOnlineRepository domain.UserOnlineRepository, seenMessageRepository domain.SeenMessageRepository, messageRepository domain.MessageRepository, accountDeletionRepository domain.AccountDeletionRepository, publisher pubsub.Publisher, obs *observability.Observability) AccountDeletionUseCase {
+	return &accountDeletionUseCase{
+		accountRepository:         accountRepository,
+		userRepository:            userRepository,
+		sessionRepository:         sessionRepository,
+		sessionCacheRepository:    sessionCacheRepository,
+		botRepository:             botRepository,
+		apiKeyRepository:          apiKeyRepository,
+		fcmRepository:             fcmRepository,
+		contactRepository:         contactRepository,
+		userOnlineRepository:      userOnlineRepository,
+		seenMessageRepository:     seenMessageRepository,
+		messageRepository:         messageRepository,
+		accountDeletionRepository: accountDeletionRepository,
+		publisher:                 publisher,
+		obs:                       obs,
+	}
+}
@@ -0,0 +1,464 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/internal/presenter"
+	"github.com/chat-socio/backend/internal/utils"
+	"github.com/chat-socio/backend/pkg/hash"
+	"github.com/chat-socio/backend/pkg/observability"
+	"github.com/chat-socio/backend/pkg/pointer"
+	"github.com/chat-socio/backend/pkg/uuid"
+	"github.com/chat-socio/backend/pubsub"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	apiKeyPrefix       = "csk_"
+	apiKeyPrefixLength = 12
+)
+
+var (
+	ErrNotFoundBot          = errors.New("bot not found")
+	ErrNotFoundApiKey       = errors.New("api key not found")
+	ErrNotGroupConversation = errors.New("conversation is not a group")
+	ErrNotFoundBotCommand   = errors.New("bot command not found")
+)
+
+type BotUseCase interface {
+	CreateBot(ctx context.Context, request *presenter.CreateBotRequest) (*presenter.BotResponse, error)
+	GetListBot(ctx context.Context) ([]*presenter.BotResponse, error)
+	DeleteBot(ctx context.Context, botID string) error
+	CreateApiKey(ctx context.Context, request *presenter.CreateApiKeyRequest) (*presenter.ApiKeyResponse, error)
+	GetListApiKey(ctx context.Context, botID string) ([]*presenter.ApiKeyResponse, error)
+	RevokeApiKey(ctx context.Context, apiKeyID string) error
+	AddBotToConversation(ctx context.Context, request *presenter.AddBotToConversationRequest) error
+	CreateBotCommand(ctx context.Context, request *presenter.CreateBotCommandRequest) (*presenter.BotCommandResponse, error)
+	GetListBotCommand(ctx context.Context, botID string) ([]*presenter.BotCommandResponse, error)
+	DeleteBotCommand(ctx context.Context, botCommandID string) error
+}
+
+type botUseCase struct {
+	userRepository         domain.UserRepository
+	botRepository          domain.BotRepository
+	apiKeyRepository       domain.ApiKeyRepository
+	conversationRepository domain.ConversationRepository
+	botCommandRepository   domain.BotCommandRepository
+	publisher              pubsub.Publisher
+	obs                    *observability.Observability
+}
+
+// CreateBot implements BotUseCase.
+func (b *botUseCase) CreateBot(ctx context.Context, request *presenter.CreateBotRequest) (*presenter.BotResponse, error) {
+	ctx, span := b.obs.StartSpan(ctx, "BotUseCase.CreateBot")
+	defer span()
+
+	owner, err := b.getCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	account, user, bot, err := newBot(owner.ID, request.FullName, request.Avatar)
+	if err != nil {
+		return nil, err
+	}
+	err = b.botRepository.CreateBot(ctx, account, user, bot)
+	if err != nil {
+		return nil, err
+	}
+	return toBotResponse(bot), nil
+}
+
+// GetListBot implements BotUseCase.
+func (b *botUseCase) GetListBot(ctx context.Context) ([]*presenter.BotResponse, error) {
+	ctx, span := b.obs.StartSpan(ctx, "BotUseCase.GetListBot")
+	defer span()
+
+	owner, err := b.getCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bots, err := b.botRepository.GetListBotByOwnerID(ctx, owner.ID)
+	if err != nil {
+		return nil, err
+	}
+	responses := make([]*presenter.BotResponse, 0, len(bots))
+	for _, bot := range bots {
+		responses = append(responses, toBotResponse(bot))
+	}
+	return responses, nil
+}
+
+// DeleteBot implements BotUseCase.
+func (b *botUseCase) DeleteBot(ctx context.Context, botID string) error {
+	ctx, span := b.obs.StartSpan(ctx, "BotUseCase.DeleteBot")
+	defer span()
+
+	bot, err := b.getOwnedBot(ctx, botID)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	err = b.apiKeyRepository.RevokeApiKeysByBotID(ctx, bot.ID, now)
+	if err != nil {
+		return err
+	}
+	return b.botRepository.DeleteBot(ctx, bot.ID, now)
+}
+
+// CreateApiKey implements BotUseCase.
+func (b *botUseCase) CreateApiKey(ctx context.Context, request *presenter.CreateApiKeyRequest) (*presenter.ApiKeyResponse, error) {
+	ctx, span := b.obs.StartSpan(ctx, "BotUseCase.CreateApiKey")
+	defer span()
+
+	bot, err := b.getOwnedBot(ctx, request.BotID)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := generateApiKey()
+	if err != nil {
+		return nil, err
+	}
+	id, err := uuid.NewID()
+	if err != nil {
+		return nil, err
+	}
+	apiKey := &domain.ApiKey{
+		ID:        id,
+		BotID:     bot.ID,
+		Name:      request.Name,
+		Prefix:    key[:apiKeyPrefixLength],
+		KeyHash:   hash.HashToken(key),
+		Scopes:    request.Scopes,
+		CreatedAt: pointer.ToPtr(time.Now()),
+	}
+	err = b.apiKeyRepository.CreateApiKey(ctx, apiKey)
+	if err != nil {
+		return nil, err
+	}
+	response := toApiKeyResponse(apiKey)
+	response.Key = key
+	return response, nil
+}
+
+// GetListApiKey implements BotUseCase.
+func (b *botUseCase) GetListApiKey(ctx context.Context, botID string) ([]*presenter.ApiKeyResponse, error) {
+	ctx, span := b.obs.StartSpan(ctx, "BotUseCase.GetListApiKey")
+	defer span()
+
+	bot, err := b.getOwnedBot(ctx, botID)
+	if err != nil {
+		return nil, err
+	}
+	apiKeys, err := b.apiKeyRepository.GetListApiKeyByBotID(ctx, bot.ID)
+	if err != nil {
+		return nil, err
+	}
+	responses := make([]*presenter.ApiKeyResponse, 0, len(apiKeys))
+	for _, apiKey := range apiKeys {
+		responses = append(responses, toApiKeyResponse(apiKey))
+	}
+	return responses, nil
+}
+
+// RevokeApiKey implements BotUseCase.
+func (b *botUseCase) RevokeApiKey(ctx context.Context, apiKeyID string) error {
+	ctx, span := b.obs.StartSpan(ctx, "BotUseCase.RevokeApiKey")
+	defer span()
+
+	apiKey, err := b.apiKeyRepository.GetApiKeyByID(ctx, apiKeyID)
+	if err != nil && err != pgx.ErrNoRows {
+		return err
+	}
+	if err == pgx.ErrNoRows {
+		return ErrNotFoundApiKey
+	}
+	_, err = b.getOwnedBot(ctx, apiKey.BotID)
+	if err == ErrNotFoundBot {
+		return ErrNotFoundApiKey
+	}
+	if err != nil {
+		return err
+	}
+	return b.apiKeyRepository.RevokeApiKey(ctx, apiKey.ID, time.Now())
+}
+
+// AddBotToConversation implements BotUseCase.
+// Only the owner of the bot can add it, and only to a group they belong to.
+func (b *botUseCase) AddBotToConversation(ctx context.Context, request *presenter.AddBotToConversationRequest) error {
+	ctx, span := b.obs.StartSpan(ctx, "BotUseCase.AddBotToConversation")
+	defer span()
+
+	bot, err := b.getOwnedBot(ctx, request.BotID)
+	if err != nil {
+		return err
+	}
+
+	isMember, err := b.conversationRepository.CheckIsMemberOfConversation(ctx, bot.OwnerID, request.ConversationID)
+	if err != nil && err != pgx.ErrNoRows {
+		return err
+	}
+	if !isMember {
+		return domain.ErrNotFoundMemberOfConversation
+	}
+
+	conversation, _, err := b.conversationRepository.GetConversationByID(ctx, request.ConversationID)
+	if err != nil {
+		return err
+	}
+	if conversation.Type != domain.ConversationTypeGroup {
+		return ErrNotGroupConversation
+	}
+
+	isMember, err = b.conversationRepository.CheckIsMemberOfConversation(ctx, bot.ID, request.ConversationID)
+	if err != nil && err != pgx.ErrNoRows {
+		return err
+	}
+	if isMember {
+		return nil
+	}
+
+	memberID, err := uuid.NewID()
+	if err != nil {
+		return err
+	}
+	added, err := b.conversationRepository.AddConversationMember(ctx, &domain.ConversationMember{
+		ID:             memberID,
+		ConversationID: request.ConversationID,
+		UserID:         bot.ID,
+		Role:           domain.ConversationMemberRoleMember,
+		CreatedAt:      pointer.ToPtr(time.Now()),
+		UpdatedAt:      pointer.ToPtr(time.Now()),
+	})
+	if err != nil {
+		return err
+	}
+	if !added {
+		return nil
+	}
+
+	err = publishBotAdded(ctx, b.publisher, request.ConversationID, bot)
+	if err != nil {
+		b.obs.Logger.WithContext(ctx).Error("failed to publish member added event", err)
+	}
+	return nil
+}
+
+// newBot builds the account, the user info and the bot of a new bot owned by
+// ownerID.
+func newBot(ownerID string, fullName string, avatar string) (*domain.Account, *domain.UserInfo, *domain.Bot, error) {
+	botID, err := uuid.NewID()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	accountID, err := uuid.NewID()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	now := pointer.ToPtr(time.Now())
+	// the account has no password so a bot can never log in
+	account := &domain.Account{
+		ID:        accountID,
+		Username:  fmt.Sprintf("bot-%s", botID),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	user := &domain.UserInfo{
+		ID:        botID,
+		AccountID: accountID,
+		Type:      domain.InternalUserType,
+		Email:     fmt.Sprintf("bot-%s", botID),
+		FullName:  fullName,
+		Avatar:    avatar,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	bot := &domain.Bot{
+		ID:        botID,
+		OwnerID:   ownerID,
+		CreatedAt: now,
+		UpdatedAt: now,
+		User:      user,
+	}
+	return account, user, bot, nil
+}
+
+// publishBotAdded tells the conversation that the owner of the bot added it.
+func publishBotAdded(ctx context.Context, publisher pubsub.Publisher, conversationID string, bot *domain.Bot) error {
+	return publisher.Publish(ctx, domain.SUBJECT_NEW_MESSAGE, domain.NewWebSocketMessage(domain.WsMemberAdded, map[string]any{
+		"conversation_id": conversationID,
+		"user_id":         bot.ID,
+		"full_name":       bot.User.FullName,
+		"avatar":          bot.User.Avatar,
+		"user_type":       domain.InternalUserType,
+		"role":            domain.ConversationMemberRoleMember,
+		"added_by":        bot.OwnerID,
+	}))
+}
+
+// CreateBotCommand implements BotUseCase.
+// Registering an existing command again updates its description.
+func (b *botUseCase) CreateBotCommand(ctx context.Context, request *presenter.CreateBotCommandRequest) (*presenter.BotCommandResponse, error) {
+	ctx, span := b.obs.StartSpan(ctx, "BotUseCase.CreateBotCommand")
+	defer span()
+
+	bot, err := b.getOwnedBot(ctx, request.BotID)
+	if err != nil {
+		return nil, err
+	}
+	id, err := uuid.NewID()
+	if err != nil {
+		return nil, err
+	}
+	command := &domain.BotCommand{
+		ID:          id,
+		BotID:       bot.ID,
+		Command:     request.Command,
+		Description: request.Description,
+		CreatedAt:   pointer.ToPtr(time.Now()),
+	}
+	err = b.botCommandRepository.CreateBotCommand(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+	return toBotCommandResponse(command), nil
+}
+
+// GetListBotCommand implements BotUseCase.
+func (b *botUseCase) GetListBotCommand(ctx context.Context, botID string) ([]*presenter.BotCommandResponse, error) {
+	ctx, span := b.obs.StartSpan(ctx, "BotUseCase.GetListBotCommand")
+	defer span()
+
+	bot, err := b.getOwnedBot(ctx, botID)
+	if err != nil {
+		return nil, err
+	}
+	commands, err := b.botCommandRepository.GetListBotCommandByBotID(ctx, bot.ID)
+	if err != nil {
+		return nil, err
+	}
+	responses := make([]*presenter.BotCommandResponse, 0, len(commands))
+	for _, command := range commands {
+		responses = append(responses, toBotCommandResponse(command))
+	}
+	return responses, nil
+}
+
+// DeleteBotCommand implements BotUseCase.
+func (b *botUseCase) DeleteBotCommand(ctx context.Context, botCommandID string) error {
+	ctx, span := b.obs.StartSpan(ctx, "BotUseCase.DeleteBotCommand")
+	defer span()
+
+	command, err := b.botCommandRepository.GetBotCommandByID(ctx, botCommandID)
+	if err != nil && err != pgx.ErrNoRows {
+		return err
+	}
+	if err == pgx.ErrNoRows {
+		return ErrNotFoundBotCommand
+	}
+	_, err = b.getOwnedBot(ctx, command.BotID)
+	if err == ErrNotFoundBot {
+		return ErrNotFoundBotCommand
+	}
+	if err != nil {
+		return err
+	}
+	return b.botCommandRepository.DeleteBotCommand(ctx, command.ID)
+}
+
+func (b *botUseCase) getCurrentUser(ctx context.Context) (*domain.UserInfo, error) {
+	accountID := ctx.Value(utils.AccountIDKey).(string)
+	user, err := b.userRepository.GetUserByAccountID(ctx, accountID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	if err == pgx.ErrNoRows {
+		return nil, ErrNotFoundAccount
+	}
+	return user, nil
+}
+
+// getOwnedBot returns the bot when it belongs to the current user, bots of
+// other users are reported as not found.
+func (b *botUseCase) getOwnedBot(ctx context.Context, botID string) (*domain.Bot, error) {
+	owner, err := b.getCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bot, err := b.botRepository.GetBotByID(ctx, botID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	if err == pgx.ErrNoRows || bot.OwnerID != owner.ID {
+		return nil, ErrNotFoundBot
+	}
+	return bot, nil
+}
+
+func generateApiKey() (string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return apiKeyPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func toBotResponse(bot *domain.Bot) *presenter.BotResponse {
+	response := &presenter.BotResponse{
+		BotID:     bot.ID,
+		OwnerID:   bot.OwnerID,
+		UserType:  domain.InternalUserType,
+		CreatedAt: bot.CreatedAt,
+	}
+	if bot.User != nil {
+		response.FullName = bot.User.FullName
+		response.Avatar = bot.User.Avatar
+	}
+	return response
+}
+
+func toApiKeyResponse(apiKey *domain.ApiKey) *presenter.ApiKeyResponse {
+	return &presenter.ApiKeyResponse{
+		ID:         apiKey.ID,
+		BotID:      apiKey.BotID,
+		Name:       apiKey.Name,
+		Prefix:     apiKey.Prefix,
+		Scopes:     apiKey.Scopes,
+		CreatedAt:  apiKey.CreatedAt,
+		LastUsedAt: apiKey.LastUsedAt,
+		RevokedAt:  apiKey.RevokedAt,
+	}
+}
+
+func toBotCommandResponse(command *domain.BotCommand) *presenter.BotCommandResponse {
+	return &presenter.BotCommandResponse{
+		ID:          command.ID,
+		BotID:       command.BotID,
+		Command:     command.Command,
+		Description: command.Description,
+		Subject:     domain.BotCommandSubject(command.BotID),
+		CreatedAt:   command.CreatedAt,
+	}
+}
+
+var _ BotUseCase = (*botUseCase)(nil)
+
+func NewBotUseCase(userRepository domain.UserRepository, botRepository domain.BotRepository, apiKeyRepository domain.ApiKeyRepository, conversationRepository domain.ConversationRepository, botCommandRepository domain.BotCommandRepository, publisher pubsub.Publisher, obs *observability.Observability) BotUseCase {
+	return &botUseCase{
+		userRepository:         userRepository,
+		botRepository:          botRepository,
+		apiKeyRepository:       apiKeyRepository,
+		conversationRepository: conversationRepository,
+		botCommandRepository:   botCommandRepository,
+		publisher:              publisher,
+		obs:                    obs,
+	}
+}
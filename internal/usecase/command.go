@@ -0,0 +1,279 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/internal/presenter"
+	"github.com/chat-socio/backend/pkg/pointer"
+	"github.com/chat-socio/backend/pkg/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	shrug = `¯\_(ツ)_/¯`
+
+	maxMuteDuration = 365 * 24 * time.Hour
+	// bots can reply to an invocation until it expires
+	botCommandInvocationTTL = 15 * time.Minute
+)
+
+var (
+	ErrUnknownCommand     = errors.New("unknown command")
+	ErrInvalidCommandArgs = errors.New("invalid command arguments")
+)
+
+// commandFunc runs a slash command for the message that invoked it. Commands
+// either send a message of their own or answer with an ephemeral response.
+type commandFunc func(ctx context.Context, message *presenter.SendMessageRequest, args string) (*presenter.MessageResponse, error)
+
+func (c *conversationUseCase) builtinCommands() map[string]commandFunc {
+	return map[string]commandFunc{
+		domain.CommandMe:    c.commandMe,
+		domain.CommandShrug: c.commandShrug,
+		domain.CommandPoll:  c.commandPoll,
+		domain.CommandMute:  c.commandMute,
+	}
+}
+
+// executeCommand runs the built-in command of the message body, or dispatches
+// it to the bot of the conversation that registered it.
+func (c *conversationUseCase) executeCommand(ctx context.Context, message *presenter.SendMessageRequest) (*presenter.MessageResponse, error) {
+	body := strings.TrimPrefix(message.Body, "/")
+	name, args := body, ""
+	if i := strings.IndexFunc(body, unicode.IsSpace); i >= 0 {
+		name, args = body[:i], strings.TrimSpace(body[i:])
+	}
+	name = strings.ToLower(name)
+
+	if command, ok := c.commands[name]; ok {
+		return command(ctx, message, args)
+	}
+
+	botCommand, err := c.botCommandRepository.GetBotCommandInConversation(ctx, message.ConversationID, name)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	if err == pgx.ErrNoRows {
+		return nil, ErrUnknownCommand
+	}
+	return c.dispatchBotCommand(ctx, message, botCommand, args)
+}
+
+func (c *conversationUseCase) commandMe(ctx context.Context, message *presenter.SendMessageRequest, args string) (*presenter.MessageResponse, error) {
+	if args == "" {
+		return nil, fmt.Errorf("%w: usage /me <text>", ErrInvalidCommandArgs)
+	}
+	message.Type = domain.MessageTypeAction
+	message.Body = args
+	return c.sendMessage(ctx, message)
+}
+
+func (c *conversationUseCase) commandShrug(ctx context.Context, message *presenter.SendMessageRequest, args string) (*presenter.MessageResponse, error) {
+	message.Type = domain.MessageTypeText
+	message.Body = strings.TrimSpace(args + " " + shrug)
+	return c.sendMessage(ctx, message)
+}
+
+// commandPoll creates a poll from quoted arguments:
+// /poll "Where do we eat?" "Pizza" "Sushi"
+func (c *conversationUseCase) commandPoll(ctx context.Context, message *presenter.SendMessageRequest, args string) (*presenter.MessageResponse, error) {
+	parts, err := splitCommandArgs(args)
+	if err != nil || len(parts) < 3 || len(parts) > domain.MaxPollOptions+1 {
+		return nil, fmt.Errorf(`%w: usage /poll "question" "option 1" "option 2" ... (up to %d options)`, ErrInvalidCommandArgs, domain.MaxPollOptions)
+	}
+	poll := domain.PollContent{Question: parts[0]}
+	for i, option := range parts[1:] {
+		poll.Options = append(poll.Options, &domain.PollOption{
+			ID:   strconv.Itoa(i + 1),
+			Text: option,
+		})
+	}
+	body, err := json.Marshal(poll)
+	if err != nil {
+		return nil, err
+	}
+	message.Type = domain.MessageTypePoll
+	message.Body = string(body)
+	return c.sendMessage(ctx, message)
+}
+
+// commandMute mutes the notifications of the conversation for the sender,
+// "/mute off" unmutes it.
+func (c *conversationUseCase) commandMute(ctx context.Context, message *presenter.SendMessageRequest, args string) (*presenter.MessageResponse, error) {
+	if strings.EqualFold(args, "off") {
+		err := c.conversationRepository.UpdateConversationMemberMutedUntil(ctx, message.ConversationID, message.UserID, nil)
+		if err != nil {
+			return nil, err
+		}
+		return ephemeralResponse(message.ConversationID, "Notifications are unmuted"), nil
+	}
+
+	duration, err := parseMuteDuration(args)
+	if err != nil {
+		return nil, fmt.Errorf("%w: usage /mute <duration> such as 30m, 8h, 1d, 1w or /mute off", ErrInvalidCommandArgs)
+	}
+	mutedUntil := time.Now().Add(duration)
+	err = c.conversationRepository.UpdateConversationMemberMutedUntil(ctx, message.ConversationID, message.UserID, &mutedUntil)
+	if err != nil {
+		return nil, err
+	}
+	return ephemeralResponse(message.ConversationID, fmt.Sprintf("Notifications are muted until %s", mutedUntil.Format(time.RFC3339))), nil
+}
+
+// dispatchBotCommand publishes the invocation to the bot, its reply comes
+// back asynchronously through HandleBotCommandReply.
+func (c *conversationUseCase) dispatchBotCommand(ctx context.Context, message *presenter.SendMessageRequest, botCommand *domain.BotCommand, args string) (*presenter.MessageResponse, error) {
+	id, err := uuid.NewID()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	invocation := &domain.BotCommandInvocation{
+		ID:             id,
+		BotID:          botCommand.BotID,
+		Command:        botCommand.Command,
+		Args:           args,
+		ConversationID: message.ConversationID,
+		UserID:         message.UserID,
+		CreatedAt:      &now,
+		ExpiresAt:      pointer.ToPtr(now.Add(botCommandInvocationTTL)),
+	}
+	err = c.botCommandRepository.CreateBotCommandInvocation(ctx, invocation)
+	if err != nil {
+		return nil, err
+	}
+	err = c.messagePublisher.Publish(ctx, domain.BotCommandSubject(botCommand.BotID), invocation)
+	if err != nil {
+		return nil, err
+	}
+	return ephemeralResponse(message.ConversationID, strings.TrimSpace(fmt.Sprintf("/%s %s", botCommand.Command, args))), nil
+}
+
+// HandleBotCommandReply implements ConversationUseCase.
+func (c *conversationUseCase) HandleBotCommandReply(ctx context.Context, reply domain.BotCommandReply) error {
+	ctx, span := c.obs.StartSpan(ctx, "ConversationUsecase.HandleBotCommandReply")
+	defer span()
+	logger := c.obs.Logger.WithContext(ctx)
+
+	invocation, err := c.botCommandRepository.GetBotCommandInvocationByID(ctx, reply.InvocationID)
+	if err != nil && err != pgx.ErrNoRows {
+		return err
+	}
+	if err == pgx.ErrNoRows || invocation.BotID != reply.BotID {
+		logger.Error("bot command reply does not match an invocation", reply)
+		return nil
+	}
+	if invocation.ExpiresAt.Before(time.Now()) || reply.Body == "" {
+		return nil
+	}
+	if reply.Type == "" {
+		reply.Type = domain.MessageTypeText
+	}
+
+	if reply.Ephemeral {
+		return c.messagePublisher.Publish(ctx, domain.SUBJECT_NEW_MESSAGE, domain.NewWebSocketMessage(domain.WsEphemeralMessage, map[string]any{
+			"user_id":         invocation.UserID,
+			"conversation_id": invocation.ConversationID,
+			"bot_id":          invocation.BotID,
+			"invocation_id":   invocation.ID,
+			"type":            reply.Type,
+			"body":            reply.Body,
+			"created_at":      time.Now(),
+		}))
+	}
+
+	_, err = c.SendMessage(ctx, &presenter.SendMessageRequest{
+		ConversationID: invocation.ConversationID,
+		UserID:         invocation.BotID,
+		Type:           reply.Type,
+		Body:           reply.Body,
+		IsBot:          true,
+	})
+	if err == domain.ErrNotFoundMemberOfConversation {
+		logger.Error("bot is no longer a member of the conversation", err, invocation)
+		return nil
+	}
+	return err
+}
+
+func ephemeralResponse(conversationID string, body string) *presenter.MessageResponse {
+	return &presenter.MessageResponse{
+		ConversationID: conversationID,
+		Type:           domain.MessageTypeSystem,
+		Body:           body,
+		CreatedAt:      pointer.ToPtr(time.Now()),
+		Ephemeral:      true,
+	}
+}
+
+// parseMuteDuration accepts time.ParseDuration values plus days and weeks.
+func parseMuteDuration(value string) (time.Duration, error) {
+	var duration time.Duration
+	var err error
+	switch {
+	case strings.HasSuffix(value, "d"), strings.HasSuffix(value, "w"):
+		unit := 24 * time.Hour
+		if strings.HasSuffix(value, "w") {
+			unit = 7 * 24 * time.Hour
+		}
+		var n int
+		n, err = strconv.Atoi(value[:len(value)-1])
+		// checked before multiplying so a huge count can not wrap around
+		if err == nil && (n <= 0 || n > int(maxMuteDuration/unit)) {
+			return 0, errors.New("duration out of range")
+		}
+		duration = time.Duration(n) * unit
+	default:
+		duration, err = time.ParseDuration(value)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if duration <= 0 || duration > maxMuteDuration {
+		return 0, errors.New("duration out of range")
+	}
+	return duration, nil
+}
+
+// splitCommandArgs splits arguments on spaces, double quotes group words.
+func splitCommandArgs(args string) ([]string, error) {
+	var parts []string
+	var current strings.Builder
+	inQuotes, hasPart := false, false
+	for _, r := range args {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			hasPart = true
+		case unicode.IsSpace(r) && !inQuotes:
+			if hasPart {
+				parts = append(parts, current.String())
+				current.Reset()
+				hasPart = false
+			}
+		default:
+			current.WriteRune(r)
+			hasPart = true
+		}
+	}
+	if inQuotes {
+		return nil, errors.New("unterminated quote")
+	}
+	if hasPart {
+		parts = append(parts, current.String())
+	}
+	for _, part := range parts {
+		if strings.TrimSpace(part) == "" {
+			return nil, errors.New("empty argument")
+		}
+	}
+	return parts, nil
+}
@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/internal/presenter"
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrInvalidContact = errors.New("invalid contact")
+
+// normalizeContactBody checks that the contact card points to a user who can
+// be shared and strips everything but the user id from the body.
+func (c *conversationUseCase) normalizeContactBody(ctx context.Context, body string) (string, error) {
+	card, err := domain.ParseContactCardContent(body)
+	if err != nil {
+		return "", fmt.Errorf("%w: body must be a json contact", ErrInvalidContact)
+	}
+	card.UserID = strings.TrimSpace(card.UserID)
+	if card.UserID == "" {
+		return "", fmt.Errorf("%w: user_id is required", ErrInvalidContact)
+	}
+	user, err := c.userRepository.GetUserByID(ctx, card.UserID)
+	if err != nil && err != pgx.ErrNoRows {
+		return "", err
+	}
+	// bots and deleted accounts are hidden from user search, they can not be
+	// shared either
+	if err == pgx.ErrNoRows || user.DeletedAt != nil || user.Type != domain.ExternalUserType {
+		return "", fmt.Errorf("%w: user not found", ErrInvalidContact)
+	}
+	normalized, err := json.Marshal(domain.ContactCardContent{UserID: card.UserID})
+	if err != nil {
+		return "", err
+	}
+	return string(normalized), nil
+}
+
+// getContactCards resolves the contact cards of the messages for the viewer,
+// keyed by the user id of the card.
+func (c *conversationUseCase) getContactCards(ctx context.Context, viewerID string, messages []*domain.Message) (map[string]*presenter.ContactCardResponse, error) {
+	var userIDs []string
+	for _, message := range messages {
+		if message.Type != domain.MessageTypeContact || message.DeletedAt != nil {
+			continue
+		}
+		card, err := domain.ParseContactCardContent(message.Body)
+		if err != nil || card.UserID == "" {
+			continue
+		}
+		userIDs = append(userIDs, card.UserID)
+	}
+	cards := make(map[string]*presenter.ContactCardResponse, len(userIDs))
+	if len(userIDs) == 0 {
+		return cards, nil
+	}
+
+	users, err := c.userRepository.GetListUserByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	relations, err := c.contactRepository.GetListContactRelation(ctx, viewerID, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	relationByUserID := make(map[string]*domain.ContactRelation, len(relations))
+	for _, relation := range relations {
+		relationByUserID[relation.UserID] = relation
+	}
+
+	for _, userID := range userIDs {
+		cards[userID] = &presenter.ContactCardResponse{UserID: userID}
+	}
+	for _, user := range users {
+		if user.DeletedAt != nil || user.Type != domain.ExternalUserType {
+			continue
+		}
+		cards[user.ID] = &presenter.ContactCardResponse{
+			UserID:    user.ID,
+			FullName:  user.FullName,
+			Avatar:    user.Avatar,
+			Available: true,
+			Actions:   contactCardActions(viewerID, user.ID, relationByUserID[user.ID]),
+		}
+	}
+	return cards, nil
+}
+
+func contactCardActions(viewerID string, userID string, relation *domain.ContactRelation) []*presenter.ContactCardActionResponse {
+	if viewerID == userID || relation == nil {
+		return nil
+	}
+	var actions []*presenter.ContactCardActionResponse
+	if relation.DMConversationID != nil {
+		actions = append(actions, &presenter.ContactCardActionResponse{
+			Type:           domain.ContactCardActionOpenDM,
+			ConversationID: *relation.DMConversationID,
+		})
+	} else {
+		actions = append(actions, &presenter.ContactCardActionResponse{
+			Type:   domain.ContactCardActionStartDM,
+			UserID: userID,
+		})
+	}
+	if !relation.IsContact && !relation.HasPendingRequest {
+		actions = append(actions, &presenter.ContactCardActionResponse{
+			Type:   domain.ContactCardActionSendFriendRequest,
+			UserID: userID,
+		})
+	}
+	return actions
+}
+
+// contactCard returns the resolved card of a contact message.
+func contactCard(message *domain.Message, cards map[string]*presenter.ContactCardResponse) *presenter.ContactCardResponse {
+	if message.Type != domain.MessageTypeContact || message.DeletedAt != nil {
+		return nil
+	}
+	card, err := domain.ParseContactCardContent(message.Body)
+	if err != nil {
+		return nil
+	}
+	return cards[card.UserID]
+}
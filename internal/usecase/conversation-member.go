@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/internal/presenter"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrNotFoundMember     = errors.New("member not found")
+	ErrOwnerCanNotLeave   = errors.New("the owner can not leave the conversation")
+	ErrCanNotRemoveMember = errors.New("member can not be removed")
+)
+
+// RemoveConversationMember implements ConversationUseCase.
+// Members can leave a group on their own, except for its owner. Admins can
+// remove members and only the owner can remove admins.
+func (c *conversationUseCase) RemoveConversationMember(ctx context.Context, userID string, conversationID string, memberID string) error {
+	ctx, span := c.obs.StartSpan(ctx, "ConversationUsecase.RemoveConversationMember")
+	defer span()
+	logger := c.obs.Logger.WithContext(ctx)
+
+	actor, err := c.getConversationMember(ctx, conversationID, userID)
+	if err != nil {
+		return err
+	}
+	conversation, _, err := c.conversationRepository.GetConversationByID(ctx, conversationID)
+	if err != nil {
+		return err
+	}
+	if conversation.Type != domain.ConversationTypeGroup {
+		return ErrNotGroupConversation
+	}
+
+	action := domain.SystemActionMemberLeft
+	if memberID == userID {
+		if actor.Role == domain.ConversationMemberRoleOwner {
+			return ErrOwnerCanNotLeave
+		}
+	} else {
+		if !actor.IsAdmin() {
+			return domain.ErrNotAdminOfConversation
+		}
+		member, err := c.conversationRepository.GetConversationMember(ctx, conversationID, memberID)
+		if err != nil && err != pgx.ErrNoRows {
+			return err
+		}
+		if err == pgx.ErrNoRows {
+			return ErrNotFoundMember
+		}
+		if member.IsAdmin() && actor.Role != domain.ConversationMemberRoleOwner {
+			return ErrCanNotRemoveMember
+		}
+		action = domain.SystemActionMemberRemoved
+	}
+
+	removed, err := c.conversationRepository.DeleteConversationMember(ctx, conversationID, memberID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return nil
+	}
+	// a member who left can no longer send, so the membership check of
+	// SendMessage is skipped
+	_, err = c.sendMessage(ctx, &presenter.SendMessageRequest{
+		ConversationID: conversationID,
+		UserID:         userID,
+		Type:           domain.MessageTypeSystem,
+		Body:           domain.SystemMessageContent{Action: action, UserID: memberID}.String(),
+	})
+	if err != nil {
+		logger.Error("failed to send member removed system message", err, conversationID, memberID)
+	}
+	err = c.messagePublisher.Publish(ctx, domain.SUBJECT_NEW_MESSAGE, domain.NewWebSocketMessage(domain.WsMemberRemoved, map[string]any{
+		"conversation_id": conversationID,
+		"user_id":         memberID,
+		"removed_by":      userID,
+	}))
+	if err != nil {
+		logger.Error("failed to publish member removed event", err, conversationID, memberID)
+	}
+	return nil
+}
+
+// handleSendEventMemberRemoved tells the conversation and the removed member,
+// who is no longer reached through the conversation.
+func (c *conversationUseCase) handleSendEventMemberRemoved(ctx context.Context, message *domain.WebSocketMessage) error {
+	err := c.handleSendEventNewMessage(ctx, message)
+	if err != nil {
+		return err
+	}
+	return c.handleSendEventToUser(ctx, message)
+}
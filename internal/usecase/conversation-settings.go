@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/internal/presenter"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrInvalidMuteDuration          = errors.New("invalid mute_duration, use a duration such as 30m, 8h, 1d, 1w or off")
+	ErrTooManyPinnedConversations   = errors.New("too many pinned conversations")
+	ErrInvalidPinnedConversationIDs = errors.New("conversation_ids must list every pinned conversation")
+)
+
+// UpdateConversationSettings implements ConversationUseCase.
+// Archiving a conversation unpins it and pinning an archived conversation
+// moves it back to the main list.
+func (c *conversationUseCase) UpdateConversationSettings(ctx context.Context, userID string, request *presenter.UpdateConversationSettingsRequest) (*presenter.ConversationSettingsResponse, error) {
+	ctx, span := c.obs.StartSpan(ctx, "ConversationUsecase.UpdateConversationSettings")
+	defer span()
+
+	member, err := c.getConversationMember(ctx, request.ConversationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.MuteDuration != "" {
+		var mutedUntil *time.Time
+		if !strings.EqualFold(request.MuteDuration, "off") {
+			duration, err := parseMuteDuration(request.MuteDuration)
+			if err != nil {
+				return nil, ErrInvalidMuteDuration
+			}
+			until := time.Now().Add(duration)
+			mutedUntil = &until
+		}
+		err = c.conversationRepository.UpdateConversationMemberMutedUntil(ctx, request.ConversationID, userID, mutedUntil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	archived := member.IsArchived()
+	if request.Archived != nil {
+		archived = *request.Archived
+	}
+	pinned := member.IsPinned()
+	if request.Pinned != nil {
+		pinned = *request.Pinned
+		if pinned {
+			archived = false
+		}
+	}
+	if archived {
+		pinned = false
+	}
+
+	if pinned != member.IsPinned() {
+		conversationIDs, err := c.conversationRepository.GetListPinnedConversationID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if pinned {
+			if len(conversationIDs) >= domain.MaxPinnedConversations {
+				return nil, ErrTooManyPinnedConversations
+			}
+			conversationIDs = append(conversationIDs, request.ConversationID)
+		} else {
+			conversationIDs = slices.DeleteFunc(conversationIDs, func(id string) bool {
+				return id == request.ConversationID
+			})
+		}
+		err = c.conversationRepository.UpdatePinnedConversationOrder(ctx, userID, conversationIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if archived != member.IsArchived() {
+		var archivedAt *time.Time
+		if archived {
+			now := time.Now()
+			archivedAt = &now
+		}
+		err = c.conversationRepository.UpdateConversationMemberArchivedAt(ctx, request.ConversationID, userID, archivedAt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	member, err = c.getConversationMember(ctx, request.ConversationID, userID)
+	if err != nil {
+		return nil, err
+	}
+	response := &presenter.ConversationSettingsResponse{
+		ConversationID: member.ConversationID,
+		MutedUntil:     member.MutedUntil,
+		Archived:       member.IsArchived(),
+		Pinned:         member.IsPinned(),
+	}
+	c.publishConversationSettingsUpdated(ctx, userID, response)
+	return response, nil
+}
+
+// ReorderPinnedConversations implements ConversationUseCase.
+// The request must list exactly the conversations the user has pinned.
+func (c *conversationUseCase) ReorderPinnedConversations(ctx context.Context, userID string, request *presenter.ReorderPinnedConversationRequest) (*presenter.PinnedConversationResponse, error) {
+	ctx, span := c.obs.StartSpan(ctx, "ConversationUsecase.ReorderPinnedConversations")
+	defer span()
+
+	conversationIDs, err := c.conversationRepository.GetListPinnedConversationID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(conversationIDs) != len(request.ConversationIDs) {
+		return nil, ErrInvalidPinnedConversationIDs
+	}
+	for _, conversationID := range request.ConversationIDs {
+		if !slices.Contains(conversationIDs, conversationID) {
+			return nil, ErrInvalidPinnedConversationIDs
+		}
+	}
+
+	err = c.conversationRepository.UpdatePinnedConversationOrder(ctx, userID, request.ConversationIDs)
+	if err != nil {
+		return nil, err
+	}
+	err = c.messagePublisher.Publish(ctx, domain.SUBJECT_NEW_MESSAGE, domain.NewWebSocketMessage(domain.WsConversationSettingsUpdated, map[string]any{
+		"user_id":                 userID,
+		"pinned_conversation_ids": request.ConversationIDs,
+	}))
+	if err != nil {
+		c.obs.Logger.WithContext(ctx).Error("failed to publish conversation settings updated", err, request)
+	}
+	return &presenter.PinnedConversationResponse{ConversationIDs: request.ConversationIDs}, nil
+}
+
+func (c *conversationUseCase) getConversationMember(ctx context.Context, conversationID string, userID string) (*domain.ConversationMember, error) {
+	member, err := c.conversationRepository.GetConversationMember(ctx, conversationID, userID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	if err == pgx.ErrNoRows {
+		return nil, domain.ErrNotFoundMemberOfConversation
+	}
+	return member, nil
+}
+
+// publishConversationSettingsUpdated keeps the conversation list of the other
+// devices of the user in sync, errors are only logged since the settings are
+// already stored.
+func (c *conversationUseCase) publishConversationSettingsUpdated(ctx context.Context, userID string, settings *presenter.ConversationSettingsResponse) {
+	err := c.messagePublisher.Publish(ctx, domain.SUBJECT_NEW_MESSAGE, domain.NewWebSocketMessage(domain.WsConversationSettingsUpdated, map[string]any{
+		"user_id":         userID,
+		"conversation_id": settings.ConversationID,
+		"muted_until":     settings.MutedUntil,
+		"archived":        settings.Archived,
+		"pinned":          settings.Pinned,
+	}))
+	if err != nil {
+		c.obs.Logger.WithContext(ctx).Error("failed to publish conversation settings updated", err, settings)
+	}
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/internal/presenter"
+)
+
+var ErrInvalidAvatarURL = errors.New("avatar must be a file uploaded to our storage")
+
+// UpdateConversation implements ConversationUseCase.
+// Only the owners and admins of a group can change its title and avatar,
+// every change is announced with a system message.
+func (c *conversationUseCase) UpdateConversation(ctx context.Context, userID string, request *presenter.UpdateConversationRequest) (*presenter.ConversationResponse, error) {
+	ctx, span := c.obs.StartSpan(ctx, "ConversationUsecase.UpdateConversation")
+	defer span()
+	logger := c.obs.Logger.WithContext(ctx)
+
+	err := checkCanManageConversation(ctx, c.conversationRepository, userID, request.ConversationID)
+	if err != nil {
+		return nil, err
+	}
+	conversation, _, err := c.conversationRepository.GetConversationByID(ctx, request.ConversationID)
+	if err != nil {
+		return nil, err
+	}
+	if conversation.Type != domain.ConversationTypeGroup {
+		return nil, ErrNotGroupConversation
+	}
+
+	var systemMessages []domain.SystemMessageContent
+	title, avatar := conversation.Title, conversation.Avatar
+	if request.Title != nil && *request.Title != title {
+		title = *request.Title
+		systemMessages = append(systemMessages, domain.SystemMessageContent{Action: domain.SystemActionTitleUpdated, Title: title})
+	}
+	if request.Avatar != nil && *request.Avatar != avatar {
+		if _, _, ok := uploadedObject(*request.Avatar); *request.Avatar != "" && !ok {
+			return nil, ErrInvalidAvatarURL
+		}
+		avatar = *request.Avatar
+		systemMessages = append(systemMessages, domain.SystemMessageContent{Action: domain.SystemActionAvatarUpdated, Avatar: avatar})
+	}
+	if len(systemMessages) == 0 {
+		return c.GetConversationByID(ctx, request.ConversationID)
+	}
+
+	err = c.conversationRepository.UpdateConversationInfo(ctx, request.ConversationID, title, avatar)
+	if err != nil {
+		return nil, err
+	}
+	for _, systemMessage := range systemMessages {
+		_, err = c.SendMessage(ctx, &presenter.SendMessageRequest{
+			ConversationID: request.ConversationID,
+			UserID:         userID,
+			Type:           domain.MessageTypeSystem,
+			Body:           systemMessage.String(),
+		})
+		if err != nil {
+			logger.Error("failed to send conversation updated system message", err, request)
+		}
+	}
+	err = c.messagePublisher.Publish(ctx, domain.SUBJECT_NEW_MESSAGE, domain.NewWebSocketMessage(domain.WsConversationUpdated, map[string]any{
+		"conversation_id": request.ConversationID,
+		"title":           title,
+		"avatar":          avatar,
+		"user_id":         userID,
+	}))
+	if err != nil {
+		logger.Error("failed to publish conversation updated", err, request)
+	}
+	return c.GetConversationByID(ctx, request.ConversationID)
+}
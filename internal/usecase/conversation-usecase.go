@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"encoding/json"
+	"slices"
 	"strings"
 	"time"
 
@@ -17,29 +18,55 @@ import (
 )
 
 type ConversationUseCase interface {
-	GetListConversationByUserID(ctx context.Context, userID string, lastMessageID string, limit int) ([]*presenter.GetListConversationResponse, error)
+	GetListConversationByUserID(ctx context.Context, userID string, lastMessageID string, limit int, archived bool) ([]*presenter.GetListConversationResponse, error)
 	GetConversationByID(ctx context.Context, conversationID string) (*presenter.ConversationResponse, error)
 	GetListMessageByConversationID(ctx context.Context, userID string, conversationID string, lastMessageID string, limit int) ([]*presenter.MessageResponse, error)
+	GetMessagePage(ctx context.Context, userID string, request *presenter.GetMessagePageRequest) (*presenter.MessagePageResponse, error)
 	CreateConversation(ctx context.Context, conversation *presenter.CreateConversationRequest) (*presenter.ConversationResponse, error)
 	SendMessage(ctx context.Context, message *presenter.SendMessageRequest) (*presenter.MessageResponse, error)
 	HandleNewMessage(ctx context.Context, message *domain.WebSocketMessage) error
 	HandleUpdateLastMessageID(ctx context.Context, data domain.UpdateLastMessageID) error
 	SeenMessage(ctx context.Context, messageID string, userID string, conversationID string) error
-	GetListSeenMessageByConversationID(ctx context.Context, conversationID string) ([]*presenter.SeenMessageResponse, error)
+	GetListSeenMessageByConversationID(ctx context.Context, userID string, conversationID string) ([]*presenter.SeenMessageResponse, error)
+	GetListSeenByMessageID(ctx context.Context, userID string, messageID string) ([]*presenter.SeenMessageResponse, error)
 	HandleSeenMessage(ctx context.Context, message *domain.SeenMessage) error
 	HandleSendMessageToFCM(ctx context.Context, message *domain.Message) error
+	HandleBotCommandReply(ctx context.Context, reply domain.BotCommandReply) error
+	GetMessageThread(ctx context.Context, userID string, rootID string, lastID string, limit int) (*presenter.MessageThreadResponse, error)
+	ForwardMessages(ctx context.Context, request *presenter.ForwardMessageRequest) ([]*presenter.MessageResponse, error)
+	UpdateMessageTTL(ctx context.Context, userID string, request *presenter.UpdateMessageTTLRequest) (*presenter.ConversationResponse, error)
+	SearchMessages(ctx context.Context, request *presenter.SearchMessageRequest) (*presenter.SearchMessageResponse, error)
+	GetTotalUnreadCount(ctx context.Context, userID string) (*presenter.UnreadCountResponse, error)
+	MarkUnread(ctx context.Context, userID string, request *presenter.MarkUnreadRequest) error
+	MarkAllRead(ctx context.Context, userID string) (*presenter.MarkAllReadResponse, error)
+	HandleMessageDelivered(ctx context.Context, delivered domain.DeliveredMessage) error
+	AckDeliveredMessages(ctx context.Context, userID string, request *presenter.DeliveredMessageRequest) error
+	GetMessageStatus(ctx context.Context, userID string, messageID string) (*presenter.MessageStatusResponse, error)
+	UpdateConversationSettings(ctx context.Context, userID string, request *presenter.UpdateConversationSettingsRequest) (*presenter.ConversationSettingsResponse, error)
+	ReorderPinnedConversations(ctx context.Context, userID string, request *presenter.ReorderPinnedConversationRequest) (*presenter.PinnedConversationResponse, error)
+	UpdateConversation(ctx context.Context, userID string, request *presenter.UpdateConversationRequest) (*presenter.ConversationResponse, error)
+	EditMessage(ctx context.Context, userID string, request *presenter.EditMessageRequest) (*presenter.MessageResponse, error)
+	RemoveConversationMember(ctx context.Context, userID string, conversationID string, memberID string) error
 }
 
 type conversationUseCase struct {
-	conversationRepository domain.ConversationRepository
-	messageRepository      domain.MessageRepository
-	messagePublisher       pubsub.Publisher
-	userOnlineRepository   domain.UserOnlineRepository
-	userRepository         domain.UserRepository
-	seenMessageRepository  domain.SeenMessageRepository
-	fcmRepository          domain.FcmTokenRepository
-	obs                    *observability.Observability
-	fcmClient              *messaging.Client
+	conversationRepository    domain.ConversationRepository
+	messageRepository         domain.MessageRepository
+	messagePublisher          pubsub.Publisher
+	userOnlineRepository      domain.UserOnlineRepository
+	userRepository            domain.UserRepository
+	seenMessageRepository     domain.SeenMessageRepository
+	fcmRepository             domain.FcmTokenRepository
+	botCommandRepository      domain.BotCommandRepository
+	pollVoteRepository        domain.PollVoteRepository
+	contactRepository         domain.ContactRepository
+	stickerRepository         domain.StickerRepository
+	pinnedMessageRepository   domain.PinnedMessageRepository
+	unreadCountRepository     domain.UnreadCountRepository
+	messageDeliveryRepository domain.MessageDeliveryRepository
+	obs                       *observability.Observability
+	fcmClient                 *messaging.Client
+	commands                  map[string]commandFunc
 }
 
 func (c *conversationUseCase) HandleSeenMessage(ctx context.Context, message *domain.SeenMessage) error {
@@ -50,11 +77,25 @@ func (c *conversationUseCase) HandleSeenMessage(ctx context.Context, message *do
 		return err
 	}
 	message.ID = id
-	err = c.seenMessageRepository.CreateSeenMessage(ctx, message)
+	// marking the first message unread leaves nothing read in the conversation
+	if message.MarkedUnread && message.MessageID == "" {
+		err = c.seenMessageRepository.DeleteSeenMessage(ctx, message.UserID, message.ConversationID)
+	} else {
+		err = c.seenMessageRepository.CreateSeenMessage(ctx, message)
+	}
 	if err != nil {
 		logger.Error("failed to upsert seen message", err, message)
 		return err
 	}
+	err = c.recountUnreadCount(ctx, message.ConversationID, message.UserID)
+	if err != nil {
+		logger.Error("failed to recount unread count", err, message)
+		return err
+	}
+	c.publishReadMarkerUpdated(ctx, message)
+	if message.MarkedUnread {
+		return nil
+	}
 	wsMessage := &domain.WebSocketMessage{
 		Type: domain.WsSeenMessage,
 		Payload: map[string]any{
@@ -84,6 +125,10 @@ func (c *conversationUseCase) HandleSendMessageToFCM(ctx context.Context, messag
 		logger.Error("failed to get message by id", err, message)
 		return err
 	}
+	// system messages are rendered by the clients, they are not worth a push
+	if messageDomain.Type == domain.MessageTypeSystem {
+		return nil
+	}
 
 	conversation, members, err := c.conversationRepository.GetConversationByID(ctx, messageDomain.ConversationID)
 	if err != nil {
@@ -91,10 +136,14 @@ func (c *conversationUseCase) HandleSendMessageToFCM(ctx context.Context, messag
 		return err
 	}
 
+	now := time.Now()
 	for _, member := range members {
 		if member.UserID == messageDomain.UserID {
 			continue
 		}
+		if member.MutedUntil != nil && member.MutedUntil.After(now) {
+			continue
+		}
 		fcmToken, err := c.fcmRepository.GetFcmTokenByUserID(ctx, member.UserID)
 		if err != nil {
 			logger.Error("failed to get fcm token by user id", err, member)
@@ -159,26 +208,28 @@ func (c *conversationUseCase) HandleSendMessageToFCM(ctx context.Context, messag
 }
 
 // GetListSeenMessageByConversationID implements ConversationUseCase.
-func (c *conversationUseCase) GetListSeenMessageByConversationID(ctx context.Context, conversationID string) ([]*presenter.SeenMessageResponse, error) {
+// Only the seen messages of current members are listed.
+func (c *conversationUseCase) GetListSeenMessageByConversationID(ctx context.Context, userID string, conversationID string) ([]*presenter.SeenMessageResponse, error) {
 	ctx, span := c.obs.StartSpan(ctx, "ConversationUsecase.GetListSeenMessageByConversationID")
 	defer span()
 	logger := c.obs.Logger.WithContext(ctx)
+	isMember, err := c.conversationRepository.CheckIsMemberOfConversation(ctx, userID, conversationID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	if !isMember {
+		return nil, domain.ErrNotFoundMemberOfConversation
+	}
 	seenMessages, err := c.seenMessageRepository.GetListSeenMessageByConversationID(ctx, conversationID)
 	if err != nil {
 		logger.Error("failed to get list seen message by conversation id", err, conversationID)
 		return nil, err
 	}
-	seenMessageResponses := make([]*presenter.SeenMessageResponse, 0)
-	for _, seenMessage := range seenMessages {
-		seenMessageResponses = append(seenMessageResponses, &presenter.SeenMessageResponse{
-			MessageID:      seenMessage.MessageID,
-			UserID:         seenMessage.UserID,
-			ConversationID: seenMessage.ConversationID,
-			CreatedAt:      seenMessage.CreatedAt,
-			UpdatedAt:      seenMessage.UpdatedAt,
-		})
+	_, members, err := c.conversationRepository.GetConversationByID(ctx, conversationID)
+	if err != nil {
+		return nil, err
 	}
-	return seenMessageResponses, nil
+	return buildSeenMessageResponses(seenMessages, members), nil
 }
 
 // SeenMessage implements ConversationUseCase.
@@ -217,6 +268,11 @@ func (c *conversationUseCase) HandleUpdateLastMessageID(ctx context.Context, dat
 		logger.Error("error get message by id", err, data)
 		return err
 	}
+	err = c.incrementUnreadCounts(ctx, message)
+	if err != nil {
+		logger.Error("error increment unread counts", err, data)
+		return err
+	}
 
 	userMap, err := pointer.ToMap(message.User)
 	if err != nil {
@@ -252,12 +308,19 @@ func (c *conversationUseCase) getUserOnlineByConversationID(ctx context.Context,
 }
 
 func (c *conversationUseCase) handleSendEventNewMessage(ctx context.Context, message *domain.WebSocketMessage) error {
+	_, err := c.sendEventToConversation(ctx, message)
+	return err
+}
+
+// sendEventToConversation writes the event to the connections of the
+// conversation on this instance, it returns the users it reached.
+func (c *conversationUseCase) sendEventToConversation(ctx context.Context, message *domain.WebSocketMessage) ([]string, error) {
 	logger := c.obs.Logger.WithContext(ctx)
 	// get user online by conversation id
 	userOnlines, err := c.getUserOnlineByConversationID(ctx, message.Payload["conversation_id"].(string))
 	if err != nil {
 		logger.Error("error get user online by conversation id", err, message)
-		return err
+		return nil, err
 	}
 
 	mapIgnoreUserOnlines := make(map[string]bool)
@@ -266,6 +329,7 @@ func (c *conversationUseCase) handleSendEventNewMessage(ctx context.Context, mes
 	}
 
 	// send message to websocket
+	var userIDs []string
 	for _, userOnline := range userOnlines {
 		// exclude user who send message
 		if _, ok := mapIgnoreUserOnlines[userOnline.ID]; ok {
@@ -286,8 +350,11 @@ func (c *conversationUseCase) handleSendEventNewMessage(ctx context.Context, mes
 			logger.Error("failed to send message to websocket", err, message)
 			continue
 		}
+		if !slices.Contains(userIDs, userOnline.UserID) {
+			userIDs = append(userIDs, userOnline.UserID)
+		}
 	}
-	return nil
+	return userIDs, nil
 }
 
 func (c *conversationUseCase) handleSendEventUpdateLastMessageID(ctx context.Context, message *domain.WebSocketMessage) error {
@@ -327,31 +394,102 @@ func (c *conversationUseCase) handleSendEventUpdateLastMessageID(ctx context.Con
 	return nil
 }
 
+// handleSendEventToUser sends an event to every connection of the user in the
+// payload, used for events that are not scoped to a conversation.
+func (c *conversationUseCase) handleSendEventToUser(ctx context.Context, message *domain.WebSocketMessage) error {
+	logger := c.obs.Logger.WithContext(ctx)
+	userID, _ := message.Payload["user_id"].(string)
+	userOnlines, err := c.userOnlineRepository.GetUserOnlineByUserID(ctx, userID)
+	if err != nil {
+		logger.Error("error get user online by user id", err, message)
+		return err
+	}
+
+	b, err := json.Marshal(message)
+	if err != nil {
+		logger.Error("failed to marshal message to json", err, message)
+		return err
+	}
+	for _, userOnline := range userOnlines {
+		wsConn, ok := domain.WebSocket.GetConnection(userOnline.ConnectionID)
+		if !ok {
+			continue
+		}
+		err = wsConn.SendMessage(b)
+		if err != nil {
+			logger.Error("failed to send message to websocket", err, message)
+			continue
+		}
+	}
+	return nil
+}
+
 // HandleNewMessage implements ConversationUseCase.
 func (c *conversationUseCase) HandleNewMessage(ctx context.Context, message *domain.WebSocketMessage) error {
 	switch message.Type {
 	case domain.WsMessage:
-		return c.handleSendEventNewMessage(ctx, message)
+		return c.handleSendEventMessage(ctx, message)
 	case domain.WsUpdateLastMessage:
 		return c.handleSendEventUpdateLastMessageID(ctx, message)
 	case domain.WsSeenMessage:
 		return c.handleSendEventNewMessage(ctx, message)
+	case domain.WsDataExportReady:
+		return c.handleSendEventToUser(ctx, message)
+	case domain.WsMemberAdded:
+		return c.handleSendEventNewMessage(ctx, message)
+	case domain.WsEphemeralMessage:
+		return c.handleSendEventToUser(ctx, message)
+	case domain.WsPollUpdated:
+		return c.handleSendEventNewMessage(ctx, message)
+	case domain.WsLocationUpdated:
+		return c.handleSendEventNewMessage(ctx, message)
+	case domain.WsThreadUpdated:
+		return c.handleSendEventNewMessage(ctx, message)
+	case domain.WsPinUpdated:
+		return c.handleSendEventNewMessage(ctx, message)
+	case domain.WsScheduledFailed:
+		return c.handleSendEventToUser(ctx, message)
+	case domain.WsMessageExpired:
+		return c.handleSendEventNewMessage(ctx, message)
+	case domain.WsUnreadCountUpdated:
+		return c.handleSendEventToUser(ctx, message)
+	case domain.WsMessageDelivered:
+		return c.handleSendEventToUser(ctx, message)
+	case domain.WsReadMarkerUpdated:
+		return c.handleSendEventToUser(ctx, message)
+	case domain.WsConversationSettingsUpdated:
+		return c.handleSendEventToUser(ctx, message)
+	case domain.WsConversationUpdated:
+		return c.handleSendEventNewMessage(ctx, message)
+	case domain.WsMessageUpdated:
+		return c.handleSendEventNewMessage(ctx, message)
+	case domain.WsMemberRemoved:
+		return c.handleSendEventMemberRemoved(ctx, message)
 	}
 	return nil
 }
 
-func NewConversationUseCase(conversationRepository domain.ConversationRepository, messageRepository domain.MessageRepository, messagePublisher pubsub.Publisher, userOnlineRepository domain.UserOnlineRepository, userRepository domain.UserRepository, seenMessageRepository domain.SeenMessageRepository, fcmRepository domain.FcmTokenRepository, obs *observability.Observability, fcmClient *messaging.Client) ConversationUseCase {
-	return &conversationUseCase{
-		conversationRepository: conversationRepository,
-		messageRepository:      messageRepository,
-		messagePublisher:       messagePublisher,
-		userOnlineRepository:   userOnlineRepository,
-		userRepository:         userRepository,
-		seenMessageRepository:  seenMessageRepository,
-		fcmRepository:          fcmRepository,
-		obs:                    obs,
-		fcmClient:              fcmClient,
-	}
+func NewConversationUseCase(conversationRepository domain.ConversationRepository, messageRepository domain.MessageRepository, messagePublisher pubsub.Publisher, userOnlineRepository domain.UserOnlineRepository, userRepository domain.UserRepository, seenMessageRepository domain.SeenMessageRepository, fcmRepository domain.FcmTokenRepository, botCommandRepository domain.BotCommandRepository, pollVoteRepository domain.PollVoteRepository, contactRepository domain.ContactRepository, stickerRepository domain.StickerRepository, pinnedMessageRepository domain.PinnedMessageRepository, unreadCountRepository domain.UnreadCountRepository, messageDeliveryRepository domain.MessageDeliveryRepository, obs *observability.Observability, fcmClient *messaging.Client) ConversationUseCase {
+	c := &conversationUseCase{
+		conversationRepository:    conversationRepository,
+		messageRepository:         messageRepository,
+		messagePublisher:          messagePublisher,
+		userOnlineRepository:      userOnlineRepository,
+		userRepository:            userRepository,
+		seenMessageRepository:     seenMessageRepository,
+		fcmRepository:             fcmRepository,
+		botCommandRepository:      botCommandRepository,
+		pollVoteRepository:        pollVoteRepository,
+		contactRepository:         contactRepository,
+		stickerRepository:         stickerRepository,
+		pinnedMessageRepository:   pinnedMessageRepository,
+		unreadCountRepository:     unreadCountRepository,
+		messageDeliveryRepository: messageDeliveryRepository,
+		obs:                       obs,
+		fcmClient:                 fcmClient,
+	}
+	c.commands = c.builtinCommands()
+	return c
 }
 
 // CreateConversation implements ConversationUseCase.
@@ -362,11 +500,16 @@ func (c *conversationUseCase) CreateConversation(ctx context.Context, conversati
 			return nil, err
 		}
 		if existConversation != nil {
+			pinnedCount, err := c.pinnedMessageRepository.CountPinnedMessage(ctx, existConversation.ID)
+			if err != nil {
+				return nil, err
+			}
 			return &presenter.ConversationResponse{
 				ConversationID: existConversation.ID,
 				Type:           existConversation.Type,
 				Title:          existConversation.Title,
 				Avatar:         existConversation.Avatar,
+				PinnedCount:    pinnedCount,
 			}, nil
 		}
 	}
@@ -384,20 +527,31 @@ func (c *conversationUseCase) CreateConversation(ctx context.Context, conversati
 	}
 	conversationMembers := make([]*domain.ConversationMember, 0)
 	conversationMemberResponses := make([]*presenter.ConversationMemberResponse, 0)
+	ownerID := conversation.CreatorID
+	if !slices.Contains(conversation.Members, ownerID) {
+		ownerID = conversation.Members[0]
+	}
 	for _, userID := range conversation.Members {
 		conversationMemberID, err := uuid.NewID()
 		if err != nil {
 			return nil, err
 		}
+		// both sides of a DM manage it equally
+		role := domain.ConversationMemberRoleMember
+		if userID == ownerID || conversation.Type == domain.ConversationTypeDM {
+			role = domain.ConversationMemberRoleOwner
+		}
 		conversationMembers = append(conversationMembers, &domain.ConversationMember{
 			ID:             conversationMemberID,
 			ConversationID: conversationID,
 			UserID:         userID,
+			Role:           role,
 			CreatedAt:      pointer.ToPtr(time.Now()),
 			UpdatedAt:      pointer.ToPtr(time.Now()),
 		})
 		conversationMemberResponses = append(conversationMemberResponses, &presenter.ConversationMemberResponse{
 			UserID: userID,
+			Role:   role,
 		})
 	}
 	conversationDomain, err = c.conversationRepository.CreateConversation(ctx, conversationDomain, conversationMembers)
@@ -426,23 +580,39 @@ func (c *conversationUseCase) GetConversationByID(ctx context.Context, conversat
 			FullName: conversationMember.FullName,
 			Avatar:   conversationMember.Avatar,
 			UserType: conversationMember.UserType,
+			Role:     conversationMember.Role,
 		})
 	}
+	pinnedCount, err := c.pinnedMessageRepository.CountPinnedMessage(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
 	return &presenter.ConversationResponse{
 		ConversationID: conversation.ID,
 		Type:           conversation.Type,
 		Title:          conversation.Title,
 		Avatar:         conversation.Avatar,
 		Members:        conversationMemberResponses,
+		PinnedCount:    pinnedCount,
+		MessageTTL:     conversation.MessageTTL,
 	}, nil
 }
 
 // GetListConversationByUserID implements ConversationUseCase.
-func (c *conversationUseCase) GetListConversationByUserID(ctx context.Context, userID string, lastMessageID string, limit int) ([]*presenter.GetListConversationResponse, error) {
-	conversations, err := c.conversationRepository.GetListConversationByUserID(ctx, userID, lastMessageID, limit)
+// Pinned conversations come first on the first page of the main list, the
+// following pages continue from the last message id of the unpinned ones.
+func (c *conversationUseCase) GetListConversationByUserID(ctx context.Context, userID string, lastMessageID string, limit int, archived bool) ([]*presenter.GetListConversationResponse, error) {
+	conversations, err := c.conversationRepository.GetListConversationByUserID(ctx, userID, lastMessageID, limit, domain.ConversationListFilter{Archived: archived})
 	if err != nil {
 		return nil, err
 	}
+	if !archived && lastMessageID == "" {
+		pinnedConversations, err := c.conversationRepository.GetListConversationByUserID(ctx, userID, "", domain.MaxPinnedConversations, domain.ConversationListFilter{Pinned: true})
+		if err != nil {
+			return nil, err
+		}
+		conversations = append(pinnedConversations, conversations...)
+	}
 	conversationResponses := make([]*presenter.GetListConversationResponse, 0)
 	for _, conversation := range conversations {
 		conversationResponse := &presenter.GetListConversationResponse{
@@ -453,6 +623,10 @@ func (c *conversationUseCase) GetListConversationByUserID(ctx context.Context, u
 			LastMessageID:  conversation.LastMessageID,
 			CreatedAt:      conversation.CreatedAt,
 			UpdatedAt:      conversation.UpdatedAt,
+			UnreadCount:    conversation.UnreadCount,
+			MutedUntil:     conversation.MutedUntil,
+			Archived:       conversation.ArchivedAt != nil,
+			Pinned:         conversation.PinOrder != nil,
 		}
 
 		if conversation.LastMessage != nil {
@@ -464,6 +638,7 @@ func (c *conversationUseCase) GetListConversationByUserID(ctx context.Context, u
 				Type:           conversation.LastMessage.Type,
 				DeletedAt:      conversation.LastMessage.DeletedAt,
 				ReplyTo:        conversation.LastMessage.ReplyTo,
+				DisplayName:    conversation.LastMessage.DisplayName,
 				ConversationID: conversation.LastMessage.ConversationID,
 				IsRead:         conversation.LastMessage.IsRead,
 				User: &presenter.UserResponse{
@@ -509,33 +684,93 @@ func (c *conversationUseCase) GetListMessageByConversationID(ctx context.Context
 	if err == pgx.ErrNoRows {
 		return []*presenter.MessageResponse{}, nil
 	}
-	messageResponses := make([]*presenter.MessageResponse, 0)
+	return c.buildMessageResponses(ctx, userID, messages)
+}
+
+// getPollVotes loads the votes of the poll messages grouped by message id.
+func (c *conversationUseCase) getPollVotes(ctx context.Context, messages []*domain.Message) (map[string][]*domain.PollVote, error) {
+	var messageIDs []string
 	for _, message := range messages {
-		messageResponses = append(messageResponses, &presenter.MessageResponse{
-			MessageID:      message.ID,
-			Body:           message.Body,
-			CreatedAt:      message.CreatedAt,
-			UpdatedAt:      message.UpdatedAt,
-			Type:           message.Type,
-			DeletedAt:      message.DeletedAt,
-			ReplyTo:        message.ReplyTo,
-			ConversationID: message.ConversationID,
-			User: &presenter.UserResponse{
-				UserID:   message.User.ID,
-				FullName: message.User.FullName,
-				Avatar:   message.User.Avatar,
-				UserType: message.User.Type,
-			},
-		})
+		if message.Type == domain.MessageTypePoll && message.DeletedAt == nil {
+			messageIDs = append(messageIDs, message.ID)
+		}
 	}
-	return messageResponses, nil
+	pollVotes := make(map[string][]*domain.PollVote, len(messageIDs))
+	if len(messageIDs) == 0 {
+		return pollVotes, nil
+	}
+	votes, err := c.pollVoteRepository.GetListPollVoteByMessageIDs(ctx, messageIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, vote := range votes {
+		pollVotes[vote.MessageID] = append(pollVotes[vote.MessageID], vote)
+	}
+	return pollVotes, nil
 }
 
 // SendMessage implements ConversationUseCase.
 func (c *conversationUseCase) SendMessage(ctx context.Context, message *presenter.SendMessageRequest) (*presenter.MessageResponse, error) {
 	ctx, span := c.obs.StartSpan(ctx, "ConversationUsecase.SendMessage")
 	defer span()
+	isMember, err := c.conversationRepository.CheckIsMemberOfConversation(ctx, message.UserID, message.ConversationID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	if !isMember {
+		return nil, domain.ErrNotFoundMemberOfConversation
+	}
+	if message.Type == domain.MessageTypeText && !message.IsBot && strings.HasPrefix(message.Body, "/") {
+		// a leading "//" escapes the command prefix
+		if !strings.HasPrefix(message.Body, "//") {
+			return c.executeCommand(ctx, message)
+		}
+		message.Body = message.Body[1:]
+	}
+	message.Body, err = c.normalizeMessageBody(ctx, message.UserID, message.Type, message.Body, message.LiveUntil)
+	if err != nil {
+		return nil, err
+	}
+	return c.sendMessage(ctx, message)
+}
+
+// normalizeMessageBody validates the body of a message userID is about to
+// send, structured bodies are rewritten in their canonical form.
+func (c *conversationUseCase) normalizeMessageBody(ctx context.Context, userID string, messageType string, body string, liveUntil *time.Time) (string, error) {
+	switch messageType {
+	case domain.MessageTypePoll:
+		return normalizePollBody(body)
+	case domain.MessageTypeLocation:
+		return normalizeLocationBody(body, liveUntil)
+	case domain.MessageTypeContact:
+		return c.normalizeContactBody(ctx, body)
+	case domain.MessageTypeSticker:
+		return c.normalizeStickerBody(ctx, userID, body)
+	}
+	return body, nil
+}
+
+// sendMessage stores the message and publishes it to websocket, fcm and the
+// last message update.
+func (c *conversationUseCase) sendMessage(ctx context.Context, message *presenter.SendMessageRequest) (*presenter.MessageResponse, error) {
 	logger := c.obs.Logger.WithContext(ctx)
+	var parent *domain.Message
+	threadID := ""
+	if message.ReplyTo != "" {
+		var err error
+		parent, err = c.resolveReplyTo(ctx, message)
+		if err != nil {
+			return nil, err
+		}
+		threadID = parent.ThreadID
+		if threadID == "" {
+			threadID = parent.ID
+		}
+	}
+	expiresAt, err := c.getMessageExpiresAt(ctx, message.ConversationID)
+	if err != nil {
+		return nil, err
+	}
 	messageID, err := uuid.NewID()
 	if err != nil {
 		return nil, err
@@ -549,6 +784,9 @@ func (c *conversationUseCase) SendMessage(ctx context.Context, message *presente
 		CreatedAt:      pointer.ToPtr(time.Now()),
 		UpdatedAt:      pointer.ToPtr(time.Now()),
 		ReplyTo:        message.ReplyTo,
+		DisplayName:    message.DisplayName,
+		ThreadID:       threadID,
+		ExpiresAt:      expiresAt,
 	}
 	messageDomain, err = c.messageRepository.CreateMessage(ctx, messageDomain)
 	if err != nil {
@@ -556,61 +794,93 @@ func (c *conversationUseCase) SendMessage(ctx context.Context, message *presente
 		return nil, err
 	}
 
-	err = c.messagePublisher.Publish(ctx, domain.SUBJECT_FCM_MESSAGE, messageDomain)
-	if err != nil {
-		logger.Error("failed to publish message to fcm", err, message)
-	}
-
-	// prepare message to send to websocket
 	user, err := c.userRepository.GetUserByID(ctx, message.UserID)
 	if err != nil {
 		logger.Error("error get user by id", err, message)
 		return nil, err
 	}
+	err = c.publishNewMessage(ctx, messageDomain, user)
+	if err != nil {
+		return nil, err
+	}
+	if threadID != "" {
+		c.publishThreadUpdated(ctx, message.ConversationID, threadID)
+	}
+
+	var poll *presenter.PollResponse
+	if messageDomain.Type == domain.MessageTypePoll {
+		poll = buildPollResponse(messageDomain, nil, message.UserID)
+	}
+	contactCards, err := c.getContactCards(ctx, message.UserID, []*domain.Message{messageDomain})
+	if err != nil {
+		logger.Error("error get contact card", err, message)
+	}
+	messageResponse := &presenter.MessageResponse{
+		MessageID:      messageDomain.ID,
+		Body:           messageDomain.Body,
+		CreatedAt:      messageDomain.CreatedAt,
+		UpdatedAt:      messageDomain.UpdatedAt,
+		Type:           messageDomain.Type,
+		DeletedAt:      messageDomain.DeletedAt,
+		ReplyTo:        messageDomain.ReplyTo,
+		DisplayName:    messageDomain.DisplayName,
+		ConversationID: messageDomain.ConversationID,
+		ThreadID:       messageDomain.ThreadID,
+		ForwardedFrom:  messageDomain.ForwardedFrom,
+		ExpiresAt:      messageDomain.ExpiresAt,
+		Poll:           poll,
+		Contact:        contactCard(messageDomain, contactCards),
+	}
+	if parent != nil {
+		messageResponse.Parent = buildParentResponse(parent)
+	}
+	return messageResponse, nil
+}
+
+// publishNewMessage publishes a stored message to websocket, fcm and the last
+// message update of its conversation.
+func (c *conversationUseCase) publishNewMessage(ctx context.Context, messageDomain *domain.Message, user *domain.UserInfo) error {
+	logger := c.obs.Logger.WithContext(ctx)
+	err := c.messagePublisher.Publish(ctx, domain.SUBJECT_FCM_MESSAGE, messageDomain)
+	if err != nil {
+		logger.Error("failed to publish message to fcm", err, messageDomain)
+	}
+
+	// prepare message to send to websocket
 	userMap, err := pointer.ToMap(user)
 	if err != nil {
 		logger.Error("error convert user to map", err, user)
-		return nil, err
+		return err
 	}
 
 	messageMap, err := pointer.ToMap(messageDomain)
 	if err != nil {
-		logger.Error("error convert message to map", err, message)
-		return nil, err
+		logger.Error("error convert message to map", err, messageDomain)
+		return err
 	}
 	messageMap["user"] = userMap
 	wsMessage := &domain.WebSocketMessage{
 		Type:              domain.WsMessage,
 		Payload:           messageMap,
-		IgnoreUserOnlines: []string{message.UserID},
+		IgnoreUserOnlines: []string{messageDomain.UserID},
 	}
 	// send message to websocket
 	err = c.messagePublisher.Publish(ctx, domain.SUBJECT_NEW_MESSAGE, wsMessage)
 	if err != nil {
-		logger.Error("failed to publish message to websocket", err, message)
+		logger.Error("failed to publish message to websocket", err, messageDomain)
 		// return nil, fmt.Errorf("failed to publish message to websocket: %w", err)
 	}
 	// update last message id of conversation
 	err = c.messagePublisher.Publish(ctx, domain.SUBJECT_UPDATE_LAST_MESSAGE_ID, domain.UpdateLastMessageID{
-		ConversationID: message.ConversationID,
+		ConversationID: messageDomain.ConversationID,
 		MessageID:      messageDomain.ID,
 	})
 
 	if err != nil {
-		logger.Error("failed to publish message to websocket", err, message)
+		logger.Error("failed to publish message to websocket", err, messageDomain)
 		// return nil, fmt.Errorf("failed to publish message to websocket: %w", err)
 	}
-
-	return &presenter.MessageResponse{
-		MessageID:      messageDomain.ID,
-		Body:           messageDomain.Body,
-		CreatedAt:      messageDomain.CreatedAt,
-		UpdatedAt:      messageDomain.UpdatedAt,
-		Type:           messageDomain.Type,
-		DeletedAt:      messageDomain.DeletedAt,
-		ReplyTo:        messageDomain.ReplyTo,
-		ConversationID: messageDomain.ConversationID,
-	}, nil
+	return nil
 }
 
 var _ ConversationUseCase = &conversationUseCase{}
@@ -0,0 +1,476 @@
+package usecase
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"time"
+
+	"github.com/chat-socio/backend/configuration"
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/internal/presenter"
+	"github.com/chat-socio/backend/internal/utils"
+	"github.com/chat-socio/backend/pkg/observability"
+	"github.com/chat-socio/backend/pkg/pointer"
+	"github.com/chat-socio/backend/pkg/storage"
+	"github.com/chat-socio/backend/pkg/uuid"
+	"github.com/chat-socio/backend/pubsub"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// dataExportURLTTL is how long a download link stays valid, a new link can
+	// be requested at any time while the archive exists.
+	dataExportURLTTL   = 24 * time.Hour
+	dataExportPageSize = 500
+)
+
+var ErrNotFoundDataExport = errors.New("data export not found")
+
+var (
+	transcriptHeader = template.Must(template.New("header").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Messages of {{.FullName}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; width: 100%; }
+td, th { border-bottom: 1px solid #ddd; padding: 6px; text-align: left; vertical-align: top; }
+.body { white-space: pre-wrap; }
+</style>
+</head>
+<body>
+<h1>Messages of {{.FullName}}</h1>
+<table>
+<tr><th>Time</th><th>Conversation</th><th>Type</th><th>Message</th></tr>
+`))
+	transcriptRow = template.Must(template.New("row").Parse(`<tr><td>{{.CreatedAt}}</td><td>{{.Conversation}}</td><td>{{.Type}}</td><td class="body">{{.Body}}</td></tr>
+`))
+	transcriptFooter = `</table>
+</body>
+</html>
+`
+)
+
+type DataExportUseCase interface {
+	ExportData(ctx context.Context) (*presenter.DataExportResponse, error)
+	GetDataExport(ctx context.Context, jobID string) (*presenter.DataExportResponse, error)
+	HandleExportData(ctx context.Context, data domain.DataExportJob) error
+}
+
+type dataExportUseCase struct {
+	userRepository         domain.UserRepository
+	sessionRepository      domain.SessionRepository
+	contactRepository      domain.ContactRepository
+	conversationRepository domain.ConversationRepository
+	messageRepository      domain.MessageRepository
+	dataExportRepository   domain.DataExportRepository
+	storage                storage.ObjectStorage
+	publisher              pubsub.Publisher
+	obs                    *observability.Observability
+}
+
+// ExportData implements DataExportUseCase.
+func (d *dataExportUseCase) ExportData(ctx context.Context) (*presenter.DataExportResponse, error) {
+	ctx, span := d.obs.StartSpan(ctx, "DataExportUseCase.ExportData")
+	defer span()
+	logger := d.obs.Logger.WithContext(ctx)
+
+	accountID := ctx.Value(utils.AccountIDKey).(string)
+	user, err := d.userRepository.GetUserByAccountID(ctx, accountID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	if err == pgx.ErrNoRows {
+		return nil, ErrNotFoundAccount
+	}
+
+	id, err := uuid.NewID()
+	if err != nil {
+		return nil, err
+	}
+	job := &domain.DataExportJob{
+		ID:        id,
+		UserID:    user.ID,
+		AccountID: accountID,
+		Status:    domain.DataExportStatusPending,
+		CreatedAt: pointer.ToPtr(time.Now()),
+		UpdatedAt: pointer.ToPtr(time.Now()),
+	}
+	err = d.dataExportRepository.CreateDataExportJob(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.publisher.Publish(ctx, domain.SUBJECT_EXPORT_DATA, job)
+	if err != nil {
+		logger.Error("failed to publish data export job", err)
+		return nil, err
+	}
+
+	return &presenter.DataExportResponse{
+		JobID:     job.ID,
+		Status:    job.Status,
+		CreatedAt: job.CreatedAt,
+	}, nil
+}
+
+// GetDataExport implements DataExportUseCase.
+// A fresh download link is signed on every call once the archive is ready.
+func (d *dataExportUseCase) GetDataExport(ctx context.Context, jobID string) (*presenter.DataExportResponse, error) {
+	ctx, span := d.obs.StartSpan(ctx, "DataExportUseCase.GetDataExport")
+	defer span()
+
+	accountID := ctx.Value(utils.AccountIDKey).(string)
+	job, err := d.dataExportRepository.GetDataExportJobByID(ctx, jobID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	if err == pgx.ErrNoRows || job.AccountID != accountID {
+		return nil, ErrNotFoundDataExport
+	}
+
+	response := &presenter.DataExportResponse{
+		JobID:       job.ID,
+		Status:      job.Status,
+		CreatedAt:   job.CreatedAt,
+		CompletedAt: job.CompletedAt,
+	}
+	if job.Status != domain.DataExportStatusCompleted {
+		return response, nil
+	}
+
+	url, err := d.storage.GetObjectURL(ctx, configuration.ConfigInstance.Minio.ExportBucket, job.ObjectName, dataExportURLTTL)
+	if err != nil {
+		return nil, err
+	}
+	response.URL = url
+	response.ExpiresAt = pointer.ToPtr(time.Now().Add(dataExportURLTTL))
+	return response, nil
+}
+
+// HandleExportData implements DataExportUseCase.
+func (d *dataExportUseCase) HandleExportData(ctx context.Context, data domain.DataExportJob) error {
+	logger := d.obs.Logger.WithContext(ctx)
+	job, err := d.dataExportRepository.GetDataExportJobByID(ctx, data.ID)
+	if err != nil {
+		logger.Error("failed to get data export job", err, data)
+		return err
+	}
+	if job.Status == domain.DataExportStatusCompleted {
+		return nil
+	}
+
+	file, err := os.CreateTemp("", "data-export-*.zip")
+	if err != nil {
+		logger.Error("failed to create temp file", err, job)
+		return err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	err = d.writeArchive(ctx, file, job)
+	if err != nil {
+		logger.Error("failed to write data export archive", err, job)
+		return err
+	}
+
+	size, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	_, err = file.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	bucket := configuration.ConfigInstance.Minio.ExportBucket
+	err = d.ensureBucket(ctx, bucket)
+	if err != nil {
+		logger.Error("failed to ensure export bucket", err, bucket)
+		return err
+	}
+	objectName := fmt.Sprintf("%s/%s.zip", job.UserID, job.ID)
+	err = d.storage.PutObject(ctx, bucket, objectName, file, size)
+	if err != nil {
+		logger.Error("failed to upload data export archive", err, job)
+		return err
+	}
+
+	job.Status = domain.DataExportStatusCompleted
+	job.ObjectName = objectName
+	job.UpdatedAt = pointer.ToPtr(time.Now())
+	job.CompletedAt = job.UpdatedAt
+	err = d.dataExportRepository.UpdateDataExportJob(ctx, job)
+	if err != nil {
+		logger.Error("failed to update data export job", err, job)
+		return err
+	}
+
+	url, err := d.storage.GetObjectURL(ctx, bucket, objectName, dataExportURLTTL)
+	if err != nil {
+		logger.Error("failed to get data export url", err, job)
+		return nil
+	}
+	payload, err := pointer.ToMap(presenter.DataExportResponse{
+		JobID:       job.ID,
+		Status:      job.Status,
+		URL:         url,
+		ExpiresAt:   pointer.ToPtr(time.Now().Add(dataExportURLTTL)),
+		CreatedAt:   job.CreatedAt,
+		CompletedAt: job.CompletedAt,
+	})
+	if err != nil {
+		return err
+	}
+	payload["user_id"] = job.UserID
+	err = d.publisher.Publish(ctx, domain.SUBJECT_NEW_MESSAGE, domain.NewWebSocketMessage(domain.WsDataExportReady, payload))
+	if err != nil {
+		// the archive is ready and can still be fetched through the api
+		logger.Error("failed to publish data export notification", err, job)
+	}
+	return nil
+}
+
+func (d *dataExportUseCase) ensureBucket(ctx context.Context, bucket string) error {
+	exists, err := d.storage.BucketExists(ctx, bucket)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+	return d.storage.MakeBucket(ctx, bucket)
+}
+
+func (d *dataExportUseCase) writeArchive(ctx context.Context, w io.Writer, job *domain.DataExportJob) error {
+	user, err := d.userRepository.GetUserByID(ctx, job.UserID)
+	if err != nil {
+		return err
+	}
+
+	archive := zip.NewWriter(w)
+	err = writeJSONEntry(archive, "profile.json", presenter.ExportProfile{
+		UserID:    user.ID,
+		AccountID: user.AccountID,
+		Type:      user.Type,
+		Email:     user.Email,
+		FullName:  user.FullName,
+		Avatar:    user.Avatar,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	sessions, err := d.sessionRepository.GetListSessionHistoryByAccountID(ctx, job.AccountID)
+	if err != nil {
+		return err
+	}
+	exportSessions := make([]*presenter.ExportSession, 0, len(sessions))
+	for _, session := range sessions {
+		exportSessions = append(exportSessions, &presenter.ExportSession{
+			CreatedAt: session.CreatedAt,
+			UpdatedAt: session.UpdatedAt,
+			ExpiredAt: session.ExpiredAt,
+			IsActive:  session.IsActive != nil && *session.IsActive,
+			UserAgent: session.UserAgent,
+			IPAddress: session.IPAddress,
+		})
+	}
+	err = writeJSONEntry(archive, "sessions.json", exportSessions)
+	if err != nil {
+		return err
+	}
+
+	exportContacts := make([]*presenter.ExportContact, 0)
+	lastID := ""
+	for {
+		contacts, err := d.contactRepository.GetListContactByUserID(ctx, job.UserID, dataExportPageSize, lastID)
+		if err != nil {
+			return err
+		}
+		for _, contact := range contacts {
+			exportContact := &presenter.ExportContact{
+				UserID:    contact.FriendID,
+				CreatedAt: contact.CreatedAt,
+			}
+			if contact.Friend != nil {
+				exportContact.FullName = contact.Friend.FullName
+				exportContact.Avatar = contact.Friend.Avatar
+			}
+			exportContacts = append(exportContacts, exportContact)
+		}
+		if len(contacts) < dataExportPageSize {
+			break
+		}
+		lastID = contacts[len(contacts)-1].ID
+	}
+	err = writeJSONEntry(archive, "contacts.json", exportContacts)
+	if err != nil {
+		return err
+	}
+
+	conversationIDs, err := d.conversationRepository.GetListConversationIDByUserID(ctx, job.UserID)
+	if err != nil {
+		return err
+	}
+	exportConversations := make([]*presenter.ExportConversation, 0, len(conversationIDs))
+	conversationNames := make(map[string]string, len(conversationIDs))
+	for _, conversationID := range conversationIDs {
+		conversation, members, err := d.conversationRepository.GetConversationByID(ctx, conversationID)
+		if err != nil {
+			return err
+		}
+		exportConversation := &presenter.ExportConversation{
+			ConversationID: conversation.ID,
+			Type:           conversation.Type,
+			Title:          conversation.Title,
+			Avatar:         conversation.Avatar,
+			CreatedAt:      conversation.CreatedAt,
+		}
+		name := conversation.Title
+		for _, member := range members {
+			exportConversation.Members = append(exportConversation.Members, &presenter.ConversationMemberResponse{
+				UserID:   member.UserID,
+				FullName: member.FullName,
+				Avatar:   member.Avatar,
+				UserType: member.UserType,
+			})
+			if name == "" && member.UserID != job.UserID {
+				name = member.FullName
+			}
+		}
+		exportConversations = append(exportConversations, exportConversation)
+		conversationNames[conversation.ID] = name
+	}
+	err = writeJSONEntry(archive, "conversations.json", exportConversations)
+	if err != nil {
+		return err
+	}
+
+	// messages can be large, both files are streamed page by page
+	entry, err := archive.Create("messages.json")
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(entry, "[")
+	if err != nil {
+		return err
+	}
+	first := true
+	err = d.eachMessage(ctx, job.UserID, func(message *domain.Message) error {
+		if !first {
+			if _, err := io.WriteString(entry, ","); err != nil {
+				return err
+			}
+		}
+		first = false
+		b, err := json.Marshal(presenter.ExportMessage{
+			MessageID:      message.ID,
+			ConversationID: message.ConversationID,
+			Type:           message.Type,
+			Body:           message.Body,
+			ReplyTo:        message.ReplyTo,
+			CreatedAt:      message.CreatedAt,
+			UpdatedAt:      message.UpdatedAt,
+			DeletedAt:      message.DeletedAt,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = entry.Write(b)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(entry, "]")
+	if err != nil {
+		return err
+	}
+
+	entry, err = archive.Create("messages.html")
+	if err != nil {
+		return err
+	}
+	err = transcriptHeader.Execute(entry, user)
+	if err != nil {
+		return err
+	}
+	err = d.eachMessage(ctx, job.UserID, func(message *domain.Message) error {
+		createdAt := ""
+		if message.CreatedAt != nil {
+			createdAt = message.CreatedAt.UTC().Format(time.RFC3339)
+		}
+		return transcriptRow.Execute(entry, map[string]string{
+			"CreatedAt":    createdAt,
+			"Conversation": conversationNames[message.ConversationID],
+			"Type":         message.Type,
+			"Body":         message.Body,
+		})
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(entry, transcriptFooter)
+	if err != nil {
+		return err
+	}
+
+	return archive.Close()
+}
+
+// eachMessage walks every message authored by the user, oldest first.
+func (d *dataExportUseCase) eachMessage(ctx context.Context, userID string, fn func(message *domain.Message) error) error {
+	afterID := ""
+	for {
+		messages, err := d.messageRepository.GetListMessageByUserID(ctx, userID, afterID, dataExportPageSize)
+		if err != nil {
+			return err
+		}
+		for _, message := range messages {
+			err = fn(message)
+			if err != nil {
+				return err
+			}
+		}
+		if len(messages) < dataExportPageSize {
+			return nil
+		}
+		afterID = messages[len(messages)-1].ID
+	}
+}
+
+func writeJSONEntry(archive *zip.Writer, name string, data any) error {
+	entry, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(entry)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(data)
+}
+
+var _ DataExportUseCase = (*dataExportUseCase)(nil)
+
+func NewDataExportUseCase(userRepository domain.UserRepository, sessionRepository domain.SessionRepository, contactRepository domain.ContactRepository, conversationRepository domain.ConversationRepository, messageRepository domain.MessageRepository, dataExportRepository domain.DataExportRepository, storage storage.ObjectStorage, publisher pubsub.Publisher, obs *observability.Observability) DataExportUseCase {
+	return &dataExportUseCase{
+		userRepository:         userRepository,
+		sessionRepository:      sessionRepository,
+		contactRepository:      contactRepository,
+		conversationRepository: conversationRepository,
+		messageRepository:      messageRepository,
+		dataExportRepository:   dataExportRepository,
+		storage:                storage,
+		publisher:              publisher,
+		obs:                    obs,
+	}
+}
@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/internal/presenter"
+	"github.com/chat-socio/backend/pkg/pointer"
+	"github.com/chat-socio/backend/pkg/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrInvalidForward = errors.New("invalid forward")
+
+// ForwardMessages implements ConversationUseCase.
+// Every message is copied to every target conversation, the copies of all
+// targets are stored together before they are published.
+func (c *conversationUseCase) ForwardMessages(ctx context.Context, request *presenter.ForwardMessageRequest) ([]*presenter.MessageResponse, error) {
+	ctx, span := c.obs.StartSpan(ctx, "ConversationUsecase.ForwardMessages")
+	defer span()
+	logger := c.obs.Logger.WithContext(ctx)
+
+	messageIDs := slices.Compact(slices.Sorted(slices.Values(request.MessageIDs)))
+	sources, err := c.messageRepository.GetListMessageByIDs(ctx, messageIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(sources) != len(messageIDs) {
+		return nil, fmt.Errorf("%w: message not found", ErrInvalidForward)
+	}
+	// message ids are time ordered, the copies keep the order of the originals
+	slices.SortFunc(sources, func(a, b *domain.Message) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	targetIDs := slices.Compact(slices.Sorted(slices.Values(request.ConversationIDs)))
+	conversationIDs := slices.Clone(targetIDs)
+	for _, source := range sources {
+		if !slices.Contains(conversationIDs, source.ConversationID) {
+			conversationIDs = append(conversationIDs, source.ConversationID)
+		}
+	}
+	err = c.checkIsMemberOfConversations(ctx, request.UserID, conversationIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var bodies []string
+	for _, source := range sources {
+		body, err := c.forwardedBody(ctx, request.UserID, source)
+		if err != nil {
+			return nil, err
+		}
+		bodies = append(bodies, body)
+	}
+
+	var messages []*domain.Message
+	for _, conversationID := range targetIDs {
+		expiresAt, err := c.getMessageExpiresAt(ctx, conversationID)
+		if err != nil {
+			return nil, err
+		}
+		for i, source := range sources {
+			messageID, err := uuid.NewID()
+			if err != nil {
+				return nil, err
+			}
+			forwardedFrom := source.ForwardedFrom
+			if forwardedFrom == "" {
+				forwardedFrom = source.ID
+			}
+			messages = append(messages, &domain.Message{
+				ID:             messageID,
+				ConversationID: conversationID,
+				UserID:         request.UserID,
+				Type:           source.Type,
+				Body:           bodies[i],
+				CreatedAt:      pointer.ToPtr(time.Now()),
+				UpdatedAt:      pointer.ToPtr(time.Now()),
+				ForwardedFrom:  forwardedFrom,
+				ExpiresAt:      expiresAt,
+			})
+		}
+	}
+	err = c.messageRepository.CreateMessages(ctx, messages)
+	if err != nil {
+		logger.Error("error create forwarded messages", err, request)
+		return nil, err
+	}
+
+	user, err := c.userRepository.GetUserByID(ctx, request.UserID)
+	if err != nil {
+		logger.Error("error get user by id", err, request)
+		return nil, err
+	}
+	for _, message := range messages {
+		err = c.publishNewMessage(ctx, message, user)
+		if err != nil {
+			logger.Error("failed to publish forwarded message", err, message.ID)
+		}
+		message.User = user
+	}
+	return c.buildMessageResponses(ctx, request.UserID, messages)
+}
+
+func (c *conversationUseCase) checkIsMemberOfConversations(ctx context.Context, userID string, conversationIDs []string) error {
+	for _, conversationID := range conversationIDs {
+		isMember, err := c.conversationRepository.CheckIsMemberOfConversation(ctx, userID, conversationID)
+		if err != nil && err != pgx.ErrNoRows {
+			return err
+		}
+		if !isMember {
+			return domain.ErrNotFoundMemberOfConversation
+		}
+	}
+	return nil
+}
+
+// forwardedBody returns the body of the copy of a message, checked as if
+// userID sent it. A live location is forwarded as its last known position.
+func (c *conversationUseCase) forwardedBody(ctx context.Context, userID string, source *domain.Message) (string, error) {
+	if source.DeletedAt != nil {
+		return "", fmt.Errorf("%w: message %s was deleted", ErrInvalidForward, source.ID)
+	}
+	if source.Type == domain.MessageTypeSystem {
+		return "", fmt.Errorf("%w: system messages can not be forwarded", ErrInvalidForward)
+	}
+	return c.normalizeMessageBody(ctx, userID, source.Type, source.Body, nil)
+}
@@ -0,0 +1,275 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/internal/presenter"
+	"github.com/chat-socio/backend/internal/utils"
+	"github.com/chat-socio/backend/pkg/hash"
+	"github.com/chat-socio/backend/pkg/observability"
+	"github.com/chat-socio/backend/pkg/pointer"
+	"github.com/chat-socio/backend/pkg/uuid"
+	"github.com/chat-socio/backend/pubsub"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	incomingWebhookTokenPrefix       = "cshk_"
+	incomingWebhookTokenPrefixLength = 13
+	defaultIncomingWebhookName       = "Incoming Webhook"
+)
+
+var (
+	ErrNotFoundIncomingWebhook = errors.New("incoming webhook not found")
+)
+
+type IncomingWebhookUseCase interface {
+	CreateIncomingWebhook(ctx context.Context, request *presenter.CreateIncomingWebhookRequest) (*presenter.IncomingWebhookResponse, error)
+	GetListIncomingWebhook(ctx context.Context, conversationID string) ([]*presenter.IncomingWebhookResponse, error)
+	RevokeIncomingWebhook(ctx context.Context, incomingWebhookID string) error
+	PostMessage(ctx context.Context, token string, request *presenter.IncomingWebhookMessageRequest) ([]*presenter.MessageResponse, error)
+}
+
+type incomingWebhookUseCase struct {
+	userRepository            domain.UserRepository
+	conversationRepository    domain.ConversationRepository
+	botRepository             domain.BotRepository
+	incomingWebhookRepository domain.IncomingWebhookRepository
+	conversationUseCase       ConversationUseCase
+	publisher                 pubsub.Publisher
+	obs                       *observability.Observability
+}
+
+// CreateIncomingWebhook implements IncomingWebhookUseCase.
+// Every incoming webhook posts as its own bot user, owned by the creator and
+// added to the conversation.
+func (i *incomingWebhookUseCase) CreateIncomingWebhook(ctx context.Context, request *presenter.CreateIncomingWebhookRequest) (*presenter.IncomingWebhookResponse, error) {
+	ctx, span := i.obs.StartSpan(ctx, "IncomingWebhookUseCase.CreateIncomingWebhook")
+	defer span()
+
+	user, err := i.requireConversationAdmin(ctx, request.ConversationID)
+	if err != nil {
+		return nil, err
+	}
+	conversation, _, err := i.conversationRepository.GetConversationByID(ctx, request.ConversationID)
+	if err != nil {
+		return nil, err
+	}
+	if conversation.Type != domain.ConversationTypeGroup {
+		return nil, ErrNotGroupConversation
+	}
+
+	name := request.Name
+	if name == "" {
+		name = defaultIncomingWebhookName
+	}
+	account, botUser, bot, err := newBot(user.ID, name, request.Avatar)
+	if err != nil {
+		return nil, err
+	}
+	memberID, err := uuid.NewID()
+	if err != nil {
+		return nil, err
+	}
+	member := &domain.ConversationMember{
+		ID:             memberID,
+		ConversationID: request.ConversationID,
+		UserID:         bot.ID,
+		Role:           domain.ConversationMemberRoleMember,
+		CreatedAt:      bot.CreatedAt,
+		UpdatedAt:      bot.CreatedAt,
+	}
+
+	token, err := generateIncomingWebhookToken()
+	if err != nil {
+		return nil, err
+	}
+	id, err := uuid.NewID()
+	if err != nil {
+		return nil, err
+	}
+	incomingWebhook := &domain.IncomingWebhook{
+		ID:             id,
+		ConversationID: request.ConversationID,
+		BotID:          bot.ID,
+		CreatedBy:      user.ID,
+		Name:           name,
+		Prefix:         token[:incomingWebhookTokenPrefixLength],
+		TokenHash:      hash.HashToken(token),
+		CreatedAt:      pointer.ToPtr(time.Now()),
+	}
+	err = i.incomingWebhookRepository.CreateIncomingWebhook(ctx, account, botUser, bot, member, incomingWebhook)
+	if err != nil {
+		return nil, err
+	}
+	err = publishBotAdded(ctx, i.publisher, request.ConversationID, bot)
+	if err != nil {
+		i.obs.Logger.WithContext(ctx).Error("failed to publish member added event", err)
+	}
+	response := toIncomingWebhookResponse(incomingWebhook)
+	response.Token = token
+	return response, nil
+}
+
+// GetListIncomingWebhook implements IncomingWebhookUseCase.
+func (i *incomingWebhookUseCase) GetListIncomingWebhook(ctx context.Context, conversationID string) ([]*presenter.IncomingWebhookResponse, error) {
+	ctx, span := i.obs.StartSpan(ctx, "IncomingWebhookUseCase.GetListIncomingWebhook")
+	defer span()
+
+	_, err := i.requireConversationAdmin(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	incomingWebhooks, err := i.incomingWebhookRepository.GetListIncomingWebhookByConversationID(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	responses := make([]*presenter.IncomingWebhookResponse, 0, len(incomingWebhooks))
+	for _, incomingWebhook := range incomingWebhooks {
+		responses = append(responses, toIncomingWebhookResponse(incomingWebhook))
+	}
+	return responses, nil
+}
+
+// RevokeIncomingWebhook implements IncomingWebhookUseCase.
+// The bot user of the webhook is deleted with it.
+func (i *incomingWebhookUseCase) RevokeIncomingWebhook(ctx context.Context, incomingWebhookID string) error {
+	ctx, span := i.obs.StartSpan(ctx, "IncomingWebhookUseCase.RevokeIncomingWebhook")
+	defer span()
+
+	incomingWebhook, err := i.incomingWebhookRepository.GetIncomingWebhookByID(ctx, incomingWebhookID)
+	if err != nil && err != pgx.ErrNoRows {
+		return err
+	}
+	if err == pgx.ErrNoRows {
+		return ErrNotFoundIncomingWebhook
+	}
+	_, err = i.requireConversationAdmin(ctx, incomingWebhook.ConversationID)
+	if err == domain.ErrNotFoundMemberOfConversation {
+		return ErrNotFoundIncomingWebhook
+	}
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	err = i.incomingWebhookRepository.RevokeIncomingWebhook(ctx, incomingWebhook.ID, now)
+	if err != nil {
+		return err
+	}
+	return i.botRepository.DeleteBot(ctx, incomingWebhook.BotID, now)
+}
+
+// PostMessage implements IncomingWebhookUseCase.
+// The text and every attachment are sent as separate messages, in order.
+func (i *incomingWebhookUseCase) PostMessage(ctx context.Context, token string, request *presenter.IncomingWebhookMessageRequest) ([]*presenter.MessageResponse, error) {
+	ctx, span := i.obs.StartSpan(ctx, "IncomingWebhookUseCase.PostMessage")
+	defer span()
+
+	incomingWebhook, err := i.incomingWebhookRepository.GetActiveIncomingWebhookByHash(ctx, hash.HashToken(token))
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	if err == pgx.ErrNoRows {
+		return nil, ErrNotFoundIncomingWebhook
+	}
+
+	var messages []*presenter.SendMessageRequest
+	if request.Text != "" {
+		messages = append(messages, &presenter.SendMessageRequest{
+			Type: domain.MessageTypeText,
+			Body: request.Text,
+		})
+	}
+	for _, attachment := range request.Attachments {
+		messages = append(messages, &presenter.SendMessageRequest{
+			Type: attachment.Type,
+			Body: attachment.URL,
+		})
+	}
+
+	responses := make([]*presenter.MessageResponse, 0, len(messages))
+	for _, message := range messages {
+		message.ConversationID = incomingWebhook.ConversationID
+		message.UserID = incomingWebhook.BotID
+		message.DisplayName = request.DisplayName
+		message.IsBot = true
+		response, err := i.conversationUseCase.SendMessage(ctx, message)
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, response)
+	}
+
+	err = i.incomingWebhookRepository.UpdateIncomingWebhookLastUsedAt(ctx, incomingWebhook.ID, time.Now())
+	if err != nil {
+		i.obs.Logger.WithContext(ctx).Error("failed to update incoming webhook last used at", err)
+	}
+	return responses, nil
+}
+
+// requireConversationAdmin returns the current user when they are an owner
+// or admin of the conversation.
+func (i *incomingWebhookUseCase) requireConversationAdmin(ctx context.Context, conversationID string) (*domain.UserInfo, error) {
+	accountID := ctx.Value(utils.AccountIDKey).(string)
+	user, err := i.userRepository.GetUserByAccountID(ctx, accountID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	if err == pgx.ErrNoRows {
+		return nil, ErrNotFoundAccount
+	}
+	member, err := i.conversationRepository.GetConversationMember(ctx, conversationID, user.ID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	if err == pgx.ErrNoRows {
+		return nil, domain.ErrNotFoundMemberOfConversation
+	}
+	if !member.IsAdmin() {
+		return nil, domain.ErrNotAdminOfConversation
+	}
+	return user, nil
+}
+
+func generateIncomingWebhookToken() (string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return incomingWebhookTokenPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func toIncomingWebhookResponse(incomingWebhook *domain.IncomingWebhook) *presenter.IncomingWebhookResponse {
+	return &presenter.IncomingWebhookResponse{
+		ID:             incomingWebhook.ID,
+		ConversationID: incomingWebhook.ConversationID,
+		BotID:          incomingWebhook.BotID,
+		CreatedBy:      incomingWebhook.CreatedBy,
+		Name:           incomingWebhook.Name,
+		Prefix:         incomingWebhook.Prefix,
+		CreatedAt:      incomingWebhook.CreatedAt,
+		LastUsedAt:     incomingWebhook.LastUsedAt,
+		RevokedAt:      incomingWebhook.RevokedAt,
+	}
+}
+
+var _ IncomingWebhookUseCase = (*incomingWebhookUseCase)(nil)
+
+func NewIncomingWebhookUseCase(userRepository domain.UserRepository, conversationRepository domain.ConversationRepository, botRepository domain.BotRepository, incomingWebhookRepository domain.IncomingWebhookRepository, conversationUseCase ConversationUseCase, publisher pubsub.Publisher, obs *observability.Observability) IncomingWebhookUseCase {
+	return &incomingWebhookUseCase{
+		userRepository:            userRepository,
+		conversationRepository:    conversationRepository,
+		botRepository:             botRepository,
+		incomingWebhookRepository: incomingWebhookRepository,
+		conversationUseCase:       conversationUseCase,
+		publisher:                 publisher,
+		obs:                       obs,
+	}
+}
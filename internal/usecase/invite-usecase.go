@@ -0,0 +1,472 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/internal/presenter"
+	"github.com/chat-socio/backend/pkg/observability"
+	"github.com/chat-socio/backend/pkg/pointer"
+	"github.com/chat-socio/backend/pkg/uuid"
+	"github.com/chat-socio/backend/pubsub"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrNotFoundInvite      = errors.New("invite not found")
+	ErrInviteExpired       = errors.New("invite is expired or used up")
+	ErrNotFoundJoinRequest = errors.New("join request not found")
+	ErrJoinRequestReviewed = errors.New("join request is already reviewed")
+)
+
+type InviteUseCase interface {
+	CreateInvite(ctx context.Context, userID string, request *presenter.CreateInviteRequest) (*presenter.InviteResponse, error)
+	GetListInvite(ctx context.Context, userID string, conversationID string) ([]*presenter.InviteResponse, error)
+	RevokeInvite(ctx context.Context, userID string, inviteID string) error
+	GetInvitePreview(ctx context.Context, userID string, code string) (*presenter.InvitePreviewResponse, error)
+	JoinInvite(ctx context.Context, userID string, request *presenter.JoinInviteRequest) (*presenter.JoinInviteResponse, error)
+	GetListJoinRequest(ctx context.Context, userID string, conversationID string) ([]*presenter.JoinRequestResponse, error)
+	ReviewJoinRequest(ctx context.Context, userID string, request *presenter.ReviewJoinRequestRequest) (*presenter.JoinRequestResponse, error)
+}
+
+type inviteUseCase struct {
+	conversationRepository       domain.ConversationRepository
+	conversationInviteRepository domain.ConversationInviteRepository
+	userRepository               domain.UserRepository
+	conversationUseCase          ConversationUseCase
+	publisher                    pubsub.Publisher
+	obs                          *observability.Observability
+}
+
+// CreateInvite implements InviteUseCase.
+func (i *inviteUseCase) CreateInvite(ctx context.Context, userID string, request *presenter.CreateInviteRequest) (*presenter.InviteResponse, error) {
+	ctx, span := i.obs.StartSpan(ctx, "InviteUseCase.CreateInvite")
+	defer span()
+
+	err := i.checkGroupAdmin(ctx, userID, request.ConversationID)
+	if err != nil {
+		return nil, err
+	}
+	code, err := generateInviteCode()
+	if err != nil {
+		return nil, err
+	}
+	id, err := uuid.NewID()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	invite := &domain.ConversationInvite{
+		ID:               id,
+		ConversationID:   request.ConversationID,
+		Code:             code,
+		CreatedBy:        userID,
+		ExpiresAt:        request.ExpiresAt,
+		MaxUses:          request.MaxUses,
+		RequiresApproval: request.RequiresApproval,
+		CreatedAt:        &now,
+		UpdatedAt:        &now,
+	}
+	err = i.conversationInviteRepository.CreateConversationInvite(ctx, invite)
+	if err != nil {
+		return nil, err
+	}
+	return toInviteResponse(invite), nil
+}
+
+// GetListInvite implements InviteUseCase.
+// Revoked invites are left out, expired and used up ones are kept so admins
+// can see how they were used.
+func (i *inviteUseCase) GetListInvite(ctx context.Context, userID string, conversationID string) ([]*presenter.InviteResponse, error) {
+	ctx, span := i.obs.StartSpan(ctx, "InviteUseCase.GetListInvite")
+	defer span()
+
+	err := i.checkGroupAdmin(ctx, userID, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	invites, err := i.conversationInviteRepository.GetListConversationInvite(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]*presenter.InviteResponse, 0, len(invites))
+	for _, invite := range invites {
+		response = append(response, toInviteResponse(invite))
+	}
+	return response, nil
+}
+
+// RevokeInvite implements InviteUseCase.
+// Pending join requests of the invite can still be reviewed.
+func (i *inviteUseCase) RevokeInvite(ctx context.Context, userID string, inviteID string) error {
+	ctx, span := i.obs.StartSpan(ctx, "InviteUseCase.RevokeInvite")
+	defer span()
+
+	invite, err := i.conversationInviteRepository.GetConversationInviteByID(ctx, inviteID)
+	if err != nil && err != pgx.ErrNoRows {
+		return err
+	}
+	if err == pgx.ErrNoRows || invite.RevokedAt != nil {
+		return ErrNotFoundInvite
+	}
+	err = i.checkGroupAdmin(ctx, userID, invite.ConversationID)
+	if err != nil {
+		return err
+	}
+	return i.conversationInviteRepository.RevokeConversationInvite(ctx, invite.ID, time.Now())
+}
+
+// GetInvitePreview implements InviteUseCase.
+// The preview is what a user sees before joining, so it does not require
+// being a member.
+func (i *inviteUseCase) GetInvitePreview(ctx context.Context, userID string, code string) (*presenter.InvitePreviewResponse, error) {
+	ctx, span := i.obs.StartSpan(ctx, "InviteUseCase.GetInvitePreview")
+	defer span()
+
+	invite, err := i.getUsableInvite(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	conversation, members, err := i.conversationRepository.GetConversationByID(ctx, invite.ConversationID)
+	if err != nil {
+		return nil, err
+	}
+	response := &presenter.InvitePreviewResponse{
+		Code:             invite.Code,
+		ConversationID:   conversation.ID,
+		Title:            conversation.Title,
+		Avatar:           conversation.Avatar,
+		MemberCount:      len(members),
+		RequiresApproval: invite.RequiresApproval,
+		ExpiresAt:        invite.ExpiresAt,
+	}
+	for _, member := range members {
+		if member.UserID == userID {
+			response.IsMember = true
+			break
+		}
+	}
+	return response, nil
+}
+
+// JoinInvite implements InviteUseCase.
+// Members joining again get the conversation without using the invite, with
+// approval required the user waits in a join request instead.
+func (i *inviteUseCase) JoinInvite(ctx context.Context, userID string, request *presenter.JoinInviteRequest) (*presenter.JoinInviteResponse, error) {
+	ctx, span := i.obs.StartSpan(ctx, "InviteUseCase.JoinInvite")
+	defer span()
+
+	invite, err := i.getUsableInvite(ctx, request.Code)
+	if err != nil {
+		return nil, err
+	}
+	isMember, err := i.conversationRepository.CheckIsMemberOfConversation(ctx, userID, invite.ConversationID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	if isMember {
+		return i.joinedResponse(ctx, invite.ConversationID)
+	}
+
+	if invite.RequiresApproval {
+		joinRequest, err := i.conversationInviteRepository.GetPendingConversationJoinRequest(ctx, invite.ConversationID, userID)
+		if err != nil && err != pgx.ErrNoRows {
+			return nil, err
+		}
+		if err == nil {
+			return &presenter.JoinInviteResponse{Status: presenter.JoinStatusPending, JoinRequestID: joinRequest.ID}, nil
+		}
+		return i.createJoinRequest(ctx, userID, invite)
+	}
+	member, err := newConversationMember(invite.ConversationID, userID)
+	if err != nil {
+		return nil, err
+	}
+	used, added, err := i.conversationInviteRepository.JoinConversationInvite(ctx, invite.ID, member, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		return nil, ErrInviteExpired
+	}
+	if added {
+		i.publishMemberJoined(ctx, invite.ConversationID, userID, userID)
+	}
+	return i.joinedResponse(ctx, invite.ConversationID)
+}
+
+// GetListJoinRequest implements InviteUseCase.
+func (i *inviteUseCase) GetListJoinRequest(ctx context.Context, userID string, conversationID string) ([]*presenter.JoinRequestResponse, error) {
+	ctx, span := i.obs.StartSpan(ctx, "InviteUseCase.GetListJoinRequest")
+	defer span()
+
+	err := i.checkGroupAdmin(ctx, userID, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	joinRequests, err := i.conversationInviteRepository.GetListPendingConversationJoinRequest(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]*presenter.JoinRequestResponse, 0, len(joinRequests))
+	if len(joinRequests) == 0 {
+		return response, nil
+	}
+	userIDs := make([]string, 0, len(joinRequests))
+	for _, joinRequest := range joinRequests {
+		userIDs = append(userIDs, joinRequest.UserID)
+	}
+	users, err := i.userRepository.GetListUserByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	userByID := make(map[string]*domain.UserInfo, len(users))
+	for _, user := range users {
+		userByID[user.ID] = user
+	}
+	for _, joinRequest := range joinRequests {
+		response = append(response, toJoinRequestResponse(joinRequest, userByID[joinRequest.UserID]))
+	}
+	return response, nil
+}
+
+// ReviewJoinRequest implements InviteUseCase.
+// An approved user joins the group as if they used the invite themselves,
+// the system message names the admin who let them in.
+func (i *inviteUseCase) ReviewJoinRequest(ctx context.Context, userID string, request *presenter.ReviewJoinRequestRequest) (*presenter.JoinRequestResponse, error) {
+	ctx, span := i.obs.StartSpan(ctx, "InviteUseCase.ReviewJoinRequest")
+	defer span()
+
+	joinRequest, err := i.conversationInviteRepository.GetConversationJoinRequestByID(ctx, request.JoinRequestID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	if err == pgx.ErrNoRows {
+		return nil, ErrNotFoundJoinRequest
+	}
+	err = i.checkGroupAdmin(ctx, userID, joinRequest.ConversationID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := domain.JoinRequestStatusRejected
+	if request.Approve {
+		status = domain.JoinRequestStatusApproved
+	}
+	now := time.Now()
+	reviewed, err := i.conversationInviteRepository.ReviewConversationJoinRequest(ctx, joinRequest.ID, status, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !reviewed {
+		return nil, ErrJoinRequestReviewed
+	}
+	joinRequest.Status = status
+	joinRequest.ReviewedBy = userID
+	joinRequest.ReviewedAt = &now
+
+	if request.Approve {
+		isMember, err := i.conversationRepository.CheckIsMemberOfConversation(ctx, joinRequest.UserID, joinRequest.ConversationID)
+		if err != nil && err != pgx.ErrNoRows {
+			return nil, err
+		}
+		if !isMember {
+			member, err := newConversationMember(joinRequest.ConversationID, joinRequest.UserID)
+			if err != nil {
+				return nil, err
+			}
+			added, err := i.conversationRepository.AddConversationMember(ctx, member)
+			if err != nil {
+				return nil, err
+			}
+			if added {
+				i.publishMemberJoined(ctx, joinRequest.ConversationID, joinRequest.UserID, userID)
+			}
+		}
+	}
+	user, err := i.userRepository.GetUserByID(ctx, joinRequest.UserID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	return toJoinRequestResponse(joinRequest, user), nil
+}
+
+// checkGroupAdmin allows only the owners and admins of a group to manage its
+// invites.
+func (i *inviteUseCase) checkGroupAdmin(ctx context.Context, userID string, conversationID string) error {
+	err := checkCanManageConversation(ctx, i.conversationRepository, userID, conversationID)
+	if err != nil {
+		return err
+	}
+	conversation, _, err := i.conversationRepository.GetConversationByID(ctx, conversationID)
+	if err != nil {
+		return err
+	}
+	if conversation.Type != domain.ConversationTypeGroup {
+		return ErrNotGroupConversation
+	}
+	return nil
+}
+
+func (i *inviteUseCase) getUsableInvite(ctx context.Context, code string) (*domain.ConversationInvite, error) {
+	invite, err := i.conversationInviteRepository.GetConversationInviteByCode(ctx, code)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	if err == pgx.ErrNoRows || invite.RevokedAt != nil {
+		return nil, ErrNotFoundInvite
+	}
+	if !invite.IsUsable(time.Now()) {
+		return nil, ErrInviteExpired
+	}
+	return invite, nil
+}
+
+// createJoinRequest records the user waiting for approval, a request created
+// concurrently for the same user is returned instead.
+func (i *inviteUseCase) createJoinRequest(ctx context.Context, userID string, invite *domain.ConversationInvite) (*presenter.JoinInviteResponse, error) {
+	id, err := uuid.NewID()
+	if err != nil {
+		return nil, err
+	}
+	joinRequest := &domain.ConversationJoinRequest{
+		ID:             id,
+		InviteID:       invite.ID,
+		ConversationID: invite.ConversationID,
+		UserID:         userID,
+		Status:         domain.JoinRequestStatusPending,
+		CreatedAt:      pointer.ToPtr(time.Now()),
+		UpdatedAt:      pointer.ToPtr(time.Now()),
+	}
+	used, created, err := i.conversationInviteRepository.CreateConversationJoinRequest(ctx, joinRequest, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		return nil, ErrInviteExpired
+	}
+	if !created {
+		joinRequest, err = i.conversationInviteRepository.GetPendingConversationJoinRequest(ctx, invite.ConversationID, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &presenter.JoinInviteResponse{Status: presenter.JoinStatusPending, JoinRequestID: joinRequest.ID}, nil
+}
+
+// publishMemberJoined announces a new member with a system message sent by
+// actorID and notifies the conversation, errors are only logged since the
+// member is already stored.
+func (i *inviteUseCase) publishMemberJoined(ctx context.Context, conversationID string, userID string, actorID string) {
+	logger := i.obs.Logger.WithContext(ctx)
+	_, err := i.conversationUseCase.SendMessage(ctx, &presenter.SendMessageRequest{
+		ConversationID: conversationID,
+		UserID:         actorID,
+		Type:           domain.MessageTypeSystem,
+		Body:           domain.SystemMessageContent{Action: domain.SystemActionMemberJoined, UserID: userID}.String(),
+	})
+	if err != nil {
+		logger.Error("failed to send member joined system message", err, userID)
+	}
+	user, err := i.userRepository.GetUserByID(ctx, userID)
+	if err != nil {
+		logger.Error("failed to get joined member", err, userID)
+		return
+	}
+	err = i.publisher.Publish(ctx, domain.SUBJECT_NEW_MESSAGE, domain.NewWebSocketMessage(domain.WsMemberAdded, map[string]any{
+		"conversation_id": conversationID,
+		"user_id":         user.ID,
+		"full_name":       user.FullName,
+		"avatar":          user.Avatar,
+		"user_type":       user.Type,
+		"role":            domain.ConversationMemberRoleMember,
+		"added_by":        actorID,
+	}))
+	if err != nil {
+		logger.Error("failed to publish member added event", err, userID)
+	}
+}
+
+func (i *inviteUseCase) joinedResponse(ctx context.Context, conversationID string) (*presenter.JoinInviteResponse, error) {
+	conversation, err := i.conversationUseCase.GetConversationByID(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	return &presenter.JoinInviteResponse{Status: presenter.JoinStatusJoined, Conversation: conversation}, nil
+}
+
+func newConversationMember(conversationID string, userID string) (*domain.ConversationMember, error) {
+	id, err := uuid.NewID()
+	if err != nil {
+		return nil, err
+	}
+	return &domain.ConversationMember{
+		ID:             id,
+		ConversationID: conversationID,
+		UserID:         userID,
+		Role:           domain.ConversationMemberRoleMember,
+		CreatedAt:      pointer.ToPtr(time.Now()),
+		UpdatedAt:      pointer.ToPtr(time.Now()),
+	}, nil
+}
+
+func generateInviteCode() (string, error) {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func toInviteResponse(invite *domain.ConversationInvite) *presenter.InviteResponse {
+	return &presenter.InviteResponse{
+		ID:               invite.ID,
+		ConversationID:   invite.ConversationID,
+		Code:             invite.Code,
+		CreatedBy:        invite.CreatedBy,
+		ExpiresAt:        invite.ExpiresAt,
+		MaxUses:          invite.MaxUses,
+		UseCount:         invite.UseCount,
+		RequiresApproval: invite.RequiresApproval,
+		CreatedAt:        invite.CreatedAt,
+	}
+}
+
+func toJoinRequestResponse(joinRequest *domain.ConversationJoinRequest, user *domain.UserInfo) *presenter.JoinRequestResponse {
+	response := &presenter.JoinRequestResponse{
+		ID:             joinRequest.ID,
+		InviteID:       joinRequest.InviteID,
+		ConversationID: joinRequest.ConversationID,
+		Status:         joinRequest.Status,
+		ReviewedBy:     joinRequest.ReviewedBy,
+		ReviewedAt:     joinRequest.ReviewedAt,
+		CreatedAt:      joinRequest.CreatedAt,
+	}
+	if user != nil {
+		response.User = &presenter.UserResponse{
+			UserID:   user.ID,
+			FullName: user.FullName,
+			Avatar:   user.Avatar,
+			UserType: user.Type,
+		}
+	}
+	return response
+}
+
+var _ InviteUseCase = (*inviteUseCase)(nil)
+
+func NewInviteUseCase(conversationRepository domain.ConversationRepository, conversationInviteRepository domain.ConversationInviteRepository, userRepository domain.UserRepository, conversationUseCase ConversationUseCase, publisher pubsub.Publisher, obs *observability.Observability) InviteUseCase {
+	return &inviteUseCase{
+		conversationRepository:       conversationRepository,
+		conversationInviteRepository: conversationInviteRepository,
+		userRepository:               userRepository,
+		conversationUseCase:          conversationUseCase,
+		publisher:                    publisher,
+		obs:                          obs,
+	}
+}
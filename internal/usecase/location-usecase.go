@@ -0,0 +1,278 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/internal/presenter"
+	"github.com/chat-socio/backend/pkg/observability"
+	"github.com/chat-socio/backend/pkg/pointer"
+	"github.com/chat-socio/backend/pubsub"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	liveLocationExpiryInterval  = 30 * time.Second
+	liveLocationExpiryBatchSize = 100
+)
+
+var (
+	ErrInvalidLocation      = errors.New("invalid location")
+	ErrNotFoundLiveLocation = errors.New("live location not found")
+	ErrLiveLocationEnded    = errors.New("live location has ended")
+)
+
+type LocationUseCase interface {
+	StartLiveLocation(ctx context.Context, request *presenter.StartLiveLocationRequest) (*presenter.MessageResponse, error)
+	UpdateLiveLocation(ctx context.Context, userID string, request *presenter.UpdateLiveLocationRequest) (*presenter.LocationResponse, error)
+	StopLiveLocation(ctx context.Context, userID string, messageID string) (*presenter.LocationResponse, error)
+	RunLiveLocationExpiry(ctx context.Context)
+}
+
+type locationUseCase struct {
+	conversationRepository domain.ConversationRepository
+	messageRepository      domain.MessageRepository
+	liveLocationRepository domain.LiveLocationRepository
+	conversationUseCase    ConversationUseCase
+	publisher              pubsub.Publisher
+	obs                    *observability.Observability
+}
+
+// StartLiveLocation implements LocationUseCase.
+func (l *locationUseCase) StartLiveLocation(ctx context.Context, request *presenter.StartLiveLocationRequest) (*presenter.MessageResponse, error) {
+	ctx, span := l.obs.StartSpan(ctx, "LocationUseCase.StartLiveLocation")
+	defer span()
+
+	body, err := json.Marshal(domain.LocationContent{
+		Latitude:  request.Latitude,
+		Longitude: request.Longitude,
+		Accuracy:  request.Accuracy,
+		Label:     strings.TrimSpace(request.Label),
+	})
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	liveUntil := now.Add(time.Duration(request.Duration) * time.Second)
+	response, err := l.conversationUseCase.SendMessage(ctx, &presenter.SendMessageRequest{
+		ConversationID: request.ConversationID,
+		UserID:         request.UserID,
+		Type:           domain.MessageTypeLocation,
+		Body:           string(body),
+		UserOnlineID:   request.UserOnlineID,
+		IsBot:          request.IsBot,
+		LiveUntil:      &liveUntil,
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = l.liveLocationRepository.CreateLiveLocation(ctx, &domain.LiveLocation{
+		MessageID:      response.MessageID,
+		ConversationID: request.ConversationID,
+		UserID:         request.UserID,
+		ExpiresAt:      &liveUntil,
+		CreatedAt:      &now,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// UpdateLiveLocation implements LocationUseCase.
+// Only the sender can move a live location, the new point replaces the
+// previous one in the message body.
+func (l *locationUseCase) UpdateLiveLocation(ctx context.Context, userID string, request *presenter.UpdateLiveLocationRequest) (*presenter.LocationResponse, error) {
+	ctx, span := l.obs.StartSpan(ctx, "LocationUseCase.UpdateLiveLocation")
+	defer span()
+
+	now := time.Now()
+	message, location, err := l.getLiveLocation(ctx, userID, request.MessageID)
+	if err != nil {
+		return nil, err
+	}
+	if !location.IsLive(now) {
+		return nil, ErrLiveLocationEnded
+	}
+	location.Latitude = request.Latitude
+	location.Longitude = request.Longitude
+	location.Accuracy = request.Accuracy
+	location.UpdatedAt = &now
+	return l.saveLocation(ctx, message, location)
+}
+
+// StopLiveLocation implements LocationUseCase.
+func (l *locationUseCase) StopLiveLocation(ctx context.Context, userID string, messageID string) (*presenter.LocationResponse, error) {
+	ctx, span := l.obs.StartSpan(ctx, "LocationUseCase.StopLiveLocation")
+	defer span()
+
+	now := time.Now()
+	message, location, err := l.getLiveLocation(ctx, userID, messageID)
+	if err != nil {
+		return nil, err
+	}
+	ended, err := l.liveLocationRepository.EndLiveLocation(ctx, messageID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !ended {
+		return nil, ErrLiveLocationEnded
+	}
+	location.EndedAt = &now
+	location.UpdatedAt = &now
+	return l.saveLocation(ctx, message, location)
+}
+
+// RunLiveLocationExpiry implements LocationUseCase.
+// It blocks until ctx is done, ending the live locations whose duration is
+// over so that members see the last point as final.
+func (l *locationUseCase) RunLiveLocationExpiry(ctx context.Context) {
+	ticker := time.NewTicker(liveLocationExpiryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		logger := l.obs.Logger.WithContext(ctx)
+		liveLocations, err := l.liveLocationRepository.EndExpiredLiveLocations(ctx, time.Now(), liveLocationExpiryBatchSize)
+		if err != nil {
+			logger.Error("failed to end expired live locations", err)
+			continue
+		}
+		for _, liveLocation := range liveLocations {
+			message, err := l.messageRepository.GetMessageByID(ctx, liveLocation.MessageID)
+			if err != nil {
+				logger.Error("failed to get live location message", err, liveLocation.MessageID)
+				continue
+			}
+			location, err := domain.ParseLocationContent(message.Body)
+			if err != nil || message.DeletedAt != nil {
+				continue
+			}
+			location.EndedAt = liveLocation.ExpiresAt
+			_, err = l.saveLocation(ctx, message, location)
+			if err != nil {
+				logger.Error("failed to end live location", err, liveLocation.MessageID)
+			}
+		}
+	}
+}
+
+// getLiveLocation returns the live location message of the user, who must
+// still be a member of its conversation.
+func (l *locationUseCase) getLiveLocation(ctx context.Context, userID string, messageID string) (*domain.Message, *domain.LocationContent, error) {
+	liveLocation, err := l.liveLocationRepository.GetLiveLocationByMessageID(ctx, messageID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, nil, err
+	}
+	if err == pgx.ErrNoRows || liveLocation.UserID != userID {
+		return nil, nil, ErrNotFoundLiveLocation
+	}
+	if liveLocation.EndedAt != nil {
+		return nil, nil, ErrLiveLocationEnded
+	}
+	isMember, err := l.conversationRepository.CheckIsMemberOfConversation(ctx, userID, liveLocation.ConversationID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, nil, err
+	}
+	if !isMember {
+		return nil, nil, domain.ErrNotFoundMemberOfConversation
+	}
+	message, err := l.messageRepository.GetMessageByID(ctx, messageID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, nil, err
+	}
+	if err == pgx.ErrNoRows || message.DeletedAt != nil {
+		return nil, nil, ErrNotFoundLiveLocation
+	}
+	location, err := domain.ParseLocationContent(message.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	return message, location, nil
+}
+
+// saveLocation persists the location in the message body and broadcasts it
+// to the members of the conversation.
+func (l *locationUseCase) saveLocation(ctx context.Context, message *domain.Message, location *domain.LocationContent) (*presenter.LocationResponse, error) {
+	body, err := json.Marshal(location)
+	if err != nil {
+		return nil, err
+	}
+	err = l.messageRepository.UpdateMessageBody(ctx, message.ID, string(body), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	response := buildLocationResponse(message, location)
+	err = l.publisher.Publish(ctx, domain.SUBJECT_NEW_MESSAGE, domain.NewWebSocketMessage(domain.WsLocationUpdated, map[string]any{
+		"conversation_id": message.ConversationID,
+		"message_id":      message.ID,
+		"user_id":         message.UserID,
+		"location":        response,
+	}))
+	if err != nil {
+		l.obs.Logger.WithContext(ctx).Error("failed to publish location updated", err, message.ID)
+	}
+	return response, nil
+}
+
+// normalizeLocationBody validates the location of a message body. The live
+// fields are only taken from liveUntil, clients can not set them in the body.
+func normalizeLocationBody(body string, liveUntil *time.Time) (string, error) {
+	location, err := domain.ParseLocationContent(body)
+	if err != nil {
+		return "", fmt.Errorf("%w: body must be a json location", ErrInvalidLocation)
+	}
+	location.Label = strings.TrimSpace(location.Label)
+	if err := location.Validate(); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidLocation, err)
+	}
+	location.Live = liveUntil != nil
+	location.LiveUntil = liveUntil
+	location.EndedAt = nil
+	location.UpdatedAt = nil
+	if location.Live {
+		location.UpdatedAt = pointer.ToPtr(time.Now())
+	}
+	normalized, err := json.Marshal(location)
+	if err != nil {
+		return "", err
+	}
+	return string(normalized), nil
+}
+
+func buildLocationResponse(message *domain.Message, location *domain.LocationContent) *presenter.LocationResponse {
+	return &presenter.LocationResponse{
+		MessageID:      message.ID,
+		ConversationID: message.ConversationID,
+		UserID:         message.UserID,
+		Latitude:       location.Latitude,
+		Longitude:      location.Longitude,
+		Accuracy:       location.Accuracy,
+		Label:          location.Label,
+		Live:           location.IsLive(time.Now()),
+		LiveUntil:      location.LiveUntil,
+		EndedAt:        location.EndedAt,
+		UpdatedAt:      location.UpdatedAt,
+	}
+}
+
+var _ LocationUseCase = (*locationUseCase)(nil)
+
+func NewLocationUseCase(conversationRepository domain.ConversationRepository, messageRepository domain.MessageRepository, liveLocationRepository domain.LiveLocationRepository, conversationUseCase ConversationUseCase, publisher pubsub.Publisher, obs *observability.Observability) LocationUseCase {
+	return &locationUseCase{
+		conversationRepository: conversationRepository,
+		messageRepository:      messageRepository,
+		liveLocationRepository: liveLocationRepository,
+		conversationUseCase:    conversationUseCase,
+		publisher:              publisher,
+		obs:                    obs,
+	}
+}
@@ -0,0 +1,189 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/internal/presenter"
+	"github.com/chat-socio/backend/pkg/pointer"
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrNotMessageSender = errors.New("user is not the sender of the message")
+
+// HandleMessageDelivered implements ConversationUseCase.
+// The sender is told about the recipients the message was not delivered to
+// before.
+func (c *conversationUseCase) HandleMessageDelivered(ctx context.Context, delivered domain.DeliveredMessage) error {
+	logger := c.obs.Logger.WithContext(ctx)
+	deliveredAt := time.Now()
+	if delivered.DeliveredAt != nil {
+		deliveredAt = *delivered.DeliveredAt
+	}
+	userIDs, err := c.messageDeliveryRepository.CreateMessageDeliveries(ctx, delivered.MessageID, delivered.UserIDs, deliveredAt)
+	if err != nil {
+		logger.Error("failed to create message deliveries", err, delivered)
+		return err
+	}
+	if len(userIDs) == 0 {
+		return nil
+	}
+	err = c.messagePublisher.Publish(ctx, domain.SUBJECT_NEW_MESSAGE, domain.NewWebSocketMessage(domain.WsMessageDelivered, map[string]any{
+		"user_id":         delivered.SenderID,
+		"conversation_id": delivered.ConversationID,
+		"message_id":      delivered.MessageID,
+		"delivered_to":    userIDs,
+		"delivered_at":    deliveredAt,
+	}))
+	if err != nil {
+		logger.Error("failed to publish message delivered", err, delivered)
+	}
+	return nil
+}
+
+// AckDeliveredMessages implements ConversationUseCase.
+// Clients call it for the messages they received from push notifications.
+func (c *conversationUseCase) AckDeliveredMessages(ctx context.Context, userID string, request *presenter.DeliveredMessageRequest) error {
+	ctx, span := c.obs.StartSpan(ctx, "ConversationUsecase.AckDeliveredMessages")
+	defer span()
+
+	isMember, err := c.conversationRepository.CheckIsMemberOfConversation(ctx, userID, request.ConversationID)
+	if err != nil && err != pgx.ErrNoRows {
+		return err
+	}
+	if !isMember {
+		return domain.ErrNotFoundMemberOfConversation
+	}
+	messages, err := c.messageRepository.GetListMessageByIDs(ctx, request.MessageIDs)
+	if err != nil {
+		return err
+	}
+	for _, message := range messages {
+		if message.ConversationID != request.ConversationID || message.UserID == userID {
+			continue
+		}
+		err = c.messagePublisher.Publish(ctx, domain.SUBJECT_MESSAGE_DELIVERED, domain.DeliveredMessage{
+			ConversationID: message.ConversationID,
+			MessageID:      message.ID,
+			SenderID:       message.UserID,
+			UserIDs:        []string{userID},
+			DeliveredAt:    pointer.ToPtr(time.Now()),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetMessageStatus implements ConversationUseCase.
+// Only the sender can see who received and read the message.
+func (c *conversationUseCase) GetMessageStatus(ctx context.Context, userID string, messageID string) (*presenter.MessageStatusResponse, error) {
+	ctx, span := c.obs.StartSpan(ctx, "ConversationUsecase.GetMessageStatus")
+	defer span()
+
+	message, err := c.messageRepository.GetMessageByID(ctx, messageID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	if err == pgx.ErrNoRows {
+		return nil, ErrNotFoundMessage
+	}
+	if message.UserID != userID {
+		return nil, ErrNotMessageSender
+	}
+	_, members, err := c.conversationRepository.GetConversationByID(ctx, message.ConversationID)
+	if err != nil {
+		return nil, err
+	}
+	deliveries, err := c.messageDeliveryRepository.GetListMessageDelivery(ctx, message.ID)
+	if err != nil {
+		return nil, err
+	}
+	seenMessages, err := c.seenMessageRepository.GetListSeenMessageByConversationID(ctx, message.ConversationID)
+	if err != nil {
+		return nil, err
+	}
+	deliveredAt := make(map[string]*time.Time, len(deliveries))
+	for _, delivery := range deliveries {
+		deliveredAt[delivery.UserID] = delivery.DeliveredAt
+	}
+	seenAt := make(map[string]*time.Time, len(seenMessages))
+	for _, seenMessage := range seenMessages {
+		// the seen message of a user is the newest message they read
+		if seenMessage.MessageID >= message.ID {
+			seenAt[seenMessage.UserID] = seenMessage.UpdatedAt
+		}
+	}
+
+	response := &presenter.MessageStatusResponse{
+		MessageID:  message.ID,
+		Status:     domain.MessageStatusSeen,
+		Recipients: make([]*presenter.MessageRecipientStatusResponse, 0, len(members)),
+	}
+	statuses := []string{domain.MessageStatusSent, domain.MessageStatusDelivered, domain.MessageStatusSeen}
+	for _, member := range members {
+		if member.UserID == message.UserID {
+			continue
+		}
+		recipient := &presenter.MessageRecipientStatusResponse{
+			User: &presenter.UserResponse{
+				UserID:   member.UserID,
+				FullName: member.FullName,
+				Avatar:   member.Avatar,
+				UserType: member.UserType,
+			},
+			Status:      domain.MessageStatusSent,
+			DeliveredAt: deliveredAt[member.UserID],
+			SeenAt:      seenAt[member.UserID],
+		}
+		if recipient.SeenAt != nil {
+			recipient.Status = domain.MessageStatusSeen
+			if recipient.DeliveredAt == nil {
+				recipient.DeliveredAt = recipient.SeenAt
+			}
+			response.SeenCount++
+		} else if recipient.DeliveredAt != nil {
+			recipient.Status = domain.MessageStatusDelivered
+		}
+		if recipient.DeliveredAt != nil {
+			response.DeliveredCount++
+		}
+		if slices.Index(statuses, recipient.Status) < slices.Index(statuses, response.Status) {
+			response.Status = recipient.Status
+		}
+		response.Recipients = append(response.Recipients, recipient)
+	}
+	return response, nil
+}
+
+// handleSendEventMessage sends a new message to the connections of the
+// conversation and records the delivery to the recipients it reached.
+func (c *conversationUseCase) handleSendEventMessage(ctx context.Context, message *domain.WebSocketMessage) error {
+	userIDs, err := c.sendEventToConversation(ctx, message)
+	if err != nil {
+		return err
+	}
+	messageID, _ := message.Payload["id"].(string)
+	senderID, _ := message.Payload["user_id"].(string)
+	userIDs = slices.DeleteFunc(userIDs, func(userID string) bool {
+		return userID == senderID
+	})
+	if messageID == "" || len(userIDs) == 0 {
+		return nil
+	}
+	err = c.messagePublisher.Publish(ctx, domain.SUBJECT_MESSAGE_DELIVERED, domain.DeliveredMessage{
+		ConversationID: message.Payload["conversation_id"].(string),
+		MessageID:      messageID,
+		SenderID:       senderID,
+		UserIDs:        userIDs,
+		DeliveredAt:    pointer.ToPtr(time.Now()),
+	})
+	if err != nil {
+		c.obs.Logger.WithContext(ctx).Error("failed to publish message delivered", err, messageID)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/internal/presenter"
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrMessageNotEditable = errors.New("only text messages can be edited")
+
+// EditMessage implements ConversationUseCase.
+// Users can only edit their own text messages while they are still members of
+// the conversation.
+func (c *conversationUseCase) EditMessage(ctx context.Context, userID string, request *presenter.EditMessageRequest) (*presenter.MessageResponse, error) {
+	ctx, span := c.obs.StartSpan(ctx, "ConversationUsecase.EditMessage")
+	defer span()
+
+	message, err := c.messageRepository.GetMessageByID(ctx, request.MessageID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	now := time.Now()
+	if err == pgx.ErrNoRows || message.UserID != userID || message.DeletedAt != nil ||
+		(message.ExpiresAt != nil && !message.ExpiresAt.After(now)) {
+		return nil, ErrNotFoundMessage
+	}
+	if message.Type != domain.MessageTypeText {
+		return nil, ErrMessageNotEditable
+	}
+	isMember, err := c.conversationRepository.CheckIsMemberOfConversation(ctx, userID, message.ConversationID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	if !isMember {
+		return nil, domain.ErrNotFoundMemberOfConversation
+	}
+
+	if request.Body != message.Body {
+		err = c.messageRepository.UpdateMessageBody(ctx, message.ID, request.Body, now)
+		if err != nil {
+			return nil, err
+		}
+		message.Body = request.Body
+		message.UpdatedAt = &now
+		err = c.messagePublisher.Publish(ctx, domain.SUBJECT_NEW_MESSAGE, domain.NewWebSocketMessage(domain.WsMessageUpdated, map[string]any{
+			"conversation_id": message.ConversationID,
+			"message_id":      message.ID,
+			"user_id":         message.UserID,
+			"body":            message.Body,
+			"updated_at":      message.UpdatedAt,
+		}))
+		if err != nil {
+			c.obs.Logger.WithContext(ctx).Error("failed to publish message updated", err, request)
+		}
+	}
+
+	return &presenter.MessageResponse{
+		MessageID:      message.ID,
+		Body:           message.Body,
+		CreatedAt:      message.CreatedAt,
+		UpdatedAt:      message.UpdatedAt,
+		Type:           message.Type,
+		ReplyTo:        message.ReplyTo,
+		DisplayName:    message.DisplayName,
+		ConversationID: message.ConversationID,
+		ThreadID:       message.ThreadID,
+		ForwardedFrom:  message.ForwardedFrom,
+		ExpiresAt:      message.ExpiresAt,
+	}, nil
+}
@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/chat-socio/backend/configuration"
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/pkg/observability"
+	"github.com/chat-socio/backend/pkg/storage"
+	"github.com/chat-socio/backend/pubsub"
+)
+
+const (
+	messageExpiryInterval  = 30 * time.Second
+	messageExpiryBatchSize = 200
+)
+
+type MessageExpiryUseCase interface {
+	RunMessageExpiry(ctx context.Context)
+}
+
+type messageExpiryUseCase struct {
+	messageRepository     domain.MessageRepository
+	unreadCountRepository domain.UnreadCountRepository
+	storage               storage.ObjectStorage
+	publisher             pubsub.Publisher
+	obs                   *observability.Observability
+}
+
+// RunMessageExpiry implements MessageExpiryUseCase.
+// It blocks until ctx is done, deleting the expired messages with their
+// attachments. Rows are claimed with skip locked so every replica can run it.
+func (m *messageExpiryUseCase) RunMessageExpiry(ctx context.Context) {
+	ticker := time.NewTicker(messageExpiryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		logger := m.obs.Logger.WithContext(ctx)
+		for {
+			messages, err := m.messageRepository.DeleteExpiredMessages(ctx, time.Now(), messageExpiryBatchSize)
+			if err != nil {
+				logger.Error("failed to delete expired messages", err)
+				break
+			}
+			m.deleteAttachments(ctx, messages)
+			m.publishMessageExpired(ctx, messages)
+			if len(messages) < messageExpiryBatchSize {
+				break
+			}
+		}
+	}
+}
+
+// deleteAttachments removes the uploaded files of the deleted messages. A file
+// is kept while anything else still points at it, since a message body can be
+// any url of our storage, sticker files are never removed.
+func (m *messageExpiryUseCase) deleteAttachments(ctx context.Context, messages []*domain.Message) {
+	logger := m.obs.Logger.WithContext(ctx)
+	for _, message := range messages {
+		bucket, objectName, ok := attachmentObject(message)
+		if !ok {
+			continue
+		}
+		inUse, err := m.messageRepository.CheckMessageBodyInUse(ctx, message.Body)
+		if err != nil {
+			logger.Error("failed to check attachment usage", err, message.ID)
+			continue
+		}
+		if inUse {
+			continue
+		}
+		err = m.storage.DeleteObject(ctx, bucket, objectName)
+		if err != nil {
+			logger.Error("failed to delete expired attachment", err, message.Body)
+		}
+	}
+}
+
+// publishMessageExpired tells every conversation which of its messages are
+// gone, unread counts that included them are recounted.
+func (m *messageExpiryUseCase) publishMessageExpired(ctx context.Context, messages []*domain.Message) {
+	logger := m.obs.Logger.WithContext(ctx)
+	messageIDs := make(map[string][]string)
+	for _, message := range messages {
+		messageIDs[message.ConversationID] = append(messageIDs[message.ConversationID], message.ID)
+	}
+	for conversationID, ids := range messageIDs {
+		unreadCounts, err := m.unreadCountRepository.RecountConversationUnreadCount(ctx, conversationID)
+		if err != nil {
+			logger.Error("failed to recount unread counts", err, conversationID)
+		}
+		publishUnreadCounts(ctx, m.publisher, m.unreadCountRepository, m.obs, unreadCounts)
+		err = m.publisher.Publish(ctx, domain.SUBJECT_NEW_MESSAGE, domain.NewWebSocketMessage(domain.WsMessageExpired, map[string]any{
+			"conversation_id": conversationID,
+			"message_ids":     ids,
+		}))
+		if err != nil {
+			logger.Error("failed to publish message expired", err, conversationID)
+		}
+	}
+}
+
+// attachmentObject returns the bucket and object of an uploaded file message,
+// ok is false for bodies that are not files of our storage.
+func attachmentObject(message *domain.Message) (string, string, bool) {
+	switch message.Type {
+	case domain.MessageTypeImage, domain.MessageTypeVideo, domain.MessageTypeAudio, domain.MessageTypeFile:
+	default:
+		return "", "", false
+	}
+	return uploadedObject(message.Body)
+}
+
+// uploadedObject returns the bucket and object of a file users uploaded to
+// our storage, ok is false for other urls and for stickers and exports.
+func uploadedObject(url string) (string, string, bool) {
+	minioConfig := configuration.ConfigInstance.Minio
+	path, ok := strings.CutPrefix(url, minioConfig.PublicEndpoint+"/")
+	if !ok || minioConfig.PublicEndpoint == "" {
+		return "", "", false
+	}
+	bucket, objectName, ok := strings.Cut(path, "/")
+	if !ok || bucket == "" || objectName == "" || bucket == minioConfig.StickerBucket || bucket == minioConfig.ExportBucket {
+		return "", "", false
+	}
+	return bucket, objectName, true
+}
+
+var _ MessageExpiryUseCase = (*messageExpiryUseCase)(nil)
+
+func NewMessageExpiryUseCase(messageRepository domain.MessageRepository, unreadCountRepository domain.UnreadCountRepository, storage storage.ObjectStorage, publisher pubsub.Publisher, obs *observability.Observability) MessageExpiryUseCase {
+	return &messageExpiryUseCase{
+		messageRepository:     messageRepository,
+		unreadCountRepository: unreadCountRepository,
+		storage:               storage,
+		publisher:             publisher,
+		obs:                   obs,
+	}
+}
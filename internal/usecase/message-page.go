@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/internal/presenter"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetMessagePage implements ConversationUseCase.
+// Around a message, the older half of the page comes before it and the newer
+// half after it. One extra message is read on each side to tell whether more
+// are left.
+func (c *conversationUseCase) GetMessagePage(ctx context.Context, userID string, request *presenter.GetMessagePageRequest) (*presenter.MessagePageResponse, error) {
+	ctx, span := c.obs.StartSpan(ctx, "ConversationUsecase.GetMessagePage")
+	defer span()
+
+	isMember, err := c.conversationRepository.CheckIsMemberOfConversation(ctx, userID, request.ConversationID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	if !isMember {
+		return nil, domain.ErrNotFoundMemberOfConversation
+	}
+
+	var before, after []*domain.Message
+	hasMoreBefore, hasMoreAfter := false, false
+	if request.AroundMessageID != "" {
+		target, err := c.messageRepository.GetMessageByID(ctx, request.AroundMessageID)
+		if err != nil && err != pgx.ErrNoRows {
+			return nil, err
+		}
+		if err == pgx.ErrNoRows || target.ConversationID != request.ConversationID || (target.ExpiresAt != nil && !target.ExpiresAt.After(time.Now())) {
+			return nil, ErrNotFoundMessage
+		}
+		beforeLimit := request.Limit / 2
+		afterLimit := request.Limit - beforeLimit - 1
+		before, err = c.messageRepository.GetListMessageByConversationID(ctx, request.ConversationID, target.ID, beforeLimit+1)
+		if err != nil {
+			return nil, err
+		}
+		after, err = c.messageRepository.GetListMessageAfterID(ctx, request.ConversationID, target.ID, afterLimit+1)
+		if err != nil {
+			return nil, err
+		}
+		hasMoreBefore, before = len(before) > beforeLimit, before[:min(len(before), beforeLimit)]
+		hasMoreAfter, after = len(after) > afterLimit, after[:min(len(after), afterLimit)]
+		after = append([]*domain.Message{target}, after...)
+	} else {
+		after, err = c.messageRepository.GetListMessageAfterID(ctx, request.ConversationID, request.AfterID, request.Limit+1)
+		if err != nil {
+			return nil, err
+		}
+		hasMoreAfter, after = len(after) > request.Limit, after[:min(len(after), request.Limit)]
+		// the page starts at after_id, there is history before it
+		hasMoreBefore = true
+	}
+
+	// newest first, like the pages of GetListMessageByConversationID
+	slices.Reverse(after)
+	messages := append(after, before...)
+	messageResponses, err := c.buildMessageResponses(ctx, userID, messages)
+	if err != nil {
+		return nil, err
+	}
+	response := &presenter.MessagePageResponse{
+		Messages:      messageResponses,
+		HasMoreBefore: hasMoreBefore && len(messages) > 0,
+		HasMoreAfter:  hasMoreAfter,
+	}
+	if response.HasMoreBefore {
+		response.BeforeID = messages[len(messages)-1].ID
+	}
+	if response.HasMoreAfter {
+		response.AfterID = messages[0].ID
+	}
+	return response, nil
+}
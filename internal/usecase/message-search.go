@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/internal/presenter"
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrInvalidSearchCursor = errors.New("invalid search cursor")
+
+// SearchMessages implements ConversationUseCase.
+// Only the conversations the user is a member of are searched.
+func (c *conversationUseCase) SearchMessages(ctx context.Context, request *presenter.SearchMessageRequest) (*presenter.SearchMessageResponse, error) {
+	ctx, span := c.obs.StartSpan(ctx, "ConversationUsecase.SearchMessages")
+	defer span()
+
+	if request.ConversationID != "" {
+		isMember, err := c.conversationRepository.CheckIsMemberOfConversation(ctx, request.UserID, request.ConversationID)
+		if err != nil && err != pgx.ErrNoRows {
+			return nil, err
+		}
+		if !isMember {
+			return nil, domain.ErrNotFoundMemberOfConversation
+		}
+	}
+	filter := &domain.MessageSearchFilter{
+		UserID:         request.UserID,
+		Query:          request.Query,
+		ConversationID: request.ConversationID,
+		FromUserID:     request.FromUserID,
+		Before:         request.Before,
+		After:          request.After,
+		Type:           request.Type,
+		Limit:          request.Limit,
+	}
+	if request.Cursor != "" {
+		rank, id, err := decodeSearchCursor(request.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		filter.CursorRank = rank
+		filter.CursorID = id
+	}
+
+	results, err := c.messageRepository.SearchMessages(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	messages := make([]*domain.Message, 0, len(results))
+	for _, result := range results {
+		messages = append(messages, result.Message)
+	}
+	messageResponses, err := c.buildMessageResponses(ctx, request.UserID, messages)
+	if err != nil {
+		return nil, err
+	}
+	response := &presenter.SearchMessageResponse{
+		Results: make([]*presenter.SearchMessageResult, 0, len(results)),
+	}
+	for i, result := range results {
+		response.Results = append(response.Results, &presenter.SearchMessageResult{
+			Message: messageResponses[i],
+			Snippet: result.Snippet,
+			Rank:    result.Rank,
+		})
+	}
+	if len(results) == request.Limit {
+		last := results[len(results)-1]
+		response.NextCursor = encodeSearchCursor(last.Rank, last.Message.ID)
+	}
+	return response, nil
+}
+
+// encodeSearchCursor packs the rank and id of the last result, the rank is
+// kept exact so that the next page starts right after it.
+func encodeSearchCursor(rank float32, id string) string {
+	cursor := strconv.FormatFloat(float64(rank), 'g', -1, 32) + ":" + id
+	return base64.RawURLEncoding.EncodeToString([]byte(cursor))
+}
+
+func decodeSearchCursor(cursor string) (float32, string, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, "", ErrInvalidSearchCursor
+	}
+	rank, id, ok := strings.Cut(string(b), ":")
+	if !ok || id == "" {
+		return 0, "", ErrInvalidSearchCursor
+	}
+	value, err := strconv.ParseFloat(rank, 32)
+	if err != nil {
+		return 0, "", ErrInvalidSearchCursor
+	}
+	return float32(value), id, nil
+}
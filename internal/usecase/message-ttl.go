@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/internal/presenter"
+	"github.com/chat-socio/backend/pkg/pointer"
+	"github.com/jackc/pgx/v5"
+)
+
+// UpdateMessageTTL implements ConversationUseCase.
+// The setting only applies to messages sent after the change, the change is
+// announced with a system message.
+func (c *conversationUseCase) UpdateMessageTTL(ctx context.Context, userID string, request *presenter.UpdateMessageTTLRequest) (*presenter.ConversationResponse, error) {
+	ctx, span := c.obs.StartSpan(ctx, "ConversationUsecase.UpdateMessageTTL")
+	defer span()
+	logger := c.obs.Logger.WithContext(ctx)
+
+	err := checkCanManageConversation(ctx, c.conversationRepository, userID, request.ConversationID)
+	if err != nil {
+		return nil, err
+	}
+	messageTTL, err := c.conversationRepository.GetConversationMessageTTL(ctx, request.ConversationID)
+	if err != nil {
+		return nil, err
+	}
+	if messageTTL != request.MessageTTL {
+		err = c.conversationRepository.UpdateConversationMessageTTL(ctx, request.ConversationID, request.MessageTTL)
+		if err != nil {
+			return nil, err
+		}
+		_, err = c.SendMessage(ctx, &presenter.SendMessageRequest{
+			ConversationID: request.ConversationID,
+			UserID:         userID,
+			Type:           domain.MessageTypeSystem,
+			Body:           domain.SystemMessageContent{Action: domain.SystemActionMessageTTL, MessageTTL: request.MessageTTL}.String(),
+		})
+		if err != nil {
+			logger.Error("failed to send message ttl system message", err, request)
+		}
+	}
+	return c.GetConversationByID(ctx, request.ConversationID)
+}
+
+// getMessageExpiresAt returns when a message sent now to the conversation
+// expires, nil when its messages do not disappear.
+func (c *conversationUseCase) getMessageExpiresAt(ctx context.Context, conversationID string) (*time.Time, error) {
+	messageTTL, err := c.conversationRepository.GetConversationMessageTTL(ctx, conversationID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	ttl := domain.MessageTTLs[messageTTL]
+	if ttl == 0 {
+		return nil, nil
+	}
+	return pointer.ToPtr(time.Now().Add(ttl)), nil
+}
+
+// checkCanManageConversation allows every member of a DM to change the
+// conversation, in groups only owners and admins can.
+func checkCanManageConversation(ctx context.Context, conversationRepository domain.ConversationRepository, userID string, conversationID string) error {
+	member, err := conversationRepository.GetConversationMember(ctx, conversationID, userID)
+	if err != nil && err != pgx.ErrNoRows {
+		return err
+	}
+	if err == pgx.ErrNoRows {
+		return domain.ErrNotFoundMemberOfConversation
+	}
+	if member.IsAdmin() {
+		return nil
+	}
+	conversation, _, err := conversationRepository.GetConversationByID(ctx, conversationID)
+	if err != nil {
+		return err
+	}
+	if conversation.Type == domain.ConversationTypeGroup {
+		return domain.ErrNotAdminOfConversation
+	}
+	return nil
+}
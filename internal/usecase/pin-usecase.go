@@ -0,0 +1,197 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/internal/presenter"
+	"github.com/chat-socio/backend/pkg/observability"
+	"github.com/chat-socio/backend/pkg/pointer"
+	"github.com/chat-socio/backend/pubsub"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrNotFoundMessage = errors.New("message not found")
+	ErrTooManyPins     = errors.New("too many pinned messages")
+	ErrNotPinned       = errors.New("message is not pinned")
+)
+
+type PinUseCase interface {
+	PinMessage(ctx context.Context, userID string, request *presenter.PinMessageRequest) (*presenter.PinnedMessageResponse, error)
+	UnpinMessage(ctx context.Context, userID string, conversationID string, messageID string) error
+	GetListPinnedMessage(ctx context.Context, userID string, conversationID string) ([]*presenter.PinnedMessageResponse, error)
+}
+
+type pinUseCase struct {
+	conversationRepository  domain.ConversationRepository
+	messageRepository       domain.MessageRepository
+	pinnedMessageRepository domain.PinnedMessageRepository
+	conversationUseCase     ConversationUseCase
+	publisher               pubsub.Publisher
+	obs                     *observability.Observability
+}
+
+// PinMessage implements PinUseCase.
+// Pinning a pinned message again returns the existing pin.
+func (p *pinUseCase) PinMessage(ctx context.Context, userID string, request *presenter.PinMessageRequest) (*presenter.PinnedMessageResponse, error) {
+	ctx, span := p.obs.StartSpan(ctx, "PinUseCase.PinMessage")
+	defer span()
+
+	err := checkCanManageConversation(ctx, p.conversationRepository, userID, request.ConversationID)
+	if err != nil {
+		return nil, err
+	}
+	message, err := p.messageRepository.GetMessageByID(ctx, request.MessageID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	if err == pgx.ErrNoRows || message.ConversationID != request.ConversationID || message.DeletedAt != nil {
+		return nil, ErrNotFoundMessage
+	}
+
+	pins, err := p.pinnedMessageRepository.GetListPinnedMessage(ctx, request.ConversationID)
+	if err != nil {
+		return nil, err
+	}
+	for _, pin := range pins {
+		if pin.MessageID == message.ID {
+			return buildPinnedMessageResponse(pin, message), nil
+		}
+	}
+
+	pin := &domain.PinnedMessage{
+		ConversationID: request.ConversationID,
+		MessageID:      message.ID,
+		PinnedBy:       userID,
+		PinnedAt:       pointer.ToPtr(time.Now()),
+	}
+	pinned, count, err := p.pinnedMessageRepository.CreatePinnedMessage(ctx, pin, domain.MaxPinnedMessages)
+	if err != nil {
+		return nil, err
+	}
+	if !pinned {
+		return nil, ErrTooManyPins
+	}
+	p.publishPinUpdated(ctx, userID, pin.ConversationID, pin.MessageID, true, count)
+	return buildPinnedMessageResponse(pin, message), nil
+}
+
+// UnpinMessage implements PinUseCase.
+func (p *pinUseCase) UnpinMessage(ctx context.Context, userID string, conversationID string, messageID string) error {
+	ctx, span := p.obs.StartSpan(ctx, "PinUseCase.UnpinMessage")
+	defer span()
+
+	err := checkCanManageConversation(ctx, p.conversationRepository, userID, conversationID)
+	if err != nil {
+		return err
+	}
+	deleted, err := p.pinnedMessageRepository.DeletePinnedMessage(ctx, conversationID, messageID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrNotPinned
+	}
+	count, err := p.pinnedMessageRepository.CountPinnedMessage(ctx, conversationID)
+	if err != nil {
+		return err
+	}
+	p.publishPinUpdated(ctx, userID, conversationID, messageID, false, count)
+	return nil
+}
+
+// GetListPinnedMessage implements PinUseCase.
+// Pins of messages that were deleted since are left out.
+func (p *pinUseCase) GetListPinnedMessage(ctx context.Context, userID string, conversationID string) ([]*presenter.PinnedMessageResponse, error) {
+	ctx, span := p.obs.StartSpan(ctx, "PinUseCase.GetListPinnedMessage")
+	defer span()
+
+	isMember, err := p.conversationRepository.CheckIsMemberOfConversation(ctx, userID, conversationID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	if !isMember {
+		return nil, domain.ErrNotFoundMemberOfConversation
+	}
+	pins, err := p.pinnedMessageRepository.GetListPinnedMessage(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]*presenter.PinnedMessageResponse, 0, len(pins))
+	if len(pins) == 0 {
+		return response, nil
+	}
+	messageIDs := make([]string, 0, len(pins))
+	for _, pin := range pins {
+		messageIDs = append(messageIDs, pin.MessageID)
+	}
+	messages, err := p.messageRepository.GetListMessageByIDs(ctx, messageIDs)
+	if err != nil {
+		return nil, err
+	}
+	messageByID := make(map[string]*domain.Message, len(messages))
+	for _, message := range messages {
+		messageByID[message.ID] = message
+	}
+	for _, pin := range pins {
+		message, ok := messageByID[pin.MessageID]
+		if !ok || message.DeletedAt != nil {
+			continue
+		}
+		response = append(response, buildPinnedMessageResponse(pin, message))
+	}
+	return response, nil
+}
+
+// publishPinUpdated posts the system message of a pin change and notifies the
+// conversation, errors are only logged since the pin is already stored.
+func (p *pinUseCase) publishPinUpdated(ctx context.Context, userID string, conversationID string, messageID string, pinned bool, pinnedCount int) {
+	logger := p.obs.Logger.WithContext(ctx)
+	action := domain.SystemActionMessagePinned
+	if !pinned {
+		action = domain.SystemActionMessageUnpinned
+	}
+	_, err := p.conversationUseCase.SendMessage(ctx, &presenter.SendMessageRequest{
+		ConversationID: conversationID,
+		UserID:         userID,
+		Type:           domain.MessageTypeSystem,
+		Body:           domain.SystemMessageContent{Action: action, MessageID: messageID}.String(),
+	})
+	if err != nil {
+		logger.Error("failed to send pin system message", err, messageID)
+	}
+	err = p.publisher.Publish(ctx, domain.SUBJECT_NEW_MESSAGE, domain.NewWebSocketMessage(domain.WsPinUpdated, map[string]any{
+		"conversation_id": conversationID,
+		"message_id":      messageID,
+		"pinned":          pinned,
+		"user_id":         userID,
+		"pinned_count":    pinnedCount,
+	}))
+	if err != nil {
+		logger.Error("failed to publish pin updated", err, messageID)
+	}
+}
+
+func buildPinnedMessageResponse(pin *domain.PinnedMessage, message *domain.Message) *presenter.PinnedMessageResponse {
+	return &presenter.PinnedMessageResponse{
+		Message:  buildMessageResponse(message),
+		PinnedBy: pin.PinnedBy,
+		PinnedAt: pin.PinnedAt,
+	}
+}
+
+var _ PinUseCase = (*pinUseCase)(nil)
+
+func NewPinUseCase(conversationRepository domain.ConversationRepository, messageRepository domain.MessageRepository, pinnedMessageRepository domain.PinnedMessageRepository, conversationUseCase ConversationUseCase, publisher pubsub.Publisher, obs *observability.Observability) PinUseCase {
+	return &pinUseCase{
+		conversationRepository:  conversationRepository,
+		messageRepository:       messageRepository,
+		pinnedMessageRepository: pinnedMessageRepository,
+		conversationUseCase:     conversationUseCase,
+		publisher:               publisher,
+		obs:                     obs,
+	}
+}
@@ -0,0 +1,266 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/internal/presenter"
+	"github.com/chat-socio/backend/pkg/observability"
+	"github.com/chat-socio/backend/pkg/pointer"
+	"github.com/chat-socio/backend/pkg/uuid"
+	"github.com/chat-socio/backend/pubsub"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrNotFoundPoll    = errors.New("poll not found")
+	ErrPollClosed      = errors.New("poll is closed")
+	ErrInvalidPollVote = errors.New("invalid poll vote")
+	ErrInvalidPoll     = errors.New("invalid poll")
+)
+
+type PollUseCase interface {
+	CreatePoll(ctx context.Context, request *presenter.CreatePollRequest) (*presenter.MessageResponse, error)
+	VotePoll(ctx context.Context, userID string, request *presenter.VotePollRequest) (*presenter.PollResponse, error)
+	UnvotePoll(ctx context.Context, userID string, messageID string, optionID string) (*presenter.PollResponse, error)
+}
+
+type pollUseCase struct {
+	conversationRepository domain.ConversationRepository
+	messageRepository      domain.MessageRepository
+	pollVoteRepository     domain.PollVoteRepository
+	conversationUseCase    ConversationUseCase
+	publisher              pubsub.Publisher
+	obs                    *observability.Observability
+}
+
+// CreatePoll implements PollUseCase.
+func (p *pollUseCase) CreatePoll(ctx context.Context, request *presenter.CreatePollRequest) (*presenter.MessageResponse, error) {
+	ctx, span := p.obs.StartSpan(ctx, "PollUseCase.CreatePoll")
+	defer span()
+
+	poll := domain.PollContent{
+		Question:       strings.TrimSpace(request.Question),
+		MultipleChoice: request.MultipleChoice,
+		Anonymous:      request.Anonymous,
+		ClosesAt:       request.ClosesAt,
+	}
+	for i, option := range request.Options {
+		poll.Options = append(poll.Options, &domain.PollOption{
+			ID:   strconv.Itoa(i + 1),
+			Text: strings.TrimSpace(option),
+		})
+	}
+	body, err := json.Marshal(poll)
+	if err != nil {
+		return nil, err
+	}
+	return p.conversationUseCase.SendMessage(ctx, &presenter.SendMessageRequest{
+		ConversationID: request.ConversationID,
+		UserID:         request.UserID,
+		Type:           domain.MessageTypePoll,
+		Body:           string(body),
+		UserOnlineID:   request.UserOnlineID,
+		IsBot:          request.IsBot,
+	})
+}
+
+// VotePoll implements PollUseCase.
+// A vote on a single choice poll replaces the previous one, votes on a
+// multiple choice poll are added to the previous ones.
+func (p *pollUseCase) VotePoll(ctx context.Context, userID string, request *presenter.VotePollRequest) (*presenter.PollResponse, error) {
+	ctx, span := p.obs.StartSpan(ctx, "PollUseCase.VotePoll")
+	defer span()
+
+	message, poll, err := p.getPoll(ctx, userID, request.MessageID)
+	if err != nil {
+		return nil, err
+	}
+	if poll.IsClosed(time.Now()) {
+		return nil, ErrPollClosed
+	}
+	if !poll.MultipleChoice && len(request.OptionIDs) != 1 {
+		return nil, fmt.Errorf("%w: a single choice poll takes exactly one option", ErrInvalidPollVote)
+	}
+
+	votes := make([]*domain.PollVote, 0, len(request.OptionIDs))
+	for _, optionID := range request.OptionIDs {
+		if !poll.HasOption(optionID) {
+			return nil, fmt.Errorf("%w: unknown option %q", ErrInvalidPollVote, optionID)
+		}
+		id, err := uuid.NewID()
+		if err != nil {
+			return nil, err
+		}
+		votes = append(votes, &domain.PollVote{
+			ID:        id,
+			OptionID:  optionID,
+			CreatedAt: pointer.ToPtr(time.Now()),
+		})
+	}
+	err = p.pollVoteRepository.CreatePollVotes(ctx, message.ID, userID, votes, !poll.MultipleChoice)
+	if err != nil {
+		return nil, err
+	}
+	return p.publishPollUpdated(ctx, message, userID)
+}
+
+// UnvotePoll implements PollUseCase.
+// An empty optionID removes every vote of the user.
+func (p *pollUseCase) UnvotePoll(ctx context.Context, userID string, messageID string, optionID string) (*presenter.PollResponse, error) {
+	ctx, span := p.obs.StartSpan(ctx, "PollUseCase.UnvotePoll")
+	defer span()
+
+	message, poll, err := p.getPoll(ctx, userID, messageID)
+	if err != nil {
+		return nil, err
+	}
+	if poll.IsClosed(time.Now()) {
+		return nil, ErrPollClosed
+	}
+	err = p.pollVoteRepository.DeletePollVotes(ctx, message.ID, userID, optionID)
+	if err != nil {
+		return nil, err
+	}
+	return p.publishPollUpdated(ctx, message, userID)
+}
+
+// getPoll returns the poll message when the user is a member of its
+// conversation.
+func (p *pollUseCase) getPoll(ctx context.Context, userID string, messageID string) (*domain.Message, *domain.PollContent, error) {
+	message, err := p.messageRepository.GetMessageByID(ctx, messageID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, nil, err
+	}
+	if err == pgx.ErrNoRows || message.Type != domain.MessageTypePoll || message.DeletedAt != nil {
+		return nil, nil, ErrNotFoundPoll
+	}
+	isMember, err := p.conversationRepository.CheckIsMemberOfConversation(ctx, userID, message.ConversationID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, nil, err
+	}
+	if !isMember {
+		return nil, nil, domain.ErrNotFoundMemberOfConversation
+	}
+	poll, err := domain.ParsePollContent(message.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	return message, poll, nil
+}
+
+// publishPollUpdated sends the new results to the conversation and returns
+// them as seen by the user who voted.
+func (p *pollUseCase) publishPollUpdated(ctx context.Context, message *domain.Message, userID string) (*presenter.PollResponse, error) {
+	votes, err := p.pollVoteRepository.GetListPollVoteByMessageIDs(ctx, []string{message.ID})
+	if err != nil {
+		return nil, err
+	}
+	err = p.publisher.Publish(ctx, domain.SUBJECT_NEW_MESSAGE, domain.NewWebSocketMessage(domain.WsPollUpdated, map[string]any{
+		"conversation_id": message.ConversationID,
+		"message_id":      message.ID,
+		"poll":            buildPollResponse(message, votes, ""),
+	}))
+	if err != nil {
+		p.obs.Logger.WithContext(ctx).Error("failed to publish poll updated", err, message.ID)
+	}
+	return buildPollResponse(message, votes, userID), nil
+}
+
+// normalizePollBody validates the poll of a message body and renumbers its
+// options so votes can refer to them.
+func normalizePollBody(body string) (string, error) {
+	poll, err := domain.ParsePollContent(body)
+	if err != nil {
+		return "", fmt.Errorf("%w: body must be a json poll", ErrInvalidPoll)
+	}
+	poll.Question = strings.TrimSpace(poll.Question)
+	if poll.Question == "" {
+		return "", fmt.Errorf("%w: question is required", ErrInvalidPoll)
+	}
+	if len(poll.Options) < 2 || len(poll.Options) > domain.MaxPollOptions {
+		return "", fmt.Errorf("%w: a poll needs 2 to %d options", ErrInvalidPoll, domain.MaxPollOptions)
+	}
+	for i, option := range poll.Options {
+		if option == nil || strings.TrimSpace(option.Text) == "" {
+			return "", fmt.Errorf("%w: options must not be empty", ErrInvalidPoll)
+		}
+		option.ID = strconv.Itoa(i + 1)
+		option.Text = strings.TrimSpace(option.Text)
+	}
+	if poll.IsClosed(time.Now()) {
+		return "", fmt.Errorf("%w: closes_at must be in the future", ErrInvalidPoll)
+	}
+	normalized, err := json.Marshal(poll)
+	if err != nil {
+		return "", err
+	}
+	return string(normalized), nil
+}
+
+// buildPollResponse aggregates the votes of a poll message. It returns nil
+// when the body is not a poll.
+func buildPollResponse(message *domain.Message, votes []*domain.PollVote, userID string) *presenter.PollResponse {
+	poll, err := domain.ParsePollContent(message.Body)
+	if err != nil {
+		return nil
+	}
+	response := &presenter.PollResponse{
+		MessageID:      message.ID,
+		Question:       poll.Question,
+		MultipleChoice: poll.MultipleChoice,
+		Anonymous:      poll.Anonymous,
+		ClosesAt:       poll.ClosesAt,
+		IsClosed:       poll.IsClosed(time.Now()),
+	}
+	options := make(map[string]*presenter.PollOptionResponse, len(poll.Options))
+	for _, option := range poll.Options {
+		optionResponse := &presenter.PollOptionResponse{ID: option.ID, Text: option.Text}
+		options[option.ID] = optionResponse
+		response.Options = append(response.Options, optionResponse)
+	}
+	var voters []string
+	for _, vote := range votes {
+		option, ok := options[vote.OptionID]
+		if !ok || vote.MessageID != message.ID {
+			continue
+		}
+		option.VoteCount++
+		if vote.UserID == userID {
+			option.Voted = true
+		}
+		if !poll.Anonymous && vote.User != nil {
+			option.Voters = append(option.Voters, &presenter.UserResponse{
+				UserID:   vote.User.ID,
+				FullName: vote.User.FullName,
+				Avatar:   vote.User.Avatar,
+				UserType: vote.User.Type,
+			})
+		}
+		if !slices.Contains(voters, vote.UserID) {
+			voters = append(voters, vote.UserID)
+		}
+	}
+	response.TotalVoters = len(voters)
+	return response
+}
+
+var _ PollUseCase = (*pollUseCase)(nil)
+
+func NewPollUseCase(conversationRepository domain.ConversationRepository, messageRepository domain.MessageRepository, pollVoteRepository domain.PollVoteRepository, conversationUseCase ConversationUseCase, publisher pubsub.Publisher, obs *observability.Observability) PollUseCase {
+	return &pollUseCase{
+		conversationRepository: conversationRepository,
+		messageRepository:      messageRepository,
+		pollVoteRepository:     pollVoteRepository,
+		conversationUseCase:    conversationUseCase,
+		publisher:              publisher,
+		obs:                    obs,
+	}
+}
@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/internal/presenter"
+	"github.com/jackc/pgx/v5"
+)
+
+// MarkUnread implements ConversationUseCase.
+// The seen message moves to the message right before the first unread one,
+// the change goes through the seen message consumer like any other read.
+func (c *conversationUseCase) MarkUnread(ctx context.Context, userID string, request *presenter.MarkUnreadRequest) error {
+	ctx, span := c.obs.StartSpan(ctx, "ConversationUsecase.MarkUnread")
+	defer span()
+
+	isMember, err := c.conversationRepository.CheckIsMemberOfConversation(ctx, userID, request.ConversationID)
+	if err != nil && err != pgx.ErrNoRows {
+		return err
+	}
+	if !isMember {
+		return domain.ErrNotFoundMemberOfConversation
+	}
+	firstUnreadID := request.MessageID
+	if firstUnreadID == "" {
+		messages, err := c.messageRepository.GetListMessageByConversationID(ctx, request.ConversationID, "", 1)
+		if err != nil {
+			return err
+		}
+		if len(messages) == 0 {
+			return nil
+		}
+		firstUnreadID = messages[0].ID
+	} else {
+		message, err := c.messageRepository.GetMessageByID(ctx, firstUnreadID)
+		if err != nil && err != pgx.ErrNoRows {
+			return err
+		}
+		if err == pgx.ErrNoRows || message.ConversationID != request.ConversationID {
+			return ErrNotFoundMessage
+		}
+	}
+	previous, err := c.messageRepository.GetListMessageByConversationID(ctx, request.ConversationID, firstUnreadID, 1)
+	if err != nil {
+		return err
+	}
+	seenMessage := domain.SeenMessage{
+		UserID:         userID,
+		ConversationID: request.ConversationID,
+		MarkedUnread:   true,
+	}
+	if len(previous) > 0 {
+		seenMessage.MessageID = previous[0].ID
+	}
+	return c.messagePublisher.Publish(ctx, domain.SUBJECT_SEEN_MESSAGE, seenMessage)
+}
+
+// MarkAllRead implements ConversationUseCase.
+// Every conversation with unread messages is read up to its newest message.
+func (c *conversationUseCase) MarkAllRead(ctx context.Context, userID string) (*presenter.MarkAllReadResponse, error) {
+	ctx, span := c.obs.StartSpan(ctx, "ConversationUsecase.MarkAllRead")
+	defer span()
+
+	seenMessages, err := c.unreadCountRepository.GetListLatestUnreadMessage(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, seenMessage := range seenMessages {
+		err = c.messagePublisher.Publish(ctx, domain.SUBJECT_SEEN_MESSAGE, seenMessage)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &presenter.MarkAllReadResponse{ConversationCount: len(seenMessages)}, nil
+}
+
+// publishReadMarkerUpdated tells the devices of the user where their read
+// marker now is, errors are only logged since the marker is already stored.
+func (c *conversationUseCase) publishReadMarkerUpdated(ctx context.Context, seenMessage *domain.SeenMessage) {
+	err := c.messagePublisher.Publish(ctx, domain.SUBJECT_NEW_MESSAGE, domain.NewWebSocketMessage(domain.WsReadMarkerUpdated, map[string]any{
+		"user_id":         seenMessage.UserID,
+		"conversation_id": seenMessage.ConversationID,
+		"message_id":      seenMessage.MessageID,
+		"marked_unread":   seenMessage.MarkedUnread,
+	}))
+	if err != nil {
+		c.obs.Logger.WithContext(ctx).Error("failed to publish read marker updated", err, seenMessage)
+	}
+}
@@ -0,0 +1,214 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/internal/presenter"
+	"github.com/chat-socio/backend/pkg/observability"
+	"github.com/chat-socio/backend/pkg/pointer"
+	"github.com/chat-socio/backend/pkg/uuid"
+	"github.com/chat-socio/backend/pubsub"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrNotFoundScheduledMessage = errors.New("scheduled message not found")
+	ErrInvalidScheduledMessage  = errors.New("invalid scheduled message")
+)
+
+const (
+	scheduledMessageInterval  = 10 * time.Second
+	scheduledMessageBatchSize = 50
+	scheduledMessageLease     = 5 * time.Minute
+)
+
+type ScheduledMessageUseCase interface {
+	CreateScheduledMessage(ctx context.Context, request *presenter.CreateScheduledMessageRequest) (*presenter.ScheduledMessageResponse, error)
+	GetListScheduledMessage(ctx context.Context, userID string, conversationID string) ([]*presenter.ScheduledMessageResponse, error)
+	CancelScheduledMessage(ctx context.Context, userID string, id string) error
+	RunScheduledMessageSender(ctx context.Context)
+}
+
+type scheduledMessageUseCase struct {
+	conversationRepository     domain.ConversationRepository
+	scheduledMessageRepository domain.ScheduledMessageRepository
+	conversationUseCase        ConversationUseCase
+	leaderLock                 domain.LeaderLock
+	publisher                  pubsub.Publisher
+	obs                        *observability.Observability
+}
+
+// CreateScheduledMessage implements ScheduledMessageUseCase.
+func (s *scheduledMessageUseCase) CreateScheduledMessage(ctx context.Context, request *presenter.CreateScheduledMessageRequest) (*presenter.ScheduledMessageResponse, error) {
+	ctx, span := s.obs.StartSpan(ctx, "ScheduledMessageUseCase.CreateScheduledMessage")
+	defer span()
+
+	isMember, err := s.conversationRepository.CheckIsMemberOfConversation(ctx, request.UserID, request.ConversationID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	if !isMember {
+		return nil, domain.ErrNotFoundMemberOfConversation
+	}
+	// commands answer right away, there is nothing to send later
+	if request.Type == domain.MessageTypeText && strings.HasPrefix(request.Body, "/") && !strings.HasPrefix(request.Body, "//") {
+		return nil, fmt.Errorf("%w: commands can not be scheduled", ErrInvalidScheduledMessage)
+	}
+
+	id, err := uuid.NewID()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	scheduledMessage := &domain.ScheduledMessage{
+		ID:             id,
+		ConversationID: request.ConversationID,
+		UserID:         request.UserID,
+		Type:           request.Type,
+		Body:           request.Body,
+		ReplyTo:        request.ReplyTo,
+		SendAt:         request.SendAt,
+		Status:         domain.ScheduledMessageStatusPending,
+		CreatedAt:      &now,
+		UpdatedAt:      &now,
+	}
+	err = s.scheduledMessageRepository.CreateScheduledMessage(ctx, scheduledMessage)
+	if err != nil {
+		return nil, err
+	}
+	return buildScheduledMessageResponse(scheduledMessage), nil
+}
+
+// GetListScheduledMessage implements ScheduledMessageUseCase.
+func (s *scheduledMessageUseCase) GetListScheduledMessage(ctx context.Context, userID string, conversationID string) ([]*presenter.ScheduledMessageResponse, error) {
+	ctx, span := s.obs.StartSpan(ctx, "ScheduledMessageUseCase.GetListScheduledMessage")
+	defer span()
+
+	scheduledMessages, err := s.scheduledMessageRepository.GetListScheduledMessageByUserID(ctx, userID, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]*presenter.ScheduledMessageResponse, 0, len(scheduledMessages))
+	for _, scheduledMessage := range scheduledMessages {
+		response = append(response, buildScheduledMessageResponse(scheduledMessage))
+	}
+	return response, nil
+}
+
+// CancelScheduledMessage implements ScheduledMessageUseCase.
+func (s *scheduledMessageUseCase) CancelScheduledMessage(ctx context.Context, userID string, id string) error {
+	ctx, span := s.obs.StartSpan(ctx, "ScheduledMessageUseCase.CancelScheduledMessage")
+	defer span()
+
+	cancelled, err := s.scheduledMessageRepository.CancelScheduledMessage(ctx, id, userID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !cancelled {
+		return ErrNotFoundScheduledMessage
+	}
+	return nil
+}
+
+// RunScheduledMessageSender implements ScheduledMessageUseCase.
+// It blocks until ctx is done. Only the replica holding the leader lock sends
+// the due messages, the others keep trying to take the lock over.
+func (s *scheduledMessageUseCase) RunScheduledMessageSender(ctx context.Context) {
+	ticker := time.NewTicker(scheduledMessageInterval)
+	defer ticker.Stop()
+	defer s.leaderLock.Unlock(context.WithoutCancel(ctx))
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		logger := s.obs.Logger.WithContext(ctx)
+		leader, err := s.leaderLock.TryLock(ctx)
+		if err != nil {
+			logger.Error("failed to take the scheduled message lock", err)
+			continue
+		}
+		if !leader {
+			continue
+		}
+		scheduledMessages, err := s.scheduledMessageRepository.ClaimDueScheduledMessages(ctx, time.Now(), scheduledMessageLease, scheduledMessageBatchSize)
+		if err != nil {
+			logger.Error("failed to claim scheduled messages", err)
+			continue
+		}
+		for _, scheduledMessage := range scheduledMessages {
+			s.send(ctx, scheduledMessage)
+		}
+	}
+}
+
+// send posts the message on behalf of its author. SendMessage checks that the
+// author is still a member of the conversation, a message that can not be
+// sent is marked failed and the author is told why.
+func (s *scheduledMessageUseCase) send(ctx context.Context, scheduledMessage *domain.ScheduledMessage) {
+	logger := s.obs.Logger.WithContext(ctx)
+	response, err := s.conversationUseCase.SendMessage(ctx, &presenter.SendMessageRequest{
+		ConversationID: scheduledMessage.ConversationID,
+		UserID:         scheduledMessage.UserID,
+		Type:           scheduledMessage.Type,
+		Body:           scheduledMessage.Body,
+		ReplyTo:        scheduledMessage.ReplyTo,
+	})
+	scheduledMessage.UpdatedAt = pointer.ToPtr(time.Now())
+	if err != nil {
+		logger.Error("failed to send scheduled message", err, scheduledMessage.ID)
+		scheduledMessage.Status = domain.ScheduledMessageStatusFailed
+		scheduledMessage.Error = err.Error()
+	} else {
+		scheduledMessage.Status = domain.ScheduledMessageStatusSent
+		scheduledMessage.MessageID = response.MessageID
+	}
+	err = s.scheduledMessageRepository.UpdateScheduledMessageStatus(ctx, scheduledMessage)
+	if err != nil {
+		logger.Error("failed to update scheduled message", err, scheduledMessage.ID)
+	}
+	if scheduledMessage.Status != domain.ScheduledMessageStatusFailed {
+		return
+	}
+	err = s.publisher.Publish(ctx, domain.SUBJECT_NEW_MESSAGE, domain.NewWebSocketMessage(domain.WsScheduledFailed, map[string]any{
+		"user_id":              scheduledMessage.UserID,
+		"conversation_id":      scheduledMessage.ConversationID,
+		"scheduled_message_id": scheduledMessage.ID,
+		"error":                scheduledMessage.Error,
+	}))
+	if err != nil {
+		logger.Error("failed to publish scheduled message failure", err, scheduledMessage.ID)
+	}
+}
+
+func buildScheduledMessageResponse(scheduledMessage *domain.ScheduledMessage) *presenter.ScheduledMessageResponse {
+	return &presenter.ScheduledMessageResponse{
+		ID:             scheduledMessage.ID,
+		ConversationID: scheduledMessage.ConversationID,
+		Type:           scheduledMessage.Type,
+		Body:           scheduledMessage.Body,
+		ReplyTo:        scheduledMessage.ReplyTo,
+		SendAt:         scheduledMessage.SendAt,
+		Status:         scheduledMessage.Status,
+		CreatedAt:      scheduledMessage.CreatedAt,
+	}
+}
+
+var _ ScheduledMessageUseCase = (*scheduledMessageUseCase)(nil)
+
+func NewScheduledMessageUseCase(conversationRepository domain.ConversationRepository, scheduledMessageRepository domain.ScheduledMessageRepository, conversationUseCase ConversationUseCase, leaderLock domain.LeaderLock, publisher pubsub.Publisher, obs *observability.Observability) ScheduledMessageUseCase {
+	return &scheduledMessageUseCase{
+		conversationRepository:     conversationRepository,
+		scheduledMessageRepository: scheduledMessageRepository,
+		conversationUseCase:        conversationUseCase,
+		leaderLock:                 leaderLock,
+		publisher:                  publisher,
+		obs:                        obs,
+	}
+}
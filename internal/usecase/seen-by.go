@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/internal/presenter"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetListSeenByMessageID implements ConversationUseCase.
+// A member has seen the message when their last seen message is the message
+// or a newer one, the sender is left out.
+func (c *conversationUseCase) GetListSeenByMessageID(ctx context.Context, userID string, messageID string) ([]*presenter.SeenMessageResponse, error) {
+	ctx, span := c.obs.StartSpan(ctx, "ConversationUsecase.GetListSeenByMessageID")
+	defer span()
+
+	message, err := c.messageRepository.GetMessageByID(ctx, messageID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	if err == pgx.ErrNoRows || (message.ExpiresAt != nil && !message.ExpiresAt.After(time.Now())) {
+		return nil, ErrNotFoundMessage
+	}
+	isMember, err := c.conversationRepository.CheckIsMemberOfConversation(ctx, userID, message.ConversationID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	if !isMember {
+		return nil, domain.ErrNotFoundMemberOfConversation
+	}
+	seenMessages, err := c.seenMessageRepository.GetListSeenMessageByConversationID(ctx, message.ConversationID)
+	if err != nil {
+		return nil, err
+	}
+	_, members, err := c.conversationRepository.GetConversationByID(ctx, message.ConversationID)
+	if err != nil {
+		return nil, err
+	}
+	seenBy := make([]*domain.SeenMessage, 0, len(seenMessages))
+	for _, seenMessage := range seenMessages {
+		if seenMessage.UserID != message.UserID && seenMessage.MessageID >= message.ID {
+			seenBy = append(seenBy, seenMessage)
+		}
+	}
+	return buildSeenMessageResponses(seenBy, members), nil
+}
+
+// buildSeenMessageResponses attaches the profile of the members to their seen
+// messages, seen messages of users who left are dropped.
+func buildSeenMessageResponses(seenMessages []*domain.SeenMessage, members []*domain.ConversationMemberWithUser) []*presenter.SeenMessageResponse {
+	memberByID := make(map[string]*domain.ConversationMemberWithUser, len(members))
+	for _, member := range members {
+		memberByID[member.UserID] = member
+	}
+	seenMessageResponses := make([]*presenter.SeenMessageResponse, 0, len(seenMessages))
+	for _, seenMessage := range seenMessages {
+		member, ok := memberByID[seenMessage.UserID]
+		if !ok {
+			continue
+		}
+		seenMessageResponses = append(seenMessageResponses, &presenter.SeenMessageResponse{
+			MessageID:      seenMessage.MessageID,
+			UserID:         seenMessage.UserID,
+			ConversationID: seenMessage.ConversationID,
+			CreatedAt:      seenMessage.CreatedAt,
+			UpdatedAt:      seenMessage.UpdatedAt,
+			User: &presenter.UserResponse{
+				UserID:   member.UserID,
+				FullName: member.FullName,
+				Avatar:   member.Avatar,
+				UserType: member.UserType,
+			},
+		})
+	}
+	return seenMessageResponses
+}
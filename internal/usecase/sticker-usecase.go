@@ -0,0 +1,279 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/chat-socio/backend/configuration"
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/internal/presenter"
+	"github.com/chat-socio/backend/pkg/observability"
+	"github.com/chat-socio/backend/pkg/storage"
+	"github.com/chat-socio/backend/pkg/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrNotFoundStickerPack = errors.New("sticker pack not found")
+	ErrInvalidSticker      = errors.New("invalid sticker")
+)
+
+var stickerFileExtensions = []string{".png", ".webp", ".gif"}
+
+type StickerUseCase interface {
+	ImportStickerPack(ctx context.Context, request *presenter.ImportStickerPackRequest) (*presenter.StickerPackResponse, error)
+	GetListInstalledStickerPack(ctx context.Context, userID string) ([]*presenter.StickerPackResponse, error)
+	GetListStickerPack(ctx context.Context, userID string) ([]*presenter.StickerPackResponse, error)
+	InstallStickerPack(ctx context.Context, userID string, packID string) error
+	UninstallStickerPack(ctx context.Context, userID string, packID string) error
+	ReorderStickerPack(ctx context.Context, userID string, request *presenter.ReorderStickerPackRequest) ([]*presenter.StickerPackResponse, error)
+}
+
+type stickerUseCase struct {
+	stickerRepository domain.StickerRepository
+	storage           storage.ObjectStorage
+	obs               *observability.Observability
+}
+
+// ImportStickerPack implements StickerUseCase.
+// The sticker files are uploaded to the sticker bucket before the pack is
+// stored, the first sticker is used as the thumbnail of the pack.
+func (s *stickerUseCase) ImportStickerPack(ctx context.Context, request *presenter.ImportStickerPackRequest) (*presenter.StickerPackResponse, error) {
+	ctx, span := s.obs.StartSpan(ctx, "StickerUseCase.ImportStickerPack")
+	defer span()
+	logger := s.obs.Logger.WithContext(ctx)
+
+	for _, sticker := range request.Stickers {
+		ext := strings.ToLower(path.Ext(sticker.FileName))
+		if !slices.Contains(stickerFileExtensions, ext) {
+			return nil, fmt.Errorf("%w: %s is not a png, webp or gif file", ErrInvalidSticker, sticker.FileName)
+		}
+	}
+
+	bucket := configuration.ConfigInstance.Minio.StickerBucket
+	exists, err := s.storage.BucketExists(ctx, bucket)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		err = s.storage.MakeBucket(ctx, bucket)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	packID, err := uuid.NewID()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	pack := &domain.StickerPack{
+		ID:          packID,
+		Name:        strings.TrimSpace(request.Name),
+		Description: strings.TrimSpace(request.Description),
+		IsDefault:   request.IsDefault,
+		CreatedAt:   &now,
+	}
+	var stickers []*domain.Sticker
+	var objectNames []string
+	for i, item := range request.Stickers {
+		stickerID, err := uuid.NewID()
+		if err != nil {
+			return nil, err
+		}
+		objectName := fmt.Sprintf("packs/%s/%s%s", packID, stickerID, strings.ToLower(path.Ext(item.FileName)))
+		err = s.storage.PutObject(ctx, bucket, objectName, item.Reader, item.Size)
+		if err != nil {
+			s.deleteObjects(ctx, bucket, objectNames)
+			return nil, err
+		}
+		objectNames = append(objectNames, objectName)
+		uri, err := s.storage.GetObjectURI(ctx, bucket, objectName)
+		if err != nil {
+			s.deleteObjects(ctx, bucket, objectNames)
+			return nil, err
+		}
+		stickers = append(stickers, &domain.Sticker{
+			ID:        stickerID,
+			PackID:    packID,
+			Emoji:     strings.TrimSpace(item.Emoji),
+			URL:       configuration.ConfigInstance.Minio.PublicEndpoint + uri,
+			Position:  i + 1,
+			CreatedAt: &now,
+		})
+	}
+	pack.ThumbnailURL = stickers[0].URL
+
+	err = s.stickerRepository.CreateStickerPack(ctx, pack, stickers)
+	if err != nil {
+		logger.Error("failed to create sticker pack", err, pack.Name)
+		s.deleteObjects(ctx, bucket, objectNames)
+		return nil, err
+	}
+	return buildStickerPackResponse(pack, stickers), nil
+}
+
+// GetListInstalledStickerPack implements StickerUseCase.
+func (s *stickerUseCase) GetListInstalledStickerPack(ctx context.Context, userID string) ([]*presenter.StickerPackResponse, error) {
+	ctx, span := s.obs.StartSpan(ctx, "StickerUseCase.GetListInstalledStickerPack")
+	defer span()
+
+	packs, err := s.stickerRepository.GetListInstalledStickerPack(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.withStickers(ctx, packs)
+}
+
+// GetListStickerPack implements StickerUseCase.
+// The catalog only carries the thumbnails, stickers are listed once the pack
+// is installed.
+func (s *stickerUseCase) GetListStickerPack(ctx context.Context, userID string) ([]*presenter.StickerPackResponse, error) {
+	ctx, span := s.obs.StartSpan(ctx, "StickerUseCase.GetListStickerPack")
+	defer span()
+
+	packs, err := s.stickerRepository.GetListStickerPack(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]*presenter.StickerPackResponse, 0, len(packs))
+	for _, pack := range packs {
+		response = append(response, buildStickerPackResponse(pack, nil))
+	}
+	return response, nil
+}
+
+// InstallStickerPack implements StickerUseCase.
+func (s *stickerUseCase) InstallStickerPack(ctx context.Context, userID string, packID string) error {
+	ctx, span := s.obs.StartSpan(ctx, "StickerUseCase.InstallStickerPack")
+	defer span()
+
+	_, err := s.stickerRepository.GetStickerPackByID(ctx, packID)
+	if err != nil && err != pgx.ErrNoRows {
+		return err
+	}
+	if err == pgx.ErrNoRows {
+		return ErrNotFoundStickerPack
+	}
+	return s.stickerRepository.InstallStickerPack(ctx, userID, packID, time.Now())
+}
+
+// UninstallStickerPack implements StickerUseCase.
+// Default packs stay available after they are uninstalled, they only lose
+// their place in the user's order.
+func (s *stickerUseCase) UninstallStickerPack(ctx context.Context, userID string, packID string) error {
+	ctx, span := s.obs.StartSpan(ctx, "StickerUseCase.UninstallStickerPack")
+	defer span()
+
+	return s.stickerRepository.UninstallStickerPack(ctx, userID, packID)
+}
+
+// ReorderStickerPack implements StickerUseCase.
+func (s *stickerUseCase) ReorderStickerPack(ctx context.Context, userID string, request *presenter.ReorderStickerPackRequest) ([]*presenter.StickerPackResponse, error) {
+	ctx, span := s.obs.StartSpan(ctx, "StickerUseCase.ReorderStickerPack")
+	defer span()
+
+	err := s.stickerRepository.UpdateStickerPackPositions(ctx, userID, request.PackIDs)
+	if err != nil {
+		return nil, err
+	}
+	packs, err := s.stickerRepository.GetListInstalledStickerPack(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.withStickers(ctx, packs)
+}
+
+func (s *stickerUseCase) withStickers(ctx context.Context, packs []*domain.StickerPack) ([]*presenter.StickerPackResponse, error) {
+	response := make([]*presenter.StickerPackResponse, 0, len(packs))
+	if len(packs) == 0 {
+		return response, nil
+	}
+	packIDs := make([]string, 0, len(packs))
+	for _, pack := range packs {
+		packIDs = append(packIDs, pack.ID)
+	}
+	stickers, err := s.stickerRepository.GetListStickerByPackIDs(ctx, packIDs)
+	if err != nil {
+		return nil, err
+	}
+	stickersByPackID := make(map[string][]*domain.Sticker, len(packs))
+	for _, sticker := range stickers {
+		stickersByPackID[sticker.PackID] = append(stickersByPackID[sticker.PackID], sticker)
+	}
+	for _, pack := range packs {
+		response = append(response, buildStickerPackResponse(pack, stickersByPackID[pack.ID]))
+	}
+	return response, nil
+}
+
+// deleteObjects removes the files of a failed import, it only logs errors.
+func (s *stickerUseCase) deleteObjects(ctx context.Context, bucket string, objectNames []string) {
+	for _, objectName := range objectNames {
+		err := s.storage.DeleteObject(ctx, bucket, objectName)
+		if err != nil {
+			s.obs.Logger.WithContext(ctx).Error("failed to delete sticker object", err, objectName)
+		}
+	}
+}
+
+// normalizeStickerBody checks that the sticker exists and is available to
+// the sender, then fills the body in from the sticker.
+func (c *conversationUseCase) normalizeStickerBody(ctx context.Context, userID string, body string) (string, error) {
+	content, err := domain.ParseStickerContent(body)
+	if err != nil || content.StickerID == "" {
+		return "", fmt.Errorf("%w: body must be a json sticker with a sticker_id", ErrInvalidSticker)
+	}
+	sticker, err := c.stickerRepository.GetAvailableSticker(ctx, userID, content.StickerID)
+	if err != nil && err != pgx.ErrNoRows {
+		return "", err
+	}
+	if err == pgx.ErrNoRows {
+		return "", fmt.Errorf("%w: sticker not found in the installed packs", ErrInvalidSticker)
+	}
+	normalized, err := json.Marshal(domain.StickerContent{
+		StickerID: sticker.ID,
+		PackID:    sticker.PackID,
+		URL:       sticker.URL,
+		Emoji:     sticker.Emoji,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(normalized), nil
+}
+
+func buildStickerPackResponse(pack *domain.StickerPack, stickers []*domain.Sticker) *presenter.StickerPackResponse {
+	response := &presenter.StickerPackResponse{
+		PackID:       pack.ID,
+		Name:         pack.Name,
+		Description:  pack.Description,
+		ThumbnailURL: pack.ThumbnailURL,
+		IsDefault:    pack.IsDefault,
+		Installed:    pack.Installed,
+	}
+	for _, sticker := range stickers {
+		response.Stickers = append(response.Stickers, &presenter.StickerResponse{
+			StickerID: sticker.ID,
+			Emoji:     sticker.Emoji,
+			URL:       sticker.URL,
+		})
+	}
+	return response
+}
+
+var _ StickerUseCase = (*stickerUseCase)(nil)
+
+func NewStickerUseCase(stickerRepository domain.StickerRepository, storage storage.ObjectStorage, obs *observability.Observability) StickerUseCase {
+	return &stickerUseCase{
+		stickerRepository: stickerRepository,
+		storage:           storage,
+		obs:               obs,
+	}
+}
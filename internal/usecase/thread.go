@@ -0,0 +1,258 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/internal/presenter"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrInvalidReplyTo = errors.New("invalid reply_to")
+	ErrNotFoundThread = errors.New("thread not found")
+)
+
+// maxThreadParticipants caps the participants listed in a thread summary,
+// participant_count still counts all of them.
+const maxThreadParticipants = 3
+
+// GetMessageThread implements ConversationUseCase.
+// rootID may be the id of any message of the thread, the replies are listed
+// oldest first after lastID.
+func (c *conversationUseCase) GetMessageThread(ctx context.Context, userID string, rootID string, lastID string, limit int) (*presenter.MessageThreadResponse, error) {
+	ctx, span := c.obs.StartSpan(ctx, "ConversationUsecase.GetMessageThread")
+	defer span()
+
+	root, err := c.messageRepository.GetMessageByID(ctx, rootID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	if err == pgx.ErrNoRows {
+		return nil, ErrNotFoundThread
+	}
+	if root.ThreadID != "" {
+		root, err = c.messageRepository.GetMessageByID(ctx, root.ThreadID)
+		if err != nil && err != pgx.ErrNoRows {
+			return nil, err
+		}
+		if err == pgx.ErrNoRows {
+			return nil, ErrNotFoundThread
+		}
+	}
+	isMember, err := c.conversationRepository.CheckIsMemberOfConversation(ctx, userID, root.ConversationID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	if !isMember {
+		return nil, domain.ErrNotFoundMemberOfConversation
+	}
+
+	replies, err := c.messageRepository.GetListMessageByThreadID(ctx, root.ID, lastID, limit)
+	if err != nil {
+		return nil, err
+	}
+	messageResponses, err := c.buildMessageResponses(ctx, userID, append([]*domain.Message{root}, replies...))
+	if err != nil {
+		return nil, err
+	}
+	return &presenter.MessageThreadResponse{
+		Root:    messageResponses[0],
+		Replies: messageResponses[1:],
+	}, nil
+}
+
+// resolveReplyTo checks that the replied message belongs to the conversation
+// and returns it, the reply joins the thread of its parent.
+func (c *conversationUseCase) resolveReplyTo(ctx context.Context, message *presenter.SendMessageRequest) (*domain.Message, error) {
+	parent, err := c.messageRepository.GetMessageByID(ctx, message.ReplyTo)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	if err == pgx.ErrNoRows || parent.ConversationID != message.ConversationID {
+		return nil, fmt.Errorf("%w: message not found in the conversation", ErrInvalidReplyTo)
+	}
+	if parent.DeletedAt != nil {
+		return nil, fmt.Errorf("%w: message was deleted", ErrInvalidReplyTo)
+	}
+	return parent, nil
+}
+
+// buildMessageResponses converts the messages for the viewer with their
+// polls, contact cards, thread summaries and quoted parents.
+func (c *conversationUseCase) buildMessageResponses(ctx context.Context, viewerID string, messages []*domain.Message) ([]*presenter.MessageResponse, error) {
+	pollVotes, err := c.getPollVotes(ctx, messages)
+	if err != nil {
+		return nil, err
+	}
+	contactCards, err := c.getContactCards(ctx, viewerID, messages)
+	if err != nil {
+		return nil, err
+	}
+	threads, err := c.getThreadSummaries(ctx, messages)
+	if err != nil {
+		return nil, err
+	}
+	parents, err := c.getParentMessages(ctx, messages)
+	if err != nil {
+		return nil, err
+	}
+	messageResponses := make([]*presenter.MessageResponse, 0, len(messages))
+	for _, message := range messages {
+		messageResponse := buildMessageResponse(message)
+		if message.Type == domain.MessageTypePoll && message.DeletedAt == nil {
+			messageResponse.Poll = buildPollResponse(message, pollVotes[message.ID], viewerID)
+		}
+		messageResponse.Contact = contactCard(message, contactCards)
+		messageResponse.Thread = threads[message.ID]
+		if parent, ok := parents[message.ReplyTo]; ok && parent.ConversationID == message.ConversationID {
+			messageResponse.Parent = buildParentResponse(parent)
+		}
+		messageResponses = append(messageResponses, messageResponse)
+	}
+	return messageResponses, nil
+}
+
+// getThreadSummaries loads the summaries of the thread roots among the
+// messages, keyed by root id.
+func (c *conversationUseCase) getThreadSummaries(ctx context.Context, messages []*domain.Message) (map[string]*presenter.ThreadSummaryResponse, error) {
+	var rootIDs []string
+	for _, message := range messages {
+		if message.ThreadID == "" && message.DeletedAt == nil {
+			rootIDs = append(rootIDs, message.ID)
+		}
+	}
+	threads := make(map[string]*presenter.ThreadSummaryResponse)
+	if len(rootIDs) == 0 {
+		return threads, nil
+	}
+	summaries, err := c.messageRepository.GetListThreadSummary(ctx, rootIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(summaries) == 0 {
+		return threads, nil
+	}
+
+	var userIDs []string
+	for _, summary := range summaries {
+		userIDs = append(userIDs, summary.ParticipantIDs[:min(len(summary.ParticipantIDs), maxThreadParticipants)]...)
+	}
+	users, err := c.userRepository.GetListUserByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	userByID := make(map[string]*domain.UserInfo, len(users))
+	for _, user := range users {
+		userByID[user.ID] = user
+	}
+	for _, summary := range summaries {
+		threads[summary.RootID] = buildThreadSummaryResponse(summary, userByID)
+	}
+	return threads, nil
+}
+
+// getParentMessages loads the messages quoted by the replies, keyed by id.
+func (c *conversationUseCase) getParentMessages(ctx context.Context, messages []*domain.Message) (map[string]*domain.Message, error) {
+	var parentIDs []string
+	for _, message := range messages {
+		if message.ReplyTo != "" {
+			parentIDs = append(parentIDs, message.ReplyTo)
+		}
+	}
+	parents := make(map[string]*domain.Message, len(parentIDs))
+	if len(parentIDs) == 0 {
+		return parents, nil
+	}
+	messageParents, err := c.messageRepository.GetListMessageByIDs(ctx, parentIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, parent := range messageParents {
+		parents[parent.ID] = parent
+	}
+	return parents, nil
+}
+
+// publishThreadUpdated sends the new summary of a thread to the conversation,
+// errors are only logged since the reply is already stored.
+func (c *conversationUseCase) publishThreadUpdated(ctx context.Context, conversationID string, rootID string) {
+	logger := c.obs.Logger.WithContext(ctx)
+	threads, err := c.getThreadSummaries(ctx, []*domain.Message{{ID: rootID}})
+	if err != nil {
+		logger.Error("failed to get thread summary", err, rootID)
+		return
+	}
+	thread, ok := threads[rootID]
+	if !ok {
+		return
+	}
+	err = c.messagePublisher.Publish(ctx, domain.SUBJECT_NEW_MESSAGE, domain.NewWebSocketMessage(domain.WsThreadUpdated, map[string]any{
+		"conversation_id": conversationID,
+		"root_id":         rootID,
+		"thread":          thread,
+	}))
+	if err != nil {
+		logger.Error("failed to publish thread updated", err, rootID)
+	}
+}
+
+func buildMessageResponse(message *domain.Message) *presenter.MessageResponse {
+	messageResponse := &presenter.MessageResponse{
+		MessageID:      message.ID,
+		Body:           message.Body,
+		CreatedAt:      message.CreatedAt,
+		UpdatedAt:      message.UpdatedAt,
+		Type:           message.Type,
+		DeletedAt:      message.DeletedAt,
+		ReplyTo:        message.ReplyTo,
+		DisplayName:    message.DisplayName,
+		ConversationID: message.ConversationID,
+		ThreadID:       message.ThreadID,
+		ForwardedFrom:  message.ForwardedFrom,
+		ExpiresAt:      message.ExpiresAt,
+	}
+	if message.User != nil {
+		messageResponse.User = &presenter.UserResponse{
+			UserID:   message.User.ID,
+			FullName: message.User.FullName,
+			Avatar:   message.User.Avatar,
+			UserType: message.User.Type,
+		}
+	}
+	return messageResponse
+}
+
+// buildParentResponse returns the quoted message of a reply, the body of a
+// deleted parent is not shown.
+func buildParentResponse(parent *domain.Message) *presenter.MessageResponse {
+	parentResponse := buildMessageResponse(parent)
+	if parent.DeletedAt != nil {
+		parentResponse.Body = ""
+	}
+	return parentResponse
+}
+
+func buildThreadSummaryResponse(summary *domain.ThreadSummary, userByID map[string]*domain.UserInfo) *presenter.ThreadSummaryResponse {
+	thread := &presenter.ThreadSummaryResponse{
+		ReplyCount:       summary.ReplyCount,
+		LastReplyID:      summary.LastReplyID,
+		LastReplyAt:      summary.LastReplyAt,
+		ParticipantCount: len(summary.ParticipantIDs),
+	}
+	for _, userID := range summary.ParticipantIDs[:min(len(summary.ParticipantIDs), maxThreadParticipants)] {
+		user, ok := userByID[userID]
+		if !ok {
+			continue
+		}
+		thread.Participants = append(thread.Participants, &presenter.UserResponse{
+			UserID:   user.ID,
+			FullName: user.FullName,
+			Avatar:   user.Avatar,
+			UserType: user.Type,
+		})
+	}
+	return thread
+}
@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/internal/presenter"
+	"github.com/chat-socio/backend/pkg/observability"
+	"github.com/chat-socio/backend/pubsub"
+)
+
+// GetTotalUnreadCount implements ConversationUseCase.
+func (c *conversationUseCase) GetTotalUnreadCount(ctx context.Context, userID string) (*presenter.UnreadCountResponse, error) {
+	ctx, span := c.obs.StartSpan(ctx, "ConversationUsecase.GetTotalUnreadCount")
+	defer span()
+
+	totals, err := c.unreadCountRepository.GetTotalUnreadCounts(ctx, []string{userID})
+	if err != nil {
+		return nil, err
+	}
+	return &presenter.UnreadCountResponse{TotalUnreadCount: totals[userID]}, nil
+}
+
+// incrementUnreadCounts counts a new message as unread for the other members
+// and pushes their new counts.
+func (c *conversationUseCase) incrementUnreadCounts(ctx context.Context, message *domain.Message) error {
+	if message.Type == domain.MessageTypeSystem {
+		return nil
+	}
+	unreadCounts, err := c.unreadCountRepository.IncrementUnreadCount(ctx, message)
+	if err != nil {
+		return err
+	}
+	publishUnreadCounts(ctx, c.messagePublisher, c.unreadCountRepository, c.obs, unreadCounts)
+	return nil
+}
+
+// recountUnreadCount rebuilds the count of the user after the seen message
+// moved and pushes it to the devices of the user.
+func (c *conversationUseCase) recountUnreadCount(ctx context.Context, conversationID string, userID string) error {
+	unreadCount, err := c.unreadCountRepository.RecountUnreadCount(ctx, conversationID, userID)
+	if err != nil {
+		return err
+	}
+	publishUnreadCounts(ctx, c.messagePublisher, c.unreadCountRepository, c.obs, []*domain.UnreadCount{unreadCount})
+	return nil
+}
+
+// publishUnreadCounts sends every user the count of the conversation with
+// the total of all their conversations, errors are only logged since the
+// counts are already stored.
+func publishUnreadCounts(ctx context.Context, publisher pubsub.Publisher, unreadCountRepository domain.UnreadCountRepository, obs *observability.Observability, unreadCounts []*domain.UnreadCount) {
+	if len(unreadCounts) == 0 {
+		return
+	}
+	logger := obs.Logger.WithContext(ctx)
+	userIDs := make([]string, 0, len(unreadCounts))
+	for _, unreadCount := range unreadCounts {
+		userIDs = append(userIDs, unreadCount.UserID)
+	}
+	totals, err := unreadCountRepository.GetTotalUnreadCounts(ctx, userIDs)
+	if err != nil {
+		logger.Error("failed to get total unread counts", err)
+		return
+	}
+	for _, unreadCount := range unreadCounts {
+		err = publisher.Publish(ctx, domain.SUBJECT_NEW_MESSAGE, domain.NewWebSocketMessage(domain.WsUnreadCountUpdated, map[string]any{
+			"user_id":            unreadCount.UserID,
+			"conversation_id":    unreadCount.ConversationID,
+			"unread_count":       unreadCount.UnreadCount,
+			"total_unread_count": totals[unreadCount.UserID],
+		}))
+		if err != nil {
+			logger.Error("failed to publish unread count", err, unreadCount)
+		}
+	}
+}
@@ -33,6 +33,7 @@ type UserUseCase interface {
 	GetListUser(ctx context.Context, userID string, keyword string, limit int, lastID string) ([]*presenter.GetUserInfoResponse, error)
 	GetUserIDByAccountID(ctx context.Context, accountID string) (string, error)
 	UpdateUser(ctx context.Context, updateRequest *presenter.UpdateUserRequest) (*presenter.GetUserInfoResponse, error)
+	Logout(ctx context.Context) error
 }
 
 type userUseCase struct {
@@ -340,6 +341,26 @@ func (u *userUseCase) UpdateUser(ctx context.Context, updateRequest *presenter.U
 	}, nil
 }
 
+// Logout implements UserUseCase.
+func (u *userUseCase) Logout(ctx context.Context) error {
+	ctx, span := u.obs.StartSpan(ctx, "UserUsecase.Logout")
+	defer span()
+
+	sessionToken := ctx.Value(utils.SessionTokenKey).(string)
+	return u.deactivateSession(ctx, sessionToken)
+}
+
+// deactivateSession marks the session inactive and evicts it from the cache
+// so the middleware stops accepting it immediately.
+func (u *userUseCase) deactivateSession(ctx context.Context, token string) error {
+	err := u.sessionRepository.DeactivateSession(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	return u.sessionCacheRepository.DeleteSession(ctx, token)
+}
+
 var _ UserUseCase = (*userUseCase)(nil)
 
 func NewUserUseCase(accountRepository domain.AccountRepository, userRepository domain.UserRepository, sessionRepository domain.SessionRepository, sessionCacheRepository domain.SessionCacheRepository, userCacheRepository domain.UserCacheRepository, obs *observability.Observability) UserUseCase {
@@ -0,0 +1,489 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/chat-socio/backend/internal/domain"
+	"github.com/chat-socio/backend/internal/presenter"
+	"github.com/chat-socio/backend/internal/utils"
+	"github.com/chat-socio/backend/pkg/observability"
+	"github.com/chat-socio/backend/pkg/pointer"
+	"github.com/chat-socio/backend/pkg/uuid"
+	"github.com/chat-socio/backend/pubsub"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	webhookSecretPrefix = "whsec_"
+
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookSignatureHeader = "X-Webhook-Signature"
+
+	webhookRequestTimeout = 10 * time.Second
+	// a delivery is given up after this many attempts
+	webhookMaxAttempts = 6
+	webhookBaseBackoff = 30 * time.Second
+	// consecutive failures after which the webhook is disabled
+	webhookDisableThreshold = 15
+	webhookDeliveryLease    = time.Minute
+	webhookRetryInterval    = 5 * time.Second
+	webhookRetryBatchSize   = 100
+	webhookResponseLimit    = 64 << 10
+)
+
+var (
+	ErrNotFoundWebhook = errors.New("webhook not found")
+)
+
+type WebhookUseCase interface {
+	CreateWebhook(ctx context.Context, request *presenter.CreateWebhookRequest) (*presenter.WebhookResponse, error)
+	GetListWebhook(ctx context.Context, conversationID string) ([]*presenter.WebhookResponse, error)
+	UpdateWebhook(ctx context.Context, request *presenter.UpdateWebhookRequest) (*presenter.WebhookResponse, error)
+	DeleteWebhook(ctx context.Context, webhookID string) error
+	GetListWebhookDelivery(ctx context.Context, webhookID string, lastID string, limit int) ([]*presenter.WebhookDeliveryResponse, error)
+	HandleConversationEvent(ctx context.Context, message *domain.WebSocketMessage) error
+	HandleWebhookDelivery(ctx context.Context, data domain.WebhookDelivery) error
+	RunWebhookRetry(ctx context.Context)
+}
+
+type webhookUseCase struct {
+	userRepository         domain.UserRepository
+	conversationRepository domain.ConversationRepository
+	webhookRepository      domain.WebhookRepository
+	publisher              pubsub.Publisher
+	httpClient             *http.Client
+	obs                    *observability.Observability
+}
+
+// CreateWebhook implements WebhookUseCase.
+// The secret is only returned here, receivers need it to verify signatures.
+func (w *webhookUseCase) CreateWebhook(ctx context.Context, request *presenter.CreateWebhookRequest) (*presenter.WebhookResponse, error) {
+	ctx, span := w.obs.StartSpan(ctx, "WebhookUseCase.CreateWebhook")
+	defer span()
+
+	user, err := w.requireConversationAdmin(ctx, request.ConversationID)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.NewID()
+	if err != nil {
+		return nil, err
+	}
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+	now := pointer.ToPtr(time.Now())
+	webhook := &domain.Webhook{
+		ID:             id,
+		ConversationID: request.ConversationID,
+		CreatedBy:      user.ID,
+		URL:            request.URL,
+		Secret:         secret,
+		Events:         request.Events,
+		IsActive:       true,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	err = w.webhookRepository.CreateWebhook(ctx, webhook)
+	if err != nil {
+		return nil, err
+	}
+	response := toWebhookResponse(webhook)
+	response.Secret = secret
+	return response, nil
+}
+
+// GetListWebhook implements WebhookUseCase.
+func (w *webhookUseCase) GetListWebhook(ctx context.Context, conversationID string) ([]*presenter.WebhookResponse, error) {
+	ctx, span := w.obs.StartSpan(ctx, "WebhookUseCase.GetListWebhook")
+	defer span()
+
+	_, err := w.requireConversationAdmin(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	webhooks, err := w.webhookRepository.GetListWebhookByConversationID(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	responses := make([]*presenter.WebhookResponse, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		responses = append(responses, toWebhookResponse(webhook))
+	}
+	return responses, nil
+}
+
+// UpdateWebhook implements WebhookUseCase.
+// Enabling a webhook again clears its failure count.
+func (w *webhookUseCase) UpdateWebhook(ctx context.Context, request *presenter.UpdateWebhookRequest) (*presenter.WebhookResponse, error) {
+	ctx, span := w.obs.StartSpan(ctx, "WebhookUseCase.UpdateWebhook")
+	defer span()
+
+	webhook, err := w.getManagedWebhook(ctx, request.ID)
+	if err != nil {
+		return nil, err
+	}
+	if request.URL != "" {
+		webhook.URL = request.URL
+	}
+	if request.Events != nil {
+		webhook.Events = request.Events
+	}
+	if request.IsActive != nil {
+		if *request.IsActive && !webhook.IsActive {
+			webhook.FailureCount = 0
+			webhook.DisabledAt = nil
+		}
+		if !*request.IsActive && webhook.IsActive {
+			webhook.DisabledAt = pointer.ToPtr(time.Now())
+		}
+		webhook.IsActive = *request.IsActive
+	}
+	webhook.UpdatedAt = pointer.ToPtr(time.Now())
+	err = w.webhookRepository.UpdateWebhook(ctx, webhook)
+	if err != nil {
+		return nil, err
+	}
+	return toWebhookResponse(webhook), nil
+}
+
+// DeleteWebhook implements WebhookUseCase.
+func (w *webhookUseCase) DeleteWebhook(ctx context.Context, webhookID string) error {
+	ctx, span := w.obs.StartSpan(ctx, "WebhookUseCase.DeleteWebhook")
+	defer span()
+
+	webhook, err := w.getManagedWebhook(ctx, webhookID)
+	if err != nil {
+		return err
+	}
+	return w.webhookRepository.DeleteWebhook(ctx, webhook.ID)
+}
+
+// GetListWebhookDelivery implements WebhookUseCase.
+func (w *webhookUseCase) GetListWebhookDelivery(ctx context.Context, webhookID string, lastID string, limit int) ([]*presenter.WebhookDeliveryResponse, error) {
+	ctx, span := w.obs.StartSpan(ctx, "WebhookUseCase.GetListWebhookDelivery")
+	defer span()
+
+	webhook, err := w.getManagedWebhook(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	deliveries, err := w.webhookRepository.GetListWebhookDeliveryByWebhookID(ctx, webhook.ID, lastID, limit)
+	if err != nil {
+		return nil, err
+	}
+	responses := make([]*presenter.WebhookDeliveryResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		responses = append(responses, &presenter.WebhookDeliveryResponse{
+			ID:             delivery.ID,
+			WebhookID:      delivery.WebhookID,
+			Event:          delivery.Event,
+			Payload:        delivery.Payload,
+			Status:         delivery.Status,
+			Attempt:        delivery.Attempt,
+			ResponseStatus: delivery.ResponseStatus,
+			Error:          delivery.Error,
+			NextRetryAt:    delivery.NextRetryAt,
+			CreatedAt:      delivery.CreatedAt,
+			UpdatedAt:      delivery.UpdatedAt,
+		})
+	}
+	return responses, nil
+}
+
+// HandleConversationEvent implements WebhookUseCase.
+// It turns conversation events into one delivery per subscribed webhook.
+func (w *webhookUseCase) HandleConversationEvent(ctx context.Context, message *domain.WebSocketMessage) error {
+	ctx, span := w.obs.StartSpan(ctx, "WebhookUseCase.HandleConversationEvent")
+	defer span()
+	logger := w.obs.Logger.WithContext(ctx)
+
+	var event string
+	switch message.Type {
+	case domain.WsMessage:
+		event = domain.WebhookEventMessageCreated
+	case domain.WsMessageUpdated:
+		event = domain.WebhookEventMessageUpdated
+	case domain.WsMemberAdded:
+		event = domain.WebhookEventMemberAdded
+	case domain.WsMemberRemoved:
+		event = domain.WebhookEventMemberRemoved
+		if message.Payload["user_id"] == message.Payload["removed_by"] {
+			event = domain.WebhookEventMemberLeft
+		}
+	default:
+		return nil
+	}
+	conversationID, _ := message.Payload["conversation_id"].(string)
+	if conversationID == "" {
+		return nil
+	}
+
+	webhooks, err := w.webhookRepository.GetListWebhookByConversationID(ctx, conversationID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var deliveries []*domain.WebhookDelivery
+	for _, webhook := range webhooks {
+		if !webhook.IsActive || !webhook.HasEvent(event) {
+			continue
+		}
+		id, err := uuid.NewID()
+		if err != nil {
+			return err
+		}
+		payload, err := json.Marshal(map[string]any{
+			"id":              id,
+			"event":           event,
+			"conversation_id": conversationID,
+			"created_at":      now,
+			"data":            message.Payload,
+		})
+		if err != nil {
+			return err
+		}
+		deliveries = append(deliveries, &domain.WebhookDelivery{
+			ID:        id,
+			WebhookID: webhook.ID,
+			Event:     event,
+			Payload:   string(payload),
+			Status:    domain.WebhookDeliveryStatusPending,
+			// picked up by the retry loop if the publish below is lost
+			NextRetryAt: pointer.ToPtr(now.Add(webhookDeliveryLease)),
+			CreatedAt:   &now,
+			UpdatedAt:   &now,
+		})
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	err = w.webhookRepository.CreateWebhookDeliveries(ctx, deliveries)
+	if err != nil {
+		return err
+	}
+	for _, delivery := range deliveries {
+		err = w.publisher.Publish(ctx, domain.SUBJECT_WEBHOOK_DELIVERY, domain.WebhookDelivery{ID: delivery.ID})
+		if err != nil {
+			logger.Error("failed to publish webhook delivery", err, delivery.ID)
+		}
+	}
+	return nil
+}
+
+// HandleWebhookDelivery implements WebhookUseCase.
+// Failed attempts are rescheduled with exponential backoff, the retry loop
+// republishes them once they are due.
+func (w *webhookUseCase) HandleWebhookDelivery(ctx context.Context, data domain.WebhookDelivery) error {
+	ctx, span := w.obs.StartSpan(ctx, "WebhookUseCase.HandleWebhookDelivery")
+	defer span()
+	logger := w.obs.Logger.WithContext(ctx)
+
+	delivery, err := w.webhookRepository.GetWebhookDeliveryByID(ctx, data.ID)
+	if err != nil && err != pgx.ErrNoRows {
+		return err
+	}
+	if err == pgx.ErrNoRows || delivery.Status != domain.WebhookDeliveryStatusPending {
+		return nil
+	}
+
+	now := time.Now()
+	delivery.UpdatedAt = &now
+	webhook, err := w.webhookRepository.GetWebhookByID(ctx, delivery.WebhookID)
+	if err != nil && err != pgx.ErrNoRows {
+		return err
+	}
+	if err == pgx.ErrNoRows || !webhook.IsActive {
+		delivery.Status = domain.WebhookDeliveryStatusFailed
+		delivery.Error = "webhook is disabled"
+		delivery.NextRetryAt = nil
+		return w.webhookRepository.UpdateWebhookDelivery(ctx, delivery)
+	}
+
+	delivery.Attempt++
+	statusCode, err := w.send(ctx, webhook, delivery)
+	delivery.ResponseStatus = statusCode
+	if err == nil {
+		delivery.Status = domain.WebhookDeliveryStatusSuccess
+		delivery.Error = ""
+		delivery.NextRetryAt = nil
+		err = w.webhookRepository.UpdateWebhookDelivery(ctx, delivery)
+		if err != nil {
+			return err
+		}
+		return w.webhookRepository.ResetWebhookFailure(ctx, webhook.ID)
+	}
+
+	logger.Error("failed to deliver webhook", err, delivery.ID)
+	delivery.Error = err.Error()
+	disabled, err := w.webhookRepository.IncreaseWebhookFailure(ctx, webhook.ID, webhookDisableThreshold, now)
+	if err != nil {
+		return err
+	}
+	if disabled || delivery.Attempt >= webhookMaxAttempts {
+		delivery.Status = domain.WebhookDeliveryStatusFailed
+		delivery.NextRetryAt = nil
+	} else {
+		backoff := webhookBaseBackoff << (delivery.Attempt - 1)
+		delivery.NextRetryAt = pointer.ToPtr(now.Add(backoff))
+	}
+	return w.webhookRepository.UpdateWebhookDelivery(ctx, delivery)
+}
+
+// RunWebhookRetry implements WebhookUseCase.
+// It blocks until ctx is done, republishing deliveries whose retry is due.
+func (w *webhookUseCase) RunWebhookRetry(ctx context.Context) {
+	ticker := time.NewTicker(webhookRetryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		deliveries, err := w.webhookRepository.ClaimDueWebhookDeliveries(ctx, time.Now(), webhookDeliveryLease, webhookRetryBatchSize)
+		if err != nil {
+			w.obs.Logger.WithContext(ctx).Error("failed to claim webhook deliveries", err)
+			continue
+		}
+		for _, delivery := range deliveries {
+			err = w.publisher.Publish(ctx, domain.SUBJECT_WEBHOOK_DELIVERY, domain.WebhookDelivery{ID: delivery.ID})
+			if err != nil {
+				w.obs.Logger.WithContext(ctx).Error("failed to publish webhook delivery", err, delivery.ID)
+			}
+		}
+	}
+}
+
+// send posts the payload signed with HMAC-SHA256 over "<timestamp>.<body>".
+func (w *webhookUseCase) send(ctx context.Context, webhook *domain.Webhook, delivery *domain.WebhookDelivery) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, webhookRequestTimeout)
+	defer cancel()
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewBufferString(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, delivery.Event)
+	req.Header.Set(WebhookDeliveryHeader, delivery.ID)
+	req.Header.Set(WebhookTimestampHeader, timestamp)
+	req.Header.Set(WebhookSignatureHeader, "sha256="+signWebhookPayload(webhook.Secret, timestamp, delivery.Payload))
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, webhookResponseLimit))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// requireConversationAdmin returns the current user when they are an owner
+// or admin of the conversation.
+func (w *webhookUseCase) requireConversationAdmin(ctx context.Context, conversationID string) (*domain.UserInfo, error) {
+	accountID := ctx.Value(utils.AccountIDKey).(string)
+	user, err := w.userRepository.GetUserByAccountID(ctx, accountID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	if err == pgx.ErrNoRows {
+		return nil, ErrNotFoundAccount
+	}
+	member, err := w.conversationRepository.GetConversationMember(ctx, conversationID, user.ID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	if err == pgx.ErrNoRows {
+		return nil, domain.ErrNotFoundMemberOfConversation
+	}
+	if !member.IsAdmin() {
+		return nil, domain.ErrNotAdminOfConversation
+	}
+	return user, nil
+}
+
+// getManagedWebhook returns the webhook when the current user administers its
+// conversation.
+func (w *webhookUseCase) getManagedWebhook(ctx context.Context, webhookID string) (*domain.Webhook, error) {
+	webhook, err := w.webhookRepository.GetWebhookByID(ctx, webhookID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	if err == pgx.ErrNoRows {
+		return nil, ErrNotFoundWebhook
+	}
+	_, err = w.requireConversationAdmin(ctx, webhook.ConversationID)
+	if err == domain.ErrNotFoundMemberOfConversation {
+		return nil, ErrNotFoundWebhook
+	}
+	if err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func signWebhookPayload(secret string, timestamp string, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func generateWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return webhookSecretPrefix + hex.EncodeToString(b), nil
+}
+
+func toWebhookResponse(webhook *domain.Webhook) *presenter.WebhookResponse {
+	return &presenter.WebhookResponse{
+		ID:             webhook.ID,
+		ConversationID: webhook.ConversationID,
+		CreatedBy:      webhook.CreatedBy,
+		URL:            webhook.URL,
+		Events:         webhook.Events,
+		IsActive:       webhook.IsActive,
+		FailureCount:   webhook.FailureCount,
+		DisabledAt:     webhook.DisabledAt,
+		CreatedAt:      webhook.CreatedAt,
+		UpdatedAt:      webhook.UpdatedAt,
+	}
+}
+
+var _ WebhookUseCase = (*webhookUseCase)(nil)
+
+func NewWebhookUseCase(userRepository domain.UserRepository, conversationRepository domain.ConversationRepository, webhookRepository domain.WebhookRepository, publisher pubsub.Publisher, obs *observability.Observability) WebhookUseCase {
+	return &webhookUseCase{
+		userRepository:         userRepository,
+		conversationRepository: conversationRepository,
+		webhookRepository:      webhookRepository,
+		publisher:              publisher,
+		httpClient:             &http.Client{Timeout: webhookRequestTimeout},
+		obs:                    obs,
+	}
+}
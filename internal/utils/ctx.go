@@ -7,4 +7,5 @@ const (
 	IpAddressKey    contextKey = "ip_address"
 	AccountIDKey    contextKey = "account_id"
 	SessionTokenKey contextKey = "session_token"
+	ApiKeyIDKey     contextKey = "api_key_id"
 )
@@ -0,0 +1,24 @@
+alter table user_info add column if not exists deleted_at timestamptz;
+
+create table if not exists account_deletion_job (
+    id text primary key,
+    user_id text not null unique,
+    account_id text not null,
+    status text not null,
+    step text not null,
+    processed_messages bigint not null default 0,
+    created_at timestamptz default current_timestamp,
+    updated_at timestamptz default current_timestamp,
+    completed_at timestamptz
+);
+
+create index if not exists idx_status_account_deletion_job on account_deletion_job(status);
+
+-- placeholder author for messages of deleted accounts
+insert into account (id, username, password)
+values ('00000000-0000-0000-0000-000000000000', 'deleted-user', '')
+on conflict do nothing;
+
+insert into user_info (id, account_id, type, email, full_name, avatar)
+values ('00000000-0000-0000-0000-000000000000', '00000000-0000-0000-0000-000000000000', 'INTERNAL', 'deleted-user', 'Deleted user', '')
+on conflict do nothing;
@@ -0,0 +1,12 @@
+create table if not exists data_export_job (
+    id text primary key,
+    user_id text not null,
+    account_id text not null,
+    status text not null,
+    object_name text not null default '',
+    created_at timestamptz default current_timestamp,
+    updated_at timestamptz default current_timestamp,
+    completed_at timestamptz
+);
+
+create index if not exists idx_user_id_data_export_job on data_export_job(user_id);
@@ -0,0 +1,26 @@
+create table if not exists bot (
+    id text primary key,
+    owner_id text not null,
+    created_at timestamptz default current_timestamp,
+    updated_at timestamptz default current_timestamp,
+    deleted_at timestamptz,
+    foreign key (id) references user_info(id),
+    foreign key (owner_id) references user_info(id)
+);
+
+create index if not exists idx_owner_id_bot on bot(owner_id);
+
+create table if not exists api_key (
+    id text primary key,
+    bot_id text not null,
+    name text not null default '',
+    prefix text not null,
+    key_hash text not null unique,
+    scopes text[] not null default '{}',
+    created_at timestamptz default current_timestamp,
+    last_used_at timestamptz,
+    revoked_at timestamptz,
+    foreign key (bot_id) references bot(id)
+);
+
+create index if not exists idx_bot_id_api_key on api_key(bot_id);
@@ -0,0 +1,45 @@
+alter table conversation_member add column if not exists role text not null default 'member';
+
+-- the oldest member of a group becomes its owner, both sides of a dm own it
+update conversation_member set role = 'owner'
+where id in (
+    select distinct on (conversation_id) id from conversation_member
+    order by conversation_id, created_at, id
+);
+
+update conversation_member cm set role = 'owner'
+from conversation c
+where c.id = cm.conversation_id and c.type = 'DM';
+
+create table if not exists webhook (
+    id text primary key,
+    conversation_id text not null,
+    created_by text not null,
+    url text not null,
+    secret text not null,
+    events text[] not null default '{}',
+    is_active boolean not null default true,
+    failure_count int not null default 0,
+    disabled_at timestamptz,
+    created_at timestamptz default current_timestamp,
+    updated_at timestamptz default current_timestamp
+);
+
+create index if not exists idx_conversation_id_webhook on webhook(conversation_id);
+
+create table if not exists webhook_delivery (
+    id text primary key,
+    webhook_id text not null,
+    event text not null,
+    payload text not null,
+    status text not null,
+    attempt int not null default 0,
+    response_status int not null default 0,
+    error text not null default '',
+    next_retry_at timestamptz,
+    created_at timestamptz default current_timestamp,
+    updated_at timestamptz default current_timestamp
+);
+
+create index if not exists idx_webhook_id_webhook_delivery on webhook_delivery(webhook_id);
+create index if not exists idx_status_next_retry_at_webhook_delivery on webhook_delivery(status, next_retry_at);
@@ -0,0 +1,17 @@
+alter table message add column if not exists display_name text not null default '';
+
+create table if not exists incoming_webhook (
+    id text primary key,
+    conversation_id text not null,
+    bot_id text not null,
+    created_by text not null,
+    name text not null default '',
+    prefix text not null,
+    token_hash text not null unique,
+    created_at timestamptz default current_timestamp,
+    last_used_at timestamptz,
+    revoked_at timestamptz,
+    foreign key (bot_id) references bot(id)
+);
+
+create index if not exists idx_conversation_id_incoming_webhook on incoming_webhook(conversation_id);
@@ -0,0 +1,24 @@
+alter table conversation_member add column if not exists muted_until timestamptz;
+
+create table if not exists bot_command (
+    id text primary key,
+    bot_id text not null,
+    command text not null,
+    description text not null default '',
+    created_at timestamptz default current_timestamp,
+    foreign key (bot_id) references bot(id),
+    unique (bot_id, command)
+);
+
+create index if not exists idx_command_bot_command on bot_command(command);
+
+create table if not exists bot_command_invocation (
+    id text primary key,
+    bot_id text not null,
+    command text not null,
+    args text not null default '',
+    conversation_id text not null,
+    user_id text not null,
+    created_at timestamptz default current_timestamp,
+    expires_at timestamptz not null
+);
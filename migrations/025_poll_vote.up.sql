@@ -0,0 +1,10 @@
+create table if not exists poll_vote (
+    id text primary key,
+    message_id text not null,
+    option_id text not null,
+    user_id text not null,
+    created_at timestamptz default current_timestamp,
+    unique (message_id, option_id, user_id)
+);
+
+create index if not exists idx_message_id_poll_vote on poll_vote(message_id);
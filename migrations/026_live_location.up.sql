@@ -0,0 +1,10 @@
+create table if not exists live_location (
+    message_id text primary key,
+    conversation_id text not null,
+    user_id text not null,
+    expires_at timestamptz not null,
+    ended_at timestamptz,
+    created_at timestamptz default current_timestamp
+);
+
+create index if not exists idx_expires_at_live_location on live_location(expires_at) where ended_at is null;
@@ -0,0 +1,28 @@
+create table if not exists sticker_pack (
+    id text primary key,
+    name text not null,
+    description text not null default '',
+    thumbnail_url text not null default '',
+    is_default boolean not null default false,
+    created_at timestamptz default current_timestamp,
+    deleted_at timestamptz
+);
+
+create table if not exists sticker (
+    id text primary key,
+    pack_id text not null,
+    emoji text not null default '',
+    url text not null,
+    position int not null default 0,
+    created_at timestamptz default current_timestamp
+);
+
+create index if not exists idx_pack_id_sticker on sticker(pack_id);
+
+create table if not exists user_sticker_pack (
+    user_id text not null,
+    pack_id text not null,
+    position int not null default 0,
+    installed_at timestamptz default current_timestamp,
+    primary key (user_id, pack_id)
+);
@@ -0,0 +1,14 @@
+alter table message add column if not exists thread_id text not null default '';
+
+-- replies join the thread of the message at the top of their reply chain
+with recursive chain as (
+    select id, id as root_id from message where coalesce(reply_to, '') = ''
+    union all
+    select m.id, chain.root_id from message m
+    inner join chain on m.reply_to = chain.id
+)
+update message m set thread_id = chain.root_id
+from chain
+where m.id = chain.id and m.id <> chain.root_id;
+
+create index if not exists idx_thread_id_message on message(thread_id, id) where thread_id <> '';
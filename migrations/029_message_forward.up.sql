@@ -0,0 +1 @@
+alter table message add column if not exists forwarded_from text not null default '';
@@ -0,0 +1,7 @@
+create table if not exists pinned_message (
+    conversation_id text not null,
+    message_id text not null,
+    pinned_by text not null,
+    pinned_at timestamptz default current_timestamp,
+    primary key (conversation_id, message_id)
+);
@@ -0,0 +1,17 @@
+create table if not exists scheduled_message (
+    id text primary key,
+    conversation_id text not null,
+    user_id text not null,
+    type text not null,
+    body text not null,
+    reply_to text not null default '',
+    send_at timestamptz not null,
+    status text not null default 'pending',
+    message_id text not null default '',
+    error text not null default '',
+    created_at timestamptz default current_timestamp,
+    updated_at timestamptz default current_timestamp
+);
+
+create index if not exists idx_send_at_scheduled_message on scheduled_message(send_at) where status in ('pending', 'sending');
+create index if not exists idx_user_id_scheduled_message on scheduled_message(user_id, send_at);
@@ -0,0 +1,6 @@
+alter table conversation add column if not exists message_ttl text not null default 'off';
+
+alter table message add column if not exists expires_at timestamptz;
+
+create index if not exists idx_expires_at_message on message(expires_at) where expires_at is not null;
+create index if not exists idx_forwarded_from_message on message(forwarded_from) where forwarded_from <> '';
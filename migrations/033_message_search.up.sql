@@ -0,0 +1,17 @@
+create extension if not exists unaccent;
+create extension if not exists pg_trgm;
+
+-- unaccent is only stable, indexes need an immutable wrapper with a fixed dictionary
+create or replace function immutable_unaccent(text) returns text as $$
+    select public.unaccent('public.unaccent'::regdictionary, $1)
+$$ language sql immutable parallel safe strict;
+
+-- only messages typed by people are searched, other bodies are urls or json
+create index if not exists idx_message_body_tsv on message
+    using gin (to_tsvector('simple', immutable_unaccent(body)))
+    where type in ('text', 'action');
+
+-- partial words and typos fall back to trigram matching
+create index if not exists idx_message_body_trgm on message
+    using gin (immutable_unaccent(lower(body)) gin_trgm_ops)
+    where type in ('text', 'action');
@@ -0,0 +1,29 @@
+create table if not exists conversation_unread (
+    user_id text not null,
+    conversation_id text not null,
+    unread_count int not null default 0,
+    last_message_id text not null default '', -- newest message already counted
+    updated_at timestamptz default current_timestamp,
+    primary key (user_id, conversation_id)
+);
+
+create index if not exists idx_conversation_id_conversation_unread on conversation_unread(conversation_id);
+create index if not exists idx_conversation_id_id_message on message(conversation_id, id);
+
+-- counts start from what every member has seen so far
+insert into conversation_unread (user_id, conversation_id, unread_count, last_message_id)
+select cm.user_id, cm.conversation_id,
+    (
+        select count(*) from message m
+        where m.conversation_id = cm.conversation_id
+        and m.user_id <> cm.user_id
+        and m.type <> 'system'
+        and m.deleted_at is null
+        and (m.expires_at is null or m.expires_at > now())
+        and m.id > coalesce(sm.message_id, '')
+    ),
+    coalesce(c.last_message_id, '')
+from conversation_member cm
+inner join conversation c on c.id = cm.conversation_id
+left join seen_message sm on sm.conversation_id = cm.conversation_id and sm.user_id = cm.user_id
+on conflict (user_id, conversation_id) do nothing;
@@ -0,0 +1,6 @@
+create table if not exists message_delivery (
+    message_id text not null,
+    user_id text not null,
+    delivered_at timestamptz default current_timestamp,
+    primary key (message_id, user_id)
+);
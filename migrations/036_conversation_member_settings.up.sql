@@ -0,0 +1,4 @@
+alter table conversation_member add column if not exists archived_at timestamptz;
+alter table conversation_member add column if not exists pin_order int; -- null when the conversation is not pinned
+
+create index if not exists idx_user_id_pin_order_conversation_member on conversation_member(user_id, pin_order) where pin_order is not null;
@@ -0,0 +1,43 @@
+-- joins through invites rely on one member row per user, keep the strongest
+-- role then the oldest row
+delete from conversation_member
+where id not in (
+    select distinct on (conversation_id, user_id) id
+    from conversation_member
+    order by conversation_id, user_id, case role when 'owner' then 0 when 'admin' then 1 else 2 end, created_at nulls last, id
+);
+
+create unique index if not exists idx_conversation_id_user_id_conversation_member on conversation_member(conversation_id, user_id);
+
+create table if not exists conversation_invite (
+    id text primary key,
+    conversation_id text not null,
+    code text not null unique,
+    created_by text not null,
+    expires_at timestamptz,
+    max_uses int not null default 0, -- 0 is unlimited
+    use_count int not null default 0,
+    requires_approval boolean not null default false,
+    revoked_at timestamptz,
+    created_at timestamptz default current_timestamp,
+    updated_at timestamptz default current_timestamp,
+    foreign key (conversation_id) references conversation(id)
+);
+
+create index if not exists idx_conversation_id_conversation_invite on conversation_invite(conversation_id, created_at);
+
+create table if not exists conversation_join_request (
+    id text primary key,
+    invite_id text not null,
+    conversation_id text not null,
+    user_id text not null,
+    status text not null default 'pending',
+    reviewed_by text not null default '',
+    reviewed_at timestamptz,
+    created_at timestamptz default current_timestamp,
+    updated_at timestamptz default current_timestamp,
+    foreign key (invite_id) references conversation_invite(id),
+    foreign key (conversation_id) references conversation(id)
+);
+
+create unique index if not exists idx_conversation_id_user_id_conversation_join_request on conversation_join_request(conversation_id, user_id) where status = 'pending';
@@ -0,0 +1,2 @@
+-- expired attachments are only removed when no other row points at the file
+create index if not exists idx_body_attachment_message on message using hash (body) where type in ('image', 'video', 'audio', 'file');
@@ -0,0 +1,13 @@
+package hash
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// HashToken hashes a high entropy token such as an api key. Unlike passwords
+// the result is deterministic so it can be looked up directly.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}